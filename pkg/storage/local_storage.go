@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStorage keeps pastes as files under Root. It is meant for local development
+// and for running the services without AWS.
+type LocalStorage struct {
+	Root string
+}
+
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage path: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStorage{Root: abs}, nil
+}
+
+// GetPaste reads the whole paste from disk.
+func (storage *LocalStorage) GetPaste(key string) ([]byte, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data from storage: %w", mapFSError(err))
+	}
+	return data, nil
+}
+
+// UploadPaste writes the paste to disk.
+func (storage *LocalStorage) UploadPaste(key string, data []byte) error {
+	return storage.UploadPasteStream(context.Background(), key, bytes.NewReader(data))
+}
+
+// DeletePaste removes the paste from disk. Deleting a missing paste is not an error.
+func (storage *LocalStorage) DeletePaste(key string) error {
+	path, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete paste: %w", err)
+	}
+	return nil
+}
+
+// GetPasteStream opens the paste file for reading. The caller must close it.
+func (storage *LocalStorage) GetPasteStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data from storage: %w", mapFSError(err))
+	}
+	return file, nil
+}
+
+// GetPasteRange opens the file positioned at offset and stops reading after length bytes.
+func (storage *LocalStorage) GetPasteRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	file, err := storage.GetPasteStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.(*os.File).Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek in paste: %w", err)
+	}
+	if length <= 0 {
+		return file, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+// UploadPasteStream writes body into a temporary file and renames it into place once
+// it is complete and synced, so readers never observe a partially written paste.
+func (storage *LocalStorage) UploadPasteStream(ctx context.Context, key string, body io.Reader) error {
+	path, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create paste directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, &contextReader{ctx: ctx, r: body}); err != nil {
+		return fmt.Errorf("failed to stream paste to storage: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync paste to disk: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close paste file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move paste into place: %w", err)
+	}
+	return nil
+}
+
+// StatPaste describes the paste file. The ETag is the MD5 of the content, like S3 single part uploads.
+func (storage *LocalStorage) StatPaste(ctx context.Context, key string) (*ObjectInfo, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat paste: %w", mapFSError(err))
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat paste: %w", err)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read paste: %w", err)
+	}
+
+	hash := md5.New()
+	hash.Write(head[:n])
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, fmt.Errorf("failed to read paste: %w", err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  http.DetectContentType(head[:n]),
+		ETag:         hex.EncodeToString(hash.Sum(nil)),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+// DeletePastes removes every listed paste, stopping at the first failure.
+func (storage *LocalStorage) DeletePastes(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := storage.DeletePaste(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CopyPaste copies the file of srcKey to dstKey, dstKey is replaced atomically.
+func (storage *LocalStorage) CopyPaste(ctx context.Context, srcKey, dstKey string) error {
+	src, err := storage.GetPasteStream(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return storage.UploadPasteStream(ctx, dstKey, src)
+}
+
+// PresignGetURL returns a file:// URL of the paste. Local storage cannot sign URLs,
+// so expires is ignored and the URL is only usable on the same host.
+func (storage *LocalStorage) PresignGetURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return storage.fileURL(key)
+}
+
+// PresignPutURL returns a file:// URL where the paste is expected to be written.
+func (storage *LocalStorage) PresignPutURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return storage.fileURL(key)
+}
+
+func (storage *LocalStorage) fileURL(key string) (string, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(), nil
+}
+
+// path maps a key to a file under Root, rejecting keys that would escape it.
+func (storage *LocalStorage) path(key string) (string, error) {
+	if key == "" {
+		return "", errors.New("key cannot be empty")
+	}
+	path := filepath.Join(storage.Root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, storage.Root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+	return path, nil
+}
+
+func mapFSError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// contextReader stops a copy as soon as the context is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
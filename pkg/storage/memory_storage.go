@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data         []byte
+	lastModified time.Time
+}
+
+// MemoryStorage keeps pastes in process memory. Its content is lost on restart,
+// so it is only suitable for tests and throwaway environments.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{objects: make(map[string]memoryObject)}
+}
+
+// GetPaste returns a copy of the stored paste.
+func (storage *MemoryStorage) GetPaste(key string) ([]byte, error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	obj, ok := storage.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("failed to load data from storage: %w", ErrNotFound)
+	}
+	return bytes.Clone(obj.data), nil
+}
+
+// UploadPaste stores a copy of data under key.
+func (storage *MemoryStorage) UploadPaste(key string, data []byte) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.objects[key] = memoryObject{data: bytes.Clone(data), lastModified: time.Now()}
+	return nil
+}
+
+// DeletePaste removes the paste. Deleting a missing paste is not an error.
+func (storage *MemoryStorage) DeletePaste(key string) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	delete(storage.objects, key)
+	return nil
+}
+
+// GetPasteStream returns a reader over a snapshot of the stored paste.
+func (storage *MemoryStorage) GetPasteStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, err := storage.GetPaste(key)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// GetPasteRange returns a reader over part of a snapshot of the stored paste.
+func (storage *MemoryStorage) GetPasteRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	data, err := storage.GetPaste(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[min(offset, int64(len(data))):]
+	if length > 0 {
+		data = data[:min(length, int64(len(data)))]
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// UploadPasteStream reads body to the end and stores it only if the whole stream was read.
+func (storage *MemoryStorage) UploadPasteStream(ctx context.Context, key string, body io.Reader) error {
+	data, err := io.ReadAll(&contextReader{ctx: ctx, r: body})
+	if err != nil {
+		return fmt.Errorf("failed to stream paste to storage: %w", err)
+	}
+	return storage.UploadPaste(key, data)
+}
+
+// StatPaste describes the stored paste. The ETag is the MD5 of the content, like S3 single part uploads.
+func (storage *MemoryStorage) StatPaste(ctx context.Context, key string) (*ObjectInfo, error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	obj, ok := storage.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("failed to stat paste: %w", ErrNotFound)
+	}
+	sum := md5.Sum(obj.data)
+	return &ObjectInfo{
+		Key:          key,
+		Size:         int64(len(obj.data)),
+		ContentType:  http.DetectContentType(obj.data),
+		ETag:         hex.EncodeToString(sum[:]),
+		LastModified: obj.lastModified,
+	}, nil
+}
+
+// DeletePastes removes every listed paste.
+func (storage *MemoryStorage) DeletePastes(ctx context.Context, keys []string) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	for _, key := range keys {
+		delete(storage.objects, key)
+	}
+	return nil
+}
+
+// CopyPaste stores a copy of the paste of srcKey under dstKey.
+func (storage *MemoryStorage) CopyPaste(ctx context.Context, srcKey, dstKey string) error {
+	data, err := storage.GetPaste(srcKey)
+	if err != nil {
+		return err
+	}
+	return storage.UploadPaste(dstKey, data)
+}
+
+// PresignGetURL returns a memory:// URL identifying the paste. It cannot be fetched
+// over the network, it only lets callers exercise the presign flow in tests.
+func (storage *MemoryStorage) PresignGetURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return memoryURL(key, expires), nil
+}
+
+// PresignPutURL returns a memory:// URL identifying the paste, see PresignGetURL.
+func (storage *MemoryStorage) PresignPutURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return memoryURL(key, expires), nil
+}
+
+func memoryURL(key string, expires time.Duration) string {
+	u := url.URL{Scheme: "memory", Path: "/" + key}
+	u.RawQuery = url.Values{"expires": {time.Now().Add(expires).UTC().Format(time.RFC3339)}}.Encode()
+	return u.String()
+}
@@ -2,8 +2,10 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	"time"
 
@@ -11,11 +13,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
+// maxDeleteBatch is the largest number of keys S3 accepts in one DeleteObjects request.
+const maxDeleteBatch = 1000
+
 type S3Storage struct {
-	Bucket string
-	S3     *s3.Client
+	Bucket  string
+	S3      *s3.Client
+	Presign *s3.PresignClient
 }
 
 func NewS3Storage(bucket, region string) (*S3Storage, error) {
@@ -29,8 +37,9 @@ func NewS3Storage(bucket, region string) (*S3Storage, error) {
 	s3Client := s3.NewFromConfig(cfg)
 
 	return &S3Storage{
-		Bucket: bucket,
-		S3:     s3Client,
+		Bucket:  bucket,
+		S3:      s3Client,
+		Presign: s3.NewPresignClient(s3Client),
 	}, nil
 }
 
@@ -45,7 +54,7 @@ func (storage *S3Storage) GetPaste(key string) ([]byte, error) {
 		Key:    aws.String(key),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load data from storage: %w", err)
+		return nil, fmt.Errorf("failed to load data from storage: %w", mapS3Error(err))
 	}
 	defer res.Body.Close()
 
@@ -82,6 +91,23 @@ func (storage *S3Storage) UploadPaste(key string, data []byte) error {
 	return nil
 }
 
+// UploadPasteStream streams paste data from body to S3 in multipart chunks,
+// so the whole object never has to be held in memory.
+func (storage *S3Storage) UploadPasteStream(ctx context.Context, key string, body io.Reader) error {
+	uploader := manager.NewUploader(storage.S3)
+
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream paste to storage: %w", err)
+	}
+
+	return nil
+}
+
 // DeletePaste deletes a paste from S3
 func (storage *S3Storage) DeletePaste(key string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
@@ -97,3 +123,131 @@ func (storage *S3Storage) DeletePaste(key string) error {
 	}
 	return nil
 }
+
+// GetPasteStream returns the object body as a stream. The caller must close it.
+func (storage *S3Storage) GetPasteStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := storage.S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data from storage: %w", mapS3Error(err))
+	}
+	return res.Body, nil
+}
+
+// GetPasteRange streams part of the object using an HTTP range request.
+func (storage *S3Storage) GetPasteRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+	res, err := storage.S3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(byteRange),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data from storage: %w", mapS3Error(err))
+	}
+	return res.Body, nil
+}
+
+// StatPaste returns the object attributes without downloading its content.
+func (storage *S3Storage) StatPaste(ctx context.Context, key string) (*ObjectInfo, error) {
+	res, err := storage.S3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat paste: %w", mapS3Error(err))
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(res.ContentLength),
+		ContentType:  aws.ToString(res.ContentType),
+		ETag:         strings.Trim(aws.ToString(res.ETag), `"`),
+		LastModified: aws.ToTime(res.LastModified),
+	}, nil
+}
+
+// DeletePastes deletes the objects in batches of at most maxDeleteBatch keys.
+func (storage *S3Storage) DeletePastes(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += maxDeleteBatch {
+		end := min(start+maxDeleteBatch, len(keys))
+
+		objects := make([]types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		output, err := storage.S3.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(storage.Bucket),
+			Delete: &types.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete pastes: %w", err)
+		}
+		if len(output.Errors) > 0 {
+			failed := output.Errors[0]
+			return fmt.Errorf("failed to delete %d pastes, first %s: %s", len(output.Errors), aws.ToString(failed.Key), aws.ToString(failed.Message))
+		}
+	}
+	return nil
+}
+
+// CopyPaste copies the object within the bucket without downloading it.
+func (storage *S3Storage) CopyPaste(ctx context.Context, srcKey, dstKey string) error {
+	_, err := storage.S3.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(storage.Bucket),
+		CopySource: aws.String(url.PathEscape(storage.Bucket + "/" + srcKey)),
+		Key:        aws.String(dstKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy paste: %w", mapS3Error(err))
+	}
+	return nil
+}
+
+// PresignGetURL generates a presigned URL to download the object directly from S3.
+func (storage *S3Storage) PresignGetURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	req, err := storage.Presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download url: %w", err)
+	}
+	return req.URL, nil
+}
+
+// PresignPutURL generates a presigned URL to upload the object directly to S3.
+func (storage *S3Storage) PresignPutURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	req, err := storage.Presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(storage.Bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload url: %w", err)
+	}
+	return req.URL, nil
+}
+
+// mapS3Error translates the S3 "missing object" errors into ErrNotFound. CopyObject reports a
+// missing source only through the error code, not as a typed error.
+func mapS3Error(err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return ErrNotFound
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
@@ -1,7 +1,105 @@
 package storage
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	DriverS3     = "s3"
+	DriverLocal  = "local"
+	DriverMemory = "memory"
+)
+
+// ErrNotFound is returned when the requested object does not exist in the storage.
+var ErrNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object without its content.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
 type Storage interface {
 	GetPaste(key string) ([]byte, error)       // Retrieve post data from blob storage
 	UploadPaste(key string, data []byte) error // Upload post data to blob storage
 	DeletePaste(key string) error
+
+	GetPasteStream(ctx context.Context, key string) (io.ReadCloser, error)   // Stream post data from blob storage
+	UploadPasteStream(ctx context.Context, key string, body io.Reader) error // Stream post data to blob storage
+	StatPaste(ctx context.Context, key string) (*ObjectInfo, error)          // Describe stored post data
+	DeletePastes(ctx context.Context, keys []string) error                   // Delete many objects in one call
+	CopyPaste(ctx context.Context, srcKey, dstKey string) error              // Copy stored post data to another key
+
+	// Stream length bytes of post data starting at offset, a length of 0 reads to the end
+	GetPasteRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+
+	PresignGetURL(ctx context.Context, key string, expires time.Duration) (string, error) // URL to download post data directly
+	PresignPutURL(ctx context.Context, key string, expires time.Duration) (string, error) // URL to upload post data directly
+}
+
+// VersionObjectKey returns the storage key under which a single revision of a paste is kept.
+// Revisions are never overwritten, every edit is stored under a new version.
+func VersionObjectKey(key string, version int32) string {
+	return fmt.Sprintf("versions/%s/%d", key, version)
+}
+
+// UploadObjectKey returns the storage key a presigned upload of a revision is written to. The
+// content is copied to VersionObjectKey once the upload is completed, so the presigned URL
+// cannot overwrite content that is already served.
+func UploadObjectKey(key string, version int32) string {
+	return fmt.Sprintf("uploads/%s/%d", key, version)
+}
+
+// FileObjectKey returns the storage key of a file of a bundle, position is the index of the file in the bundle.
+func FileObjectKey(key string, position int32) string {
+	return fmt.Sprintf("files/%s/%d", key, position)
+}
+
+// Config selects and configures a storage driver.
+type Config struct {
+	Driver string `yaml:"driver" mapstructure:"driver"` // s3 (default), local or memory
+	Bucket string `yaml:"bucket" mapstructure:"bucket"` // s3 only
+	Region string `yaml:"region" mapstructure:"region"` // s3 only
+	Path   string `yaml:"path" mapstructure:"path"`     // local only: root directory of the stored objects
+}
+
+// Validate checks that the settings required by the selected driver are present.
+func (cfg *Config) Validate() error {
+	switch cfg.Driver {
+	case "", DriverS3:
+		if cfg.Bucket == "" || cfg.Region == "" {
+			return errors.New("S3 configuration is incomplete")
+		}
+	case DriverLocal:
+		if cfg.Path == "" {
+			return errors.New("local storage path is not set")
+		}
+	case DriverMemory:
+	default:
+		return fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+	return nil
+}
+
+// New creates the storage driver selected by the config.
+func New(cfg *Config) (Storage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Driver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.Path)
+	case DriverMemory:
+		return NewMemoryStorage(), nil
+	default:
+		return NewS3Storage(cfg.Bucket, cfg.Region)
+	}
 }
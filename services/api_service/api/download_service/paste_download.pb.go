@@ -21,15 +21,216 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Order of the pastes listed by DownloadByUserId.
+type PasteSort int32
+
+const (
+	PasteSort_PASTE_SORT_CREATED_AT PasteSort = 0 // Newest first.
+	PasteSort_PASTE_SORT_EXPIRATION PasteSort = 1 // Expiring soonest first.
+	PasteSort_PASTE_SORT_TITLE      PasteSort = 2 // Alphabetical, untitled pastes last.
+)
+
+// Enum value maps for PasteSort.
+var (
+	PasteSort_name = map[int32]string{
+		0: "PASTE_SORT_CREATED_AT",
+		1: "PASTE_SORT_EXPIRATION",
+		2: "PASTE_SORT_TITLE",
+	}
+	PasteSort_value = map[string]int32{
+		"PASTE_SORT_CREATED_AT": 0,
+		"PASTE_SORT_EXPIRATION": 1,
+		"PASTE_SORT_TITLE":      2,
+	}
+)
+
+func (x PasteSort) Enum() *PasteSort {
+	p := new(PasteSort)
+	*p = x
+	return p
+}
+
+func (x PasteSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PasteSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_paste_download_paste_download_proto_enumTypes[0].Descriptor()
+}
+
+func (PasteSort) Type() protoreflect.EnumType {
+	return &file_paste_download_paste_download_proto_enumTypes[0]
+}
+
+func (x PasteSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PasteSort.Descriptor instead.
+func (PasteSort) EnumDescriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{0}
+}
+
+// Who is allowed to read a paste.
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_PUBLIC   Visibility = 0 // Anyone holding the key, listed in search.
+	Visibility_VISIBILITY_UNLISTED Visibility = 1 // Anyone holding the key, never listed.
+	Visibility_VISIBILITY_PRIVATE  Visibility = 2 // Only the owner.
+	Visibility_VISIBILITY_SHARED   Visibility = 3 // The owner and the users the paste is shared with.
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_PUBLIC",
+		1: "VISIBILITY_UNLISTED",
+		2: "VISIBILITY_PRIVATE",
+		3: "VISIBILITY_SHARED",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_PUBLIC":   0,
+		"VISIBILITY_UNLISTED": 1,
+		"VISIBILITY_PRIVATE":  2,
+		"VISIBILITY_SHARED":   3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_paste_download_paste_download_proto_enumTypes[1].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_paste_download_paste_download_proto_enumTypes[1]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{1}
+}
+
+// Output formats of RenderPaste.
+type RenderFormat int32
+
+const (
+	RenderFormat_RENDER_FORMAT_HTML RenderFormat = 0 // Standalone HTML document with inline styles.
+	RenderFormat_RENDER_FORMAT_ANSI RenderFormat = 1 // 256 color terminal escape sequences.
+)
+
+// Enum value maps for RenderFormat.
+var (
+	RenderFormat_name = map[int32]string{
+		0: "RENDER_FORMAT_HTML",
+		1: "RENDER_FORMAT_ANSI",
+	}
+	RenderFormat_value = map[string]int32{
+		"RENDER_FORMAT_HTML": 0,
+		"RENDER_FORMAT_ANSI": 1,
+	}
+)
+
+func (x RenderFormat) Enum() *RenderFormat {
+	p := new(RenderFormat)
+	*p = x
+	return p
+}
+
+func (x RenderFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RenderFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_paste_download_paste_download_proto_enumTypes[2].Descriptor()
+}
+
+func (RenderFormat) Type() protoreflect.EnumType {
+	return &file_paste_download_paste_download_proto_enumTypes[2]
+}
+
+func (x RenderFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RenderFormat.Descriptor instead.
+func (RenderFormat) EnumDescriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{2}
+}
+
+// Archive formats of DownloadBundle.
+type ArchiveFormat int32
+
+const (
+	ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ ArchiveFormat = 0
+	ArchiveFormat_ARCHIVE_FORMAT_ZIP    ArchiveFormat = 1
+)
+
+// Enum value maps for ArchiveFormat.
+var (
+	ArchiveFormat_name = map[int32]string{
+		0: "ARCHIVE_FORMAT_TAR_GZ",
+		1: "ARCHIVE_FORMAT_ZIP",
+	}
+	ArchiveFormat_value = map[string]int32{
+		"ARCHIVE_FORMAT_TAR_GZ": 0,
+		"ARCHIVE_FORMAT_ZIP":    1,
+	}
+)
+
+func (x ArchiveFormat) Enum() *ArchiveFormat {
+	p := new(ArchiveFormat)
+	*p = x
+	return p
+}
+
+func (x ArchiveFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArchiveFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_paste_download_paste_download_proto_enumTypes[3].Descriptor()
+}
+
+func (ArchiveFormat) Type() protoreflect.EnumType {
+	return &file_paste_download_paste_download_proto_enumTypes[3]
+}
+
+func (x ArchiveFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArchiveFormat.Descriptor instead.
+func (ArchiveFormat) EnumDescriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{3}
+}
+
 // Request message for downloading a slice of objects by userId.
 type DownloadByUserIdRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`  // The unique userId to filter objects by.
-	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`   // The maximum number of objects to retrieve (for pagination).
-	Offset int32  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // The starting point in the collection of objects (for pagination).
+	UserId       string    `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`                                  // The unique userId to filter objects by.
+	Limit        int32     `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                   // The maximum number of objects to retrieve (for pagination).
+	Offset       int32     `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                                 // The starting point in the collection of objects (for pagination). Ignored when a cursor is given.
+	Tag          string    `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`                                        // Only pastes with this tag, empty for all.
+	CollectionId int64     `protobuf:"varint,5,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // Only pastes in this collection, 0 for all.
+	Sort         PasteSort `protobuf:"varint,6,opt,name=sort,proto3,enum=pastedownload.PasteSort" json:"sort,omitempty"`
+	Cursor       string    `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page, empty for the first page.
 }
 
 func (x *DownloadByUserIdRequest) Reset() {
@@ -83,6 +284,34 @@ func (x *DownloadByUserIdRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *DownloadByUserIdRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DownloadByUserIdRequest) GetCollectionId() int64 {
+	if x != nil {
+		return x.CollectionId
+	}
+	return 0
+}
+
+func (x *DownloadByUserIdRequest) GetSort() PasteSort {
+	if x != nil {
+		return x.Sort
+	}
+	return PasteSort_PASTE_SORT_CREATED_AT
+}
+
+func (x *DownloadByUserIdRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // Response message containing the slice of downloaded objects.
 type DownloadByUserIdResponse struct {
 	state         protoimpl.MessageState
@@ -91,6 +320,7 @@ type DownloadByUserIdResponse struct {
 
 	Objects    []*Metadata `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`                          // A list of objects matching the userId.
 	TotalCount int32       `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // The total number of objects matching the userId (for pagination).
+	NextCursor string      `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`  // Cursor of the next page, empty on the last page.
 }
 
 func (x *DownloadByUserIdResponse) Reset() {
@@ -137,13 +367,22 @@ func (x *DownloadByUserIdResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *DownloadByUserIdResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // Request message for downloading an object by key.
 type DownloadByKeyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // The unique key of the object to be downloaded.
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                     // The unique key of the object to be downloaded.
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`           // Access password, required for password-protected pastes.
+	UserId   string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the caller, empty for anonymous requests.
 }
 
 func (x *DownloadByKeyRequest) Reset() {
@@ -183,14 +422,30 @@ func (x *DownloadByKeyRequest) GetKey() string {
 	return ""
 }
 
+func (x *DownloadByKeyRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DownloadByKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 // Response message for downloading an object by key.
 type DownloadByKeyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Metadata    *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	DownlaodUrl string    `protobuf:"bytes,2,opt,name=downlaod_url,json=downlaodUrl,proto3" json:"downlaod_url,omitempty"` // The binary content of the downloaded object.
+	Metadata    *Metadata         `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	DownlaodUrl string            `protobuf:"bytes,2,opt,name=downlaod_url,json=downlaodUrl,proto3" json:"downlaod_url,omitempty"` // The binary content of the downloaded object.
+	Encryption  *EncryptionHeader `protobuf:"bytes,3,opt,name=encryption,proto3" json:"encryption,omitempty"`                      // Set for client side encrypted pastes.
+	Files       []*PasteFile      `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`                                // Manifest of a bundle, the download URL is then empty.
 }
 
 func (x *DownloadByKeyResponse) Reset() {
@@ -237,21 +492,140 @@ func (x *DownloadByKeyResponse) GetDownlaodUrl() string {
 	return ""
 }
 
+func (x *DownloadByKeyResponse) GetEncryption() *EncryptionHeader {
+	if x != nil {
+		return x.Encryption
+	}
+	return nil
+}
+
+func (x *DownloadByKeyResponse) GetFiles() []*PasteFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// Envelope of client side encrypted content. The key itself never reaches the server.
+type EncryptionHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm     string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"` // Content cipher: AES-256-GCM or XChaCha20-Poly1305.
+	Kdf           string `protobuf:"bytes,2,opt,name=kdf,proto3" json:"kdf,omitempty"`             // Key derivation: none, PBKDF2-SHA256 or argon2id.
+	KdfSalt       []byte `protobuf:"bytes,3,opt,name=kdf_salt,json=kdfSalt,proto3" json:"kdf_salt,omitempty"`
+	KdfIterations int32  `protobuf:"varint,4,opt,name=kdf_iterations,json=kdfIterations,proto3" json:"kdf_iterations,omitempty"` // Iterations of PBKDF2 or time cost of argon2id.
+	KdfMemoryKib  int32  `protobuf:"varint,5,opt,name=kdf_memory_kib,json=kdfMemoryKib,proto3" json:"kdf_memory_kib,omitempty"`  // Memory cost of argon2id.
+	Nonce         []byte `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	KeyCheck      []byte `protobuf:"bytes,7,opt,name=key_check,json=keyCheck,proto3" json:"key_check,omitempty"` // Derived from the key, lets clients detect a wrong key before decrypting.
+}
+
+func (x *EncryptionHeader) Reset() {
+	*x = EncryptionHeader{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionHeader) ProtoMessage() {}
+
+func (x *EncryptionHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionHeader.ProtoReflect.Descriptor instead.
+func (*EncryptionHeader) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EncryptionHeader) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetKdf() string {
+	if x != nil {
+		return x.Kdf
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetKdfSalt() []byte {
+	if x != nil {
+		return x.KdfSalt
+	}
+	return nil
+}
+
+func (x *EncryptionHeader) GetKdfIterations() int32 {
+	if x != nil {
+		return x.KdfIterations
+	}
+	return 0
+}
+
+func (x *EncryptionHeader) GetKdfMemoryKib() int32 {
+	if x != nil {
+		return x.KdfMemoryKib
+	}
+	return 0
+}
+
+func (x *EncryptionHeader) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *EncryptionHeader) GetKeyCheck() []byte {
+	if x != nil {
+		return x.KeyCheck
+	}
+	return nil
+}
+
 // Metadata for tracking the object.
 type Metadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key         string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiredDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expired_date,json=expiredDate,proto3" json:"expired_date,omitempty"`
+	Key               string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiredDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expired_date,json=expiredDate,proto3" json:"expired_date,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,5,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	ViewLimited       bool                   `protobuf:"varint,6,opt,name=view_limited,json=viewLimited,proto3" json:"view_limited,omitempty"`          // Set for burn-after-read and max_views pastes.
+	RemainingViews    int32                  `protobuf:"varint,7,opt,name=remaining_views,json=remainingViews,proto3" json:"remaining_views,omitempty"` // Downloads left before a view limited paste is deleted.
+	Visibility        Visibility             `protobuf:"varint,8,opt,name=visibility,proto3,enum=pastedownload.Visibility" json:"visibility,omitempty"`
+	UserId            string                 `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                              // ID of the owner, empty for anonymous pastes.
+	Encrypted         bool                   `protobuf:"varint,10,opt,name=encrypted,proto3" json:"encrypted,omitempty"`                                    // The content is encrypted on the client.
+	Language          string                 `protobuf:"bytes,11,opt,name=language,proto3" json:"language,omitempty"`                                       // Syntax of the content, e.g. "go", empty when unknown.
+	FileCount         int32                  `protobuf:"varint,12,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`                   // Number of files of a bundle, 0 for single file pastes.
+	MetadataVersion   int64                  `protobuf:"varint,13,opt,name=metadata_version,json=metadataVersion,proto3" json:"metadata_version,omitempty"` // Version of the title, expiration and tags, edits are made against it.
+	CollectionId      int64                  `protobuf:"varint,14,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`          // Collection of the owner the paste is in, 0 for none.
+	Tags              []string               `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (x *Metadata) Reset() {
 	*x = Metadata{}
-	mi := &file_paste_download_paste_download_proto_msgTypes[4]
+	mi := &file_paste_download_paste_download_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -263,7 +637,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_download_paste_download_proto_msgTypes[4]
+	mi := &file_paste_download_paste_download_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +650,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{4}
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Metadata) GetKey() string {
@@ -307,119 +681,1596 @@ func (x *Metadata) GetExpiredDate() *timestamppb.Timestamp {
 	return nil
 }
 
-var File_paste_download_paste_download_proto protoreflect.FileDescriptor
+func (x *Metadata) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
 
-var file_paste_download_paste_download_proto_rawDesc = []byte{
-	0x0a, 0x23, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
-	0x2f, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5f, 0x0a, 0x17, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x6e, 0x0a, 0x18, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x07, 0x6f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x28, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x22, 0x6f, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x4b, 0x65,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61,
-	0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21,
-	0x0a, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x61, 0x6f, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x61, 0x6f, 0x64, 0x55, 0x72,
-	0x6c, 0x22, 0xac, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65,
-	0x32, 0xd0, 0x01, 0x0a, 0x0d, 0x50, 0x61, 0x73, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x12, 0x5a, 0x0a, 0x0d, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79,
-	0x4b, 0x65, 0x79, 0x12, 0x23, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x4b, 0x65,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
-	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
-	0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63,
-	0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x26, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x61, 0x73,
-	0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x3b, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f,
-	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Metadata) GetViewLimited() bool {
+	if x != nil {
+		return x.ViewLimited
+	}
+	return false
 }
 
-var (
-	file_paste_download_paste_download_proto_rawDescOnce sync.Once
-	file_paste_download_paste_download_proto_rawDescData = file_paste_download_paste_download_proto_rawDesc
-)
+func (x *Metadata) GetRemainingViews() int32 {
+	if x != nil {
+		return x.RemainingViews
+	}
+	return 0
+}
 
-func file_paste_download_paste_download_proto_rawDescGZIP() []byte {
-	file_paste_download_paste_download_proto_rawDescOnce.Do(func() {
-		file_paste_download_paste_download_proto_rawDescData = protoimpl.X.CompressGZIP(file_paste_download_paste_download_proto_rawDescData)
-	})
-	return file_paste_download_paste_download_proto_rawDescData
+func (x *Metadata) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_PUBLIC
 }
 
-var file_paste_download_paste_download_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_paste_download_paste_download_proto_goTypes = []any{
-	(*DownloadByUserIdRequest)(nil),  // 0: pastedownload.DownloadByUserIdRequest
-	(*DownloadByUserIdResponse)(nil), // 1: pastedownload.DownloadByUserIdResponse
-	(*DownloadByKeyRequest)(nil),     // 2: pastedownload.DownloadByKeyRequest
-	(*DownloadByKeyResponse)(nil),    // 3: pastedownload.DownloadByKeyResponse
-	(*Metadata)(nil),                 // 4: pastedownload.Metadata
-	(*timestamppb.Timestamp)(nil),    // 5: google.protobuf.Timestamp
+func (x *Metadata) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
-var file_paste_download_paste_download_proto_depIdxs = []int32{
-	4, // 0: pastedownload.DownloadByUserIdResponse.objects:type_name -> pastedownload.Metadata
-	4, // 1: pastedownload.DownloadByKeyResponse.metadata:type_name -> pastedownload.Metadata
-	5, // 2: pastedownload.Metadata.created_at:type_name -> google.protobuf.Timestamp
-	5, // 3: pastedownload.Metadata.expired_date:type_name -> google.protobuf.Timestamp
-	2, // 4: pastedownload.PasteDownload.DownloadByKey:input_type -> pastedownload.DownloadByKeyRequest
-	0, // 5: pastedownload.PasteDownload.DownloadByUserId:input_type -> pastedownload.DownloadByUserIdRequest
-	3, // 6: pastedownload.PasteDownload.DownloadByKey:output_type -> pastedownload.DownloadByKeyResponse
-	1, // 7: pastedownload.PasteDownload.DownloadByUserId:output_type -> pastedownload.DownloadByUserIdResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *Metadata) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
 }
 
-func init() { file_paste_download_paste_download_proto_init() }
-func file_paste_download_paste_download_proto_init() {
-	if File_paste_download_paste_download_proto != nil {
-		return
+func (x *Metadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Metadata) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *Metadata) GetMetadataVersion() int64 {
+	if x != nil {
+		return x.MetadataVersion
+	}
+	return 0
+}
+
+func (x *Metadata) GetCollectionId() int64 {
+	if x != nil {
+		return x.CollectionId
+	}
+	return 0
+}
+
+func (x *Metadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// A named file of a bundle.
+type PasteFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Size     int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *PasteFile) Reset() {
+	*x = PasteFile{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasteFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasteFile) ProtoMessage() {}
+
+func (x *PasteFile) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasteFile.ProtoReflect.Descriptor instead.
+func (*PasteFile) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PasteFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PasteFile) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PasteFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Request message for listing the revisions of a paste.
+type ListVersionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	UserId   string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ListVersionsRequest) Reset() {
+	*x = ListVersionsRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsRequest) ProtoMessage() {}
+
+func (x *ListVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListVersionsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListVersionsRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ListVersionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message containing the revisions of a paste.
+type ListVersionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Versions []*PasteVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (x *ListVersionsResponse) Reset() {
+	*x = ListVersionsResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVersionsResponse) ProtoMessage() {}
+
+func (x *ListVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListVersionsResponse) GetVersions() []*PasteVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Request message for retrieving a single revision of a paste.
+type GetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version  int32  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	UserId   string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetVersionRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetVersionRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *GetVersionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message containing a revision and its content.
+type GetVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version *PasteVersion `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Content []byte        `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *GetVersionResponse) Reset() {
+	*x = GetVersionResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionResponse) ProtoMessage() {}
+
+func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetVersionResponse) GetVersion() *PasteVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *GetVersionResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// A single immutable revision of a paste.
+type PasteVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version   int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *PasteVersion) Reset() {
+	*x = PasteVersion{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasteVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasteVersion) ProtoMessage() {}
+
+func (x *PasteVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasteVersion.ProtoReflect.Descriptor instead.
+func (*PasteVersion) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PasteVersion) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PasteVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PasteVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Request message for searching the pastes of a user.
+type SearchPastesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Only pastes owned by this user are searched.
+	Query  string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                 // Web search syntax: words, "quoted phrases", OR and -excluded words.
+	Limit  int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *SearchPastesRequest) Reset() {
+	*x = SearchPastesRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPastesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPastesRequest) ProtoMessage() {}
+
+func (x *SearchPastesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPastesRequest.ProtoReflect.Descriptor instead.
+func (*SearchPastesRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchPastesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchPastesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPastesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPastesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Response message containing the matching pastes, best match first.
+type SearchPastesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SearchPastesResponse) Reset() {
+	*x = SearchPastesResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPastesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPastesResponse) ProtoMessage() {}
+
+func (x *SearchPastesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPastesResponse.ProtoReflect.Descriptor instead.
+func (*SearchPastesResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchPastesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// A single search hit.
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Rank     float32   `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Snippet  string    `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"` // Matching fragments with the matched words wrapped in <mark></mark>.
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchResult) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+// Request message for rendering a paste with syntax highlighting.
+type RenderPasteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string       `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId   string       `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Password string       `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Format   RenderFormat `protobuf:"varint,4,opt,name=format,proto3,enum=pastedownload.RenderFormat" json:"format,omitempty"`
+	File     string       `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"` // Name of the file to render, required for bundles.
+}
+
+func (x *RenderPasteRequest) Reset() {
+	*x = RenderPasteRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPasteRequest) ProtoMessage() {}
+
+func (x *RenderPasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPasteRequest.ProtoReflect.Descriptor instead.
+func (*RenderPasteRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RenderPasteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RenderPasteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenderPasteRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RenderPasteRequest) GetFormat() RenderFormat {
+	if x != nil {
+		return x.Format
+	}
+	return RenderFormat_RENDER_FORMAT_HTML
+}
+
+func (x *RenderPasteRequest) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+// Response message containing the highlighted paste.
+type RenderPasteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content     []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Language    string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"` // Language the content was highlighted as.
+}
+
+func (x *RenderPasteResponse) Reset() {
+	*x = RenderPasteResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderPasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderPasteResponse) ProtoMessage() {}
+
+func (x *RenderPasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderPasteResponse.ProtoReflect.Descriptor instead.
+func (*RenderPasteResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RenderPasteResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *RenderPasteResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RenderPasteResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// Request message for streaming the content of a paste.
+type StreamContentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key             string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Password        string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	HasRange        bool                   `protobuf:"varint,4,opt,name=has_range,json=hasRange,proto3" json:"has_range,omitempty"`                       // Only send the bytes selected by offset and length.
+	Offset          int64                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`                                           // First byte to send, a negative offset selects the last -offset bytes.
+	Length          int64                  `protobuf:"varint,6,opt,name=length,proto3" json:"length,omitempty"`                                           // Number of bytes to send, 0 reads to the end.
+	IfNoneMatch     []string               `protobuf:"bytes,7,rep,name=if_none_match,json=ifNoneMatch,proto3" json:"if_none_match,omitempty"`             // ETags the caller already has, "*" matches any.
+	IfModifiedSince *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=if_modified_since,json=ifModifiedSince,proto3" json:"if_modified_since,omitempty"` // Ignored when if_none_match is set.
+	File            string                 `protobuf:"bytes,9,opt,name=file,proto3" json:"file,omitempty"`                                                // Name of the file to stream, required for bundles.
+	IfRangeEtag     string                 `protobuf:"bytes,10,opt,name=if_range_etag,json=ifRangeEtag,proto3" json:"if_range_etag,omitempty"`            // Strong ETag of If-Range, the range is only sent when it matches.
+	IfRangeDate     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=if_range_date,json=ifRangeDate,proto3" json:"if_range_date,omitempty"`            // Date of If-Range, the range is only sent when it matches the modification time.
+}
+
+func (x *StreamContentRequest) Reset() {
+	*x = StreamContentRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamContentRequest) ProtoMessage() {}
+
+func (x *StreamContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamContentRequest.ProtoReflect.Descriptor instead.
+func (*StreamContentRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StreamContentRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StreamContentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamContentRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *StreamContentRequest) GetHasRange() bool {
+	if x != nil {
+		return x.HasRange
+	}
+	return false
+}
+
+func (x *StreamContentRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StreamContentRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *StreamContentRequest) GetIfNoneMatch() []string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return nil
+}
+
+func (x *StreamContentRequest) GetIfModifiedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IfModifiedSince
+	}
+	return nil
+}
+
+func (x *StreamContentRequest) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *StreamContentRequest) GetIfRangeEtag() string {
+	if x != nil {
+		return x.IfRangeEtag
+	}
+	return ""
+}
+
+func (x *StreamContentRequest) GetIfRangeDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IfRangeDate
+	}
+	return nil
+}
+
+// Streamed content: the info comes first, followed by the chunks.
+type StreamContentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*StreamContentResponse_Info
+	//	*StreamContentResponse_Chunk
+	Data isStreamContentResponse_Data `protobuf_oneof:"data"`
+}
+
+func (x *StreamContentResponse) Reset() {
+	*x = StreamContentResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamContentResponse) ProtoMessage() {}
+
+func (x *StreamContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamContentResponse.ProtoReflect.Descriptor instead.
+func (*StreamContentResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{18}
+}
+
+func (m *StreamContentResponse) GetData() isStreamContentResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *StreamContentResponse) GetInfo() *ContentInfo {
+	if x, ok := x.GetData().(*StreamContentResponse_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *StreamContentResponse) GetChunk() []byte {
+	if x, ok := x.GetData().(*StreamContentResponse_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isStreamContentResponse_Data interface {
+	isStreamContentResponse_Data()
+}
+
+type StreamContentResponse_Info struct {
+	Info *ContentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type StreamContentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*StreamContentResponse_Info) isStreamContentResponse_Data() {}
+
+func (*StreamContentResponse_Chunk) isStreamContentResponse_Data() {}
+
+// Describes the streamed content.
+type ContentInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size         int64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // Size of the whole paste.
+	ContentType  string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Etag         string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	LastModified *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	NotModified  bool                   `protobuf:"varint,5,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"` // The conditions matched, no chunks follow.
+	Partial      bool                   `protobuf:"varint,6,opt,name=partial,proto3" json:"partial,omitempty"`                            // Only the range offset..offset+length is sent.
+	Offset       int64                  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length       int64                  `protobuf:"varint,8,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *ContentInfo) Reset() {
+	*x = ContentInfo{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentInfo) ProtoMessage() {}
+
+func (x *ContentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentInfo.ProtoReflect.Descriptor instead.
+func (*ContentInfo) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ContentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ContentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ContentInfo) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *ContentInfo) GetLastModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastModified
+	}
+	return nil
+}
+
+func (x *ContentInfo) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *ContentInfo) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *ContentInfo) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ContentInfo) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+// Request message for downloading a bundle as an archive.
+type DownloadBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId   string        `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Password string        `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Format   ArchiveFormat `protobuf:"varint,4,opt,name=format,proto3,enum=pastedownload.ArchiveFormat" json:"format,omitempty"`
+}
+
+func (x *DownloadBundleRequest) Reset() {
+	*x = DownloadBundleRequest{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBundleRequest) ProtoMessage() {}
+
+func (x *DownloadBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBundleRequest.ProtoReflect.Descriptor instead.
+func (*DownloadBundleRequest) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DownloadBundleRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DownloadBundleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DownloadBundleRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DownloadBundleRequest) GetFormat() ArchiveFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ
+}
+
+// A chunk of the archive.
+type DownloadBundleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (x *DownloadBundleResponse) Reset() {
+	*x = DownloadBundleResponse{}
+	mi := &file_paste_download_paste_download_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBundleResponse) ProtoMessage() {}
+
+func (x *DownloadBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_download_paste_download_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBundleResponse.ProtoReflect.Descriptor instead.
+func (*DownloadBundleResponse) Descriptor() ([]byte, []int) {
+	return file_paste_download_paste_download_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DownloadBundleResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+var File_paste_download_paste_download_proto protoreflect.FileDescriptor
+
+var file_paste_download_paste_download_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x2f, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdc, 0x01, 0x0a, 0x17, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2c,
+	0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70,
+	0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x73,
+	0x74, 0x65, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x22, 0x8f, 0x01, 0x0a, 0x18, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x07, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x5d, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xe0, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x61, 0x6f, 0x64,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x61, 0x6f, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x3f, 0x0a, 0x0a, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x10, 0x45, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x64, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x64, 0x66, 0x12, 0x19, 0x0a,
+	0x08, 0x6b, 0x64, 0x66, 0x5f, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x6b, 0x64, 0x66, 0x53, 0x61, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x64, 0x66, 0x5f,
+	0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x6b, 0x64, 0x66, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x6b, 0x64, 0x66, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6b, 0x69,
+	0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6b, 0x64, 0x66, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4b, 0x69, 0x62, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6b,
+	0x65, 0x79, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x6b, 0x65, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xb8, 0x04, 0x0a, 0x08, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x11, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x6f,
+	0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x69,
+	0x65, 0x77, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x56, 0x69, 0x65,
+	0x77, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x22, 0x4f, 0x0a, 0x09, 0x50, 0x61, 0x73, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x22, 0x5c, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x4f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70,
+	0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x73,
+	0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x74, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x65, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x75, 0x0a, 0x0c, 0x50, 0x61, 0x73, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x72, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x4d, 0x0a, 0x14, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x71, 0x0a, 0x0c, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
+	0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x22, 0xa4, 0x01,
+	0x0a, 0x12, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x6e, 0x0a, 0x13, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61,
+	0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x22, 0x8e, 0x03, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x6e, 0x65, 0x5f, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x66, 0x4e, 0x6f, 0x6e, 0x65,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x46, 0x0a, 0x11, 0x69, 0x66, 0x5f, 0x6d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x69, 0x66,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x66, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x74,
+	0x61, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x66, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x45, 0x74, 0x61, 0x67, 0x12, 0x3e, 0x0a, 0x0d, 0x69, 0x66, 0x5f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x69, 0x66, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x65, 0x22, 0x69, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48,
+	0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x86, 0x02, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12, 0x3f, 0x0a, 0x0d, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x6e, 0x6f, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x94, 0x01, 0x0a, 0x15, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x73,
+	0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x22, 0x2e, 0x0a, 0x16, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x2a, 0x57, 0x0a, 0x09, 0x50, 0x61, 0x73, 0x74, 0x65, 0x53, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x0a,
+	0x15, 0x50, 0x41, 0x53, 0x54, 0x45, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x41, 0x53, 0x54,
+	0x45, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x41, 0x53, 0x54, 0x45, 0x5f, 0x53, 0x4f, 0x52,
+	0x54, 0x5f, 0x54, 0x49, 0x54, 0x4c, 0x45, 0x10, 0x02, 0x2a, 0x6b, 0x0a, 0x0a, 0x56, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x49, 0x53, 0x49, 0x42,
+	0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x00, 0x12, 0x17,
+	0x0a, 0x13, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x4c,
+	0x49, 0x53, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x53, 0x49, 0x42,
+	0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12,
+	0x15, 0x0a, 0x11, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x48,
+	0x41, 0x52, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x3e, 0x0a, 0x0c, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x4e, 0x44, 0x45, 0x52,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x48, 0x54, 0x4d, 0x4c, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x52, 0x45, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f,
+	0x41, 0x4e, 0x53, 0x49, 0x10, 0x01, 0x2a, 0x42, 0x0a, 0x0d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x52, 0x43, 0x48, 0x49,
+	0x56, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x5f, 0x47, 0x5a,
+	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x5a, 0x49, 0x50, 0x10, 0x01, 0x32, 0xea, 0x05, 0x0a, 0x0d, 0x50,
+	0x61, 0x73, 0x74, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x5a, 0x0a, 0x0d,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x2e,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x4b, 0x65, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x2e, 0x70,
+	0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a,
+	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x61, 0x73, 0x74,
+	0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73, 0x74,
+	0x65, 0x12, 0x21, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x2e, 0x70, 0x61, 0x73, 0x74,
+	0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
+	0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5f, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x3b, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_paste_download_paste_download_proto_rawDescOnce sync.Once
+	file_paste_download_paste_download_proto_rawDescData = file_paste_download_paste_download_proto_rawDesc
+)
+
+func file_paste_download_paste_download_proto_rawDescGZIP() []byte {
+	file_paste_download_paste_download_proto_rawDescOnce.Do(func() {
+		file_paste_download_paste_download_proto_rawDescData = protoimpl.X.CompressGZIP(file_paste_download_paste_download_proto_rawDescData)
+	})
+	return file_paste_download_paste_download_proto_rawDescData
+}
+
+var file_paste_download_paste_download_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_paste_download_paste_download_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_paste_download_paste_download_proto_goTypes = []any{
+	(PasteSort)(0),                   // 0: pastedownload.PasteSort
+	(Visibility)(0),                  // 1: pastedownload.Visibility
+	(RenderFormat)(0),                // 2: pastedownload.RenderFormat
+	(ArchiveFormat)(0),               // 3: pastedownload.ArchiveFormat
+	(*DownloadByUserIdRequest)(nil),  // 4: pastedownload.DownloadByUserIdRequest
+	(*DownloadByUserIdResponse)(nil), // 5: pastedownload.DownloadByUserIdResponse
+	(*DownloadByKeyRequest)(nil),     // 6: pastedownload.DownloadByKeyRequest
+	(*DownloadByKeyResponse)(nil),    // 7: pastedownload.DownloadByKeyResponse
+	(*EncryptionHeader)(nil),         // 8: pastedownload.EncryptionHeader
+	(*Metadata)(nil),                 // 9: pastedownload.Metadata
+	(*PasteFile)(nil),                // 10: pastedownload.PasteFile
+	(*ListVersionsRequest)(nil),      // 11: pastedownload.ListVersionsRequest
+	(*ListVersionsResponse)(nil),     // 12: pastedownload.ListVersionsResponse
+	(*GetVersionRequest)(nil),        // 13: pastedownload.GetVersionRequest
+	(*GetVersionResponse)(nil),       // 14: pastedownload.GetVersionResponse
+	(*PasteVersion)(nil),             // 15: pastedownload.PasteVersion
+	(*SearchPastesRequest)(nil),      // 16: pastedownload.SearchPastesRequest
+	(*SearchPastesResponse)(nil),     // 17: pastedownload.SearchPastesResponse
+	(*SearchResult)(nil),             // 18: pastedownload.SearchResult
+	(*RenderPasteRequest)(nil),       // 19: pastedownload.RenderPasteRequest
+	(*RenderPasteResponse)(nil),      // 20: pastedownload.RenderPasteResponse
+	(*StreamContentRequest)(nil),     // 21: pastedownload.StreamContentRequest
+	(*StreamContentResponse)(nil),    // 22: pastedownload.StreamContentResponse
+	(*ContentInfo)(nil),              // 23: pastedownload.ContentInfo
+	(*DownloadBundleRequest)(nil),    // 24: pastedownload.DownloadBundleRequest
+	(*DownloadBundleResponse)(nil),   // 25: pastedownload.DownloadBundleResponse
+	(*timestamppb.Timestamp)(nil),    // 26: google.protobuf.Timestamp
+}
+var file_paste_download_paste_download_proto_depIdxs = []int32{
+	0,  // 0: pastedownload.DownloadByUserIdRequest.sort:type_name -> pastedownload.PasteSort
+	9,  // 1: pastedownload.DownloadByUserIdResponse.objects:type_name -> pastedownload.Metadata
+	9,  // 2: pastedownload.DownloadByKeyResponse.metadata:type_name -> pastedownload.Metadata
+	8,  // 3: pastedownload.DownloadByKeyResponse.encryption:type_name -> pastedownload.EncryptionHeader
+	10, // 4: pastedownload.DownloadByKeyResponse.files:type_name -> pastedownload.PasteFile
+	26, // 5: pastedownload.Metadata.created_at:type_name -> google.protobuf.Timestamp
+	26, // 6: pastedownload.Metadata.expired_date:type_name -> google.protobuf.Timestamp
+	1,  // 7: pastedownload.Metadata.visibility:type_name -> pastedownload.Visibility
+	15, // 8: pastedownload.ListVersionsResponse.versions:type_name -> pastedownload.PasteVersion
+	15, // 9: pastedownload.GetVersionResponse.version:type_name -> pastedownload.PasteVersion
+	26, // 10: pastedownload.PasteVersion.created_at:type_name -> google.protobuf.Timestamp
+	18, // 11: pastedownload.SearchPastesResponse.results:type_name -> pastedownload.SearchResult
+	9,  // 12: pastedownload.SearchResult.metadata:type_name -> pastedownload.Metadata
+	2,  // 13: pastedownload.RenderPasteRequest.format:type_name -> pastedownload.RenderFormat
+	26, // 14: pastedownload.StreamContentRequest.if_modified_since:type_name -> google.protobuf.Timestamp
+	26, // 15: pastedownload.StreamContentRequest.if_range_date:type_name -> google.protobuf.Timestamp
+	23, // 16: pastedownload.StreamContentResponse.info:type_name -> pastedownload.ContentInfo
+	26, // 17: pastedownload.ContentInfo.last_modified:type_name -> google.protobuf.Timestamp
+	3,  // 18: pastedownload.DownloadBundleRequest.format:type_name -> pastedownload.ArchiveFormat
+	6,  // 19: pastedownload.PasteDownload.DownloadByKey:input_type -> pastedownload.DownloadByKeyRequest
+	4,  // 20: pastedownload.PasteDownload.DownloadByUserId:input_type -> pastedownload.DownloadByUserIdRequest
+	11, // 21: pastedownload.PasteDownload.ListVersions:input_type -> pastedownload.ListVersionsRequest
+	13, // 22: pastedownload.PasteDownload.GetVersion:input_type -> pastedownload.GetVersionRequest
+	16, // 23: pastedownload.PasteDownload.SearchPastes:input_type -> pastedownload.SearchPastesRequest
+	19, // 24: pastedownload.PasteDownload.RenderPaste:input_type -> pastedownload.RenderPasteRequest
+	21, // 25: pastedownload.PasteDownload.StreamContent:input_type -> pastedownload.StreamContentRequest
+	24, // 26: pastedownload.PasteDownload.DownloadBundle:input_type -> pastedownload.DownloadBundleRequest
+	7,  // 27: pastedownload.PasteDownload.DownloadByKey:output_type -> pastedownload.DownloadByKeyResponse
+	5,  // 28: pastedownload.PasteDownload.DownloadByUserId:output_type -> pastedownload.DownloadByUserIdResponse
+	12, // 29: pastedownload.PasteDownload.ListVersions:output_type -> pastedownload.ListVersionsResponse
+	14, // 30: pastedownload.PasteDownload.GetVersion:output_type -> pastedownload.GetVersionResponse
+	17, // 31: pastedownload.PasteDownload.SearchPastes:output_type -> pastedownload.SearchPastesResponse
+	20, // 32: pastedownload.PasteDownload.RenderPaste:output_type -> pastedownload.RenderPasteResponse
+	22, // 33: pastedownload.PasteDownload.StreamContent:output_type -> pastedownload.StreamContentResponse
+	25, // 34: pastedownload.PasteDownload.DownloadBundle:output_type -> pastedownload.DownloadBundleResponse
+	27, // [27:35] is the sub-list for method output_type
+	19, // [19:27] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_paste_download_paste_download_proto_init() }
+func file_paste_download_paste_download_proto_init() {
+	if File_paste_download_paste_download_proto != nil {
+		return
+	}
+	file_paste_download_paste_download_proto_msgTypes[18].OneofWrappers = []any{
+		(*StreamContentResponse_Info)(nil),
+		(*StreamContentResponse_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_paste_download_paste_download_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      4,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_paste_download_paste_download_proto_goTypes,
 		DependencyIndexes: file_paste_download_paste_download_proto_depIdxs,
+		EnumInfos:         file_paste_download_paste_download_proto_enumTypes,
 		MessageInfos:      file_paste_download_paste_download_proto_msgTypes,
 	}.Build()
 	File_paste_download_paste_download_proto = out.File
@@ -21,33 +21,1717 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Who is allowed to read a paste
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_PUBLIC   Visibility = 0 // Anyone holding the key, listed in search
+	Visibility_VISIBILITY_UNLISTED Visibility = 1 // Anyone holding the key, never listed
+	Visibility_VISIBILITY_PRIVATE  Visibility = 2 // Only the owner
+	Visibility_VISIBILITY_SHARED   Visibility = 3 // The owner and the users in shared_with
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_PUBLIC",
+		1: "VISIBILITY_UNLISTED",
+		2: "VISIBILITY_PRIVATE",
+		3: "VISIBILITY_SHARED",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_PUBLIC":   0,
+		"VISIBILITY_UNLISTED": 1,
+		"VISIBILITY_PRIVATE":  2,
+		"VISIBILITY_SHARED":   3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_paste_upload_paste_upload_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_paste_upload_paste_upload_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{0}
+}
+
 // Upload request message
 type UploadPasteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                     // Unique paste key
-	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // User ID (consistent type)
-	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"` // Expiration date
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                     // Unique paste key
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // User ID (consistent type)
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"` // Expiration date
+	Password       string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                                   // Optional access password, only its bcrypt hash is stored
+	MaxViews       int32                  `protobuf:"varint,6,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`                  // Number of downloads after which the paste is deleted, 0 for unlimited
+	BurnAfterRead  bool                   `protobuf:"varint,7,opt,name=burn_after_read,json=burnAfterRead,proto3" json:"burn_after_read,omitempty"` // Shorthand for max_views = 1
+	Visibility     Visibility             `protobuf:"varint,8,opt,name=visibility,proto3,enum=pasteupload.Visibility" json:"visibility,omitempty"`
+	SharedWith     []string               `protobuf:"bytes,9,rep,name=shared_with,json=sharedWith,proto3" json:"shared_with,omitempty"` // User IDs allowed to read a shared paste
+	Encryption     *EncryptionHeader      `protobuf:"bytes,10,opt,name=encryption,proto3" json:"encryption,omitempty"`                  // Set when the content is encrypted on the client
+	Tags           []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	Language       string                 `protobuf:"bytes,12,opt,name=language,proto3" json:"language,omitempty"` // Syntax of the content, detected from filename and content when empty
+	Filename       string                 `protobuf:"bytes,13,opt,name=filename,proto3" json:"filename,omitempty"` // Optional file name, used as a hint for language detection
+	Tier           string                 `protobuf:"bytes,14,opt,name=tier,proto3" json:"tier,omitempty"`         // Role of the uploader: user, moderator or admin. Selects the size limit of streamed content
+}
+
+func (x *UploadPasteRequest) Reset() {
+	*x = UploadPasteRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPasteRequest) ProtoMessage() {}
+
+func (x *UploadPasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPasteRequest.ProtoReflect.Descriptor instead.
+func (*UploadPasteRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadPasteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *UploadPasteRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *UploadPasteRequest) GetBurnAfterRead() bool {
+	if x != nil {
+		return x.BurnAfterRead
+	}
+	return false
+}
+
+func (x *UploadPasteRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_PUBLIC
+}
+
+func (x *UploadPasteRequest) GetSharedWith() []string {
+	if x != nil {
+		return x.SharedWith
+	}
+	return nil
+}
+
+func (x *UploadPasteRequest) GetEncryption() *EncryptionHeader {
+	if x != nil {
+		return x.Encryption
+	}
+	return nil
+}
+
+func (x *UploadPasteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UploadPasteRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadPasteRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+// Envelope of client side encrypted content. The server stores it next to the ciphertext,
+// the key itself never leaves the client
+type EncryptionHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm     string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"` // Content cipher: AES-256-GCM or XChaCha20-Poly1305
+	Kdf           string `protobuf:"bytes,2,opt,name=kdf,proto3" json:"kdf,omitempty"`             // Key derivation: none when the raw key travels in the URL fragment, PBKDF2-SHA256 or argon2id
+	KdfSalt       []byte `protobuf:"bytes,3,opt,name=kdf_salt,json=kdfSalt,proto3" json:"kdf_salt,omitempty"`
+	KdfIterations int32  `protobuf:"varint,4,opt,name=kdf_iterations,json=kdfIterations,proto3" json:"kdf_iterations,omitempty"` // Iterations of PBKDF2 or time cost of argon2id
+	KdfMemoryKib  int32  `protobuf:"varint,5,opt,name=kdf_memory_kib,json=kdfMemoryKib,proto3" json:"kdf_memory_kib,omitempty"`  // Memory cost of argon2id
+	Nonce         []byte `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	KeyCheck      []byte `protobuf:"bytes,7,opt,name=key_check,json=keyCheck,proto3" json:"key_check,omitempty"` // Derived from the key, lets clients detect a wrong key before decrypting
+}
+
+func (x *EncryptionHeader) Reset() {
+	*x = EncryptionHeader{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionHeader) ProtoMessage() {}
+
+func (x *EncryptionHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionHeader.ProtoReflect.Descriptor instead.
+func (*EncryptionHeader) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EncryptionHeader) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetKdf() string {
+	if x != nil {
+		return x.Kdf
+	}
+	return ""
+}
+
+func (x *EncryptionHeader) GetKdfSalt() []byte {
+	if x != nil {
+		return x.KdfSalt
+	}
+	return nil
+}
+
+func (x *EncryptionHeader) GetKdfIterations() int32 {
+	if x != nil {
+		return x.KdfIterations
+	}
+	return 0
+}
+
+func (x *EncryptionHeader) GetKdfMemoryKib() int32 {
+	if x != nil {
+		return x.KdfMemoryKib
+	}
+	return 0
+}
+
+func (x *EncryptionHeader) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *EncryptionHeader) GetKeyCheck() []byte {
+	if x != nil {
+		return x.KeyCheck
+	}
+	return nil
+}
+
+// Upload response message
+type UploadPasteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadUrl      string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`                // Response message (e.g., "Upload successful")
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"` // Echo back the expiration date for confirmation
+}
+
+func (x *UploadPasteResponse) Reset() {
+	*x = UploadPasteResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPasteResponse) ProtoMessage() {}
+
+func (x *UploadPasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPasteResponse.ProtoReflect.Descriptor instead.
+func (*UploadPasteResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadPasteResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *UploadPasteResponse) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+// Finalizes a paste uploaded to the URL of UploadPaste. The paste is pending and cannot be read
+// until the content is found in storage. Content breaking the upload limits is deleted, the
+// error carries an ErrorInfo naming the violated limit
+type CompleteUploadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId   string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Owner of the paste, empty for anonymous pastes
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`           // Hex MD5 of the content, checked against the stored object
+	Tier     string `protobuf:"bytes,4,opt,name=tier,proto3" json:"tier,omitempty"`                   // Role of the uploader: user, moderator or admin. Selects the size limit
+	Version  int32  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`            // Revision returned by UploadUpdates, 0 completes the first upload of the paste
+}
+
+func (x *CompleteUploadRequest) Reset() {
+	*x = CompleteUploadRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadRequest) ProtoMessage() {}
+
+func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUploadRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompleteUploadRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *CompleteUploadRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CompleteUploadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key         string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Size        int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum    string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"` // Hex MD5 of the stored content
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Version     int32  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *CompleteUploadResponse) Reset() {
+	*x = CompleteUploadResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUploadResponse) ProtoMessage() {}
+
+func (x *CompleteUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUploadResponse.ProtoReflect.Descriptor instead.
+func (*CompleteUploadResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CompleteUploadResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CompleteUploadResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CompleteUploadResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *CompleteUploadResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CompleteUploadResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Streamed content upload: the first message carries the metadata, every following one a chunk of content.
+// A bundle of several files is uploaded by sending a file header before the chunks of each file
+type UploadContentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*UploadContentRequest_Metadata
+	//	*UploadContentRequest_Chunk
+	//	*UploadContentRequest_File
+	Data isUploadContentRequest_Data `protobuf_oneof:"data"`
+}
+
+func (x *UploadContentRequest) Reset() {
+	*x = UploadContentRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentRequest) ProtoMessage() {}
+
+func (x *UploadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContentRequest.ProtoReflect.Descriptor instead.
+func (*UploadContentRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *UploadContentRequest) GetData() isUploadContentRequest_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *UploadContentRequest) GetMetadata() *UploadPasteRequest {
+	if x, ok := x.GetData().(*UploadContentRequest_Metadata); ok {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadContentRequest) GetChunk() []byte {
+	if x, ok := x.GetData().(*UploadContentRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *UploadContentRequest) GetFile() *PasteFile {
+	if x, ok := x.GetData().(*UploadContentRequest_File); ok {
+		return x.File
+	}
+	return nil
+}
+
+type isUploadContentRequest_Data interface {
+	isUploadContentRequest_Data()
+}
+
+type UploadContentRequest_Metadata struct {
+	Metadata *UploadPasteRequest `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadContentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type UploadContentRequest_File struct {
+	File *PasteFile `protobuf:"bytes,3,opt,name=file,proto3,oneof"`
+}
+
+func (*UploadContentRequest_Metadata) isUploadContentRequest_Data() {}
+
+func (*UploadContentRequest_Chunk) isUploadContentRequest_Data() {}
+
+func (*UploadContentRequest_File) isUploadContentRequest_Data() {}
+
+// A named file of a bundle
+type PasteFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"` // Detected from the name and content when empty
+	Size     int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`        // Set by the server
+}
+
+func (x *PasteFile) Reset() {
+	*x = PasteFile{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasteFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasteFile) ProtoMessage() {}
+
+func (x *PasteFile) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasteFile.ProtoReflect.Descriptor instead.
+func (*PasteFile) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PasteFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PasteFile) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *PasteFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Streamed content upload response
+type UploadContentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Size           int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // Number of bytes written to storage
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	Files          []*PasteFile           `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"` // Manifest of a bundle, empty for single file pastes
+}
+
+func (x *UploadContentResponse) Reset() {
+	*x = UploadContentResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentResponse) ProtoMessage() {}
+
+func (x *UploadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContentResponse.ProtoReflect.Descriptor instead.
+func (*UploadContentResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadContentResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadContentResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadContentResponse) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *UploadContentResponse) GetFiles() []*PasteFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// Update request message
+type UploadUpdatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Consistent user ID type
+}
+
+func (x *UploadUpdatesRequest) Reset() {
+	*x = UploadUpdatesRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadUpdatesRequest) ProtoMessage() {}
+
+func (x *UploadUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*UploadUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UploadUpdatesRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadUpdatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Update response message
+type UploadUpdatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadUrl string `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"` // Response message (e.g., "Update successful" or error details)
+	Version   int32  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                     // Number of the revision the content must be uploaded as
+}
+
+func (x *UploadUpdatesResponse) Reset() {
+	*x = UploadUpdatesResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadUpdatesResponse) ProtoMessage() {}
+
+func (x *UploadUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*UploadUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UploadUpdatesResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *UploadUpdatesResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Edits the title, expiration and tags of a paste. Unset fields are left unchanged. The edit
+// fails with ABORTED when the metadata changed since the version it was made against
+type UpdatePasteMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                         // Only the owner can edit a paste
+	Version        int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`                                    // Metadata version the edit was made against
+	Title          *string                `protobuf:"bytes,4,opt,name=title,proto3,oneof" json:"title,omitempty"`                                   // An empty title removes it
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"` // Extends or shortens the lifetime of the paste
+	AddTags        []string               `protobuf:"bytes,6,rep,name=add_tags,json=addTags,proto3" json:"add_tags,omitempty"`
+	RemoveTags     []string               `protobuf:"bytes,7,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"`
+}
+
+func (x *UpdatePasteMetadataRequest) Reset() {
+	*x = UpdatePasteMetadataRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasteMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasteMetadataRequest) ProtoMessage() {}
+
+func (x *UpdatePasteMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasteMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePasteMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdatePasteMetadataRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpdatePasteMetadataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePasteMetadataRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdatePasteMetadataRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePasteMetadataRequest) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *UpdatePasteMetadataRequest) GetAddTags() []string {
+	if x != nil {
+		return x.AddTags
+	}
+	return nil
+}
+
+func (x *UpdatePasteMetadataRequest) GetRemoveTags() []string {
+	if x != nil {
+		return x.RemoveTags
+	}
+	return nil
+}
+
+type UpdatePasteMetadataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key            string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	Tags           []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Version        int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"` // Metadata version to make the next edit against
+}
+
+func (x *UpdatePasteMetadataResponse) Reset() {
+	*x = UpdatePasteMetadataResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasteMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasteMetadataResponse) ProtoMessage() {}
+
+func (x *UpdatePasteMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasteMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePasteMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdatePasteMetadataResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpdatePasteMetadataResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePasteMetadataResponse) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *UpdatePasteMetadataResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdatePasteMetadataResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ExpirePasteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Force  bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"` // Expire a paste of any owner, user_id is the moderator. The gateway checks the role
+}
+
+func (x *ExpirePasteRequest) Reset() {
+	*x = ExpirePasteRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpirePasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpirePasteRequest) ProtoMessage() {}
+
+func (x *ExpirePasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpirePasteRequest.ProtoReflect.Descriptor instead.
+func (*ExpirePasteRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExpirePasteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ExpirePasteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExpirePasteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type ExpirePasteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ExpirePasteResponse) Reset() {
+	*x = ExpirePasteResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpirePasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpirePasteResponse) ProtoMessage() {}
+
+func (x *ExpirePasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpirePasteResponse.ProtoReflect.Descriptor instead.
+func (*ExpirePasteResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExpirePasteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Expire all pastes for a user
+type ExpireAllPastesByUserIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Corrected field number
+}
+
+func (x *ExpireAllPastesByUserIDRequest) Reset() {
+	*x = ExpireAllPastesByUserIDRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireAllPastesByUserIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireAllPastesByUserIDRequest) ProtoMessage() {}
+
+func (x *ExpireAllPastesByUserIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireAllPastesByUserIDRequest.ProtoReflect.Descriptor instead.
+func (*ExpireAllPastesByUserIDRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExpireAllPastesByUserIDRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExpireAllPastesByUserIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Response message (e.g., "All pastes expired successfully" or error details)
+}
+
+func (x *ExpireAllPastesByUserIDResponse) Reset() {
+	*x = ExpireAllPastesByUserIDResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireAllPastesByUserIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireAllPastesByUserIDResponse) ProtoMessage() {}
+
+func (x *ExpireAllPastesByUserIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireAllPastesByUserIDResponse.ProtoReflect.Descriptor instead.
+func (*ExpireAllPastesByUserIDResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExpireAllPastesByUserIDResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Abuse reports of pastes, reviewed by moderators
+type ReportPasteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key        string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ReporterId string `protobuf:"bytes,2,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"` // Empty for anonymous reports
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ReportPasteRequest) Reset() {
+	*x = ReportPasteRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPasteRequest) ProtoMessage() {}
+
+func (x *ReportPasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPasteRequest.ProtoReflect.Descriptor instead.
+func (*ReportPasteRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReportPasteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ReportPasteRequest) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *ReportPasteRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReportPasteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ReportPasteResponse) Reset() {
+	*x = ReportPasteResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPasteResponse) ProtoMessage() {}
+
+func (x *ReportPasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPasteResponse.ProtoReflect.Descriptor instead.
+func (*ReportPasteResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReportPasteResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AbuseReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key        string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	ReporterId string                 `protobuf:"bytes,3,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Reason     string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"` // Set once a moderator expired the paste
+	ResolvedBy string                 `protobuf:"bytes,7,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+}
+
+func (x *AbuseReport) Reset() {
+	*x = AbuseReport{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbuseReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbuseReport) ProtoMessage() {}
+
+func (x *AbuseReport) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbuseReport.ProtoReflect.Descriptor instead.
+func (*AbuseReport) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AbuseReport) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AbuseReport) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AbuseReport) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *AbuseReport) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AbuseReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AbuseReport) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *AbuseReport) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+type ListAbuseReportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IncludeResolved bool  `protobuf:"varint,1,opt,name=include_resolved,json=includeResolved,proto3" json:"include_resolved,omitempty"`
+	Limit           int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *ListAbuseReportsRequest) Reset() {
+	*x = ListAbuseReportsRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAbuseReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAbuseReportsRequest) ProtoMessage() {}
+
+func (x *ListAbuseReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAbuseReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListAbuseReportsRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListAbuseReportsRequest) GetIncludeResolved() bool {
+	if x != nil {
+		return x.IncludeResolved
+	}
+	return false
+}
+
+func (x *ListAbuseReportsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAbuseReportsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListAbuseReportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reports []*AbuseReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ListAbuseReportsResponse) Reset() {
+	*x = ListAbuseReportsResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAbuseReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAbuseReportsResponse) ProtoMessage() {}
+
+func (x *ListAbuseReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAbuseReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListAbuseReportsResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListAbuseReportsResponse) GetReports() []*AbuseReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+// A named folder a user organizes their pastes in. Collections are private to their owner
+type Collection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PasteCount int32                  `protobuf:"varint,4,opt,name=paste_count,json=pasteCount,proto3" json:"paste_count,omitempty"`
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Collection) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Collection) GetPasteCount() int32 {
+	if x != nil {
+		return x.PasteCount
+	}
+	return 0
+}
+
+type CreateCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Unique among the collections of the user
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection *Collection `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *CreateCollectionResponse) Reset() {
+	*x = CreateCollectionResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionResponse) ProtoMessage() {}
+
+func (x *CreateCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateCollectionResponse) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type RenameCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *UploadPasteRequest) Reset() {
-	*x = UploadPasteRequest{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[0]
+func (x *RenameCollectionRequest) Reset() {
+	*x = RenameCollectionRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadPasteRequest) String() string {
+func (x *RenameCollectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadPasteRequest) ProtoMessage() {}
+func (*RenameCollectionRequest) ProtoMessage() {}
 
-func (x *UploadPasteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[0]
+func (x *RenameCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,64 +1742,102 @@ func (x *UploadPasteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadPasteRequest.ProtoReflect.Descriptor instead.
-func (*UploadPasteRequest) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RenameCollectionRequest.ProtoReflect.Descriptor instead.
+func (*RenameCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *UploadPasteRequest) GetKey() string {
+func (x *RenameCollectionRequest) GetId() int64 {
 	if x != nil {
-		return x.Key
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadPasteRequest) GetUserId() string {
+func (x *RenameCollectionRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *UploadPasteRequest) GetTitle() string {
+func (x *RenameCollectionRequest) GetName() string {
 	if x != nil {
-		return x.Title
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UploadPasteRequest) GetExpirationDate() *timestamppb.Timestamp {
+type RenameCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection *Collection `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *RenameCollectionResponse) Reset() {
+	*x = RenameCollectionResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionResponse) ProtoMessage() {}
+
+func (x *RenameCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[25]
 	if x != nil {
-		return x.ExpirationDate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCollectionResponse.ProtoReflect.Descriptor instead.
+func (*RenameCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RenameCollectionResponse) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
 	}
 	return nil
 }
 
-// Upload response message
-type UploadPasteResponse struct {
+// Deleting a collection keeps its pastes, they are no longer in any collection
+type DeleteCollectionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UploadUrl      string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`                // Response message (e.g., "Upload successful")
-	ExpirationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"` // Echo back the expiration date for confirmation
+	Id     int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *UploadPasteResponse) Reset() {
-	*x = UploadPasteResponse{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[1]
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadPasteResponse) String() string {
+func (x *DeleteCollectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadPasteResponse) ProtoMessage() {}
+func (*DeleteCollectionRequest) ProtoMessage() {}
 
-func (x *UploadPasteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[1]
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,50 +1848,46 @@ func (x *UploadPasteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadPasteResponse.ProtoReflect.Descriptor instead.
-func (*UploadPasteResponse) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *UploadPasteResponse) GetUploadUrl() string {
+func (x *DeleteCollectionRequest) GetId() int64 {
 	if x != nil {
-		return x.UploadUrl
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadPasteResponse) GetExpirationDate() *timestamppb.Timestamp {
+func (x *DeleteCollectionRequest) GetUserId() string {
 	if x != nil {
-		return x.ExpirationDate
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-// Update request message
-type UploadUpdatesRequest struct {
+type DeleteCollectionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Consistent user ID type
 }
 
-func (x *UploadUpdatesRequest) Reset() {
-	*x = UploadUpdatesRequest{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[2]
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadUpdatesRequest) String() string {
+func (x *DeleteCollectionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadUpdatesRequest) ProtoMessage() {}
+func (*DeleteCollectionResponse) ProtoMessage() {}
 
-func (x *UploadUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[2]
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -180,49 +1898,79 @@ func (x *UploadUpdatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadUpdatesRequest.ProtoReflect.Descriptor instead.
-func (*UploadUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UploadUpdatesRequest) GetKey() string {
+type ListCollectionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[28]
 	if x != nil {
-		return x.Key
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UploadUpdatesRequest) GetUserId() string {
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListCollectionsRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-// Update response message
-type UploadUpdatesResponse struct {
+type ListCollectionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UploadUrl string `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"` // Response message (e.g., "Update successful" or error details)
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"` // Sorted by name
 }
 
-func (x *UploadUpdatesResponse) Reset() {
-	*x = UploadUpdatesResponse{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[3]
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadUpdatesResponse) String() string {
+func (x *ListCollectionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadUpdatesResponse) ProtoMessage() {}
+func (*ListCollectionsResponse) ProtoMessage() {}
 
-func (x *UploadUpdatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[3]
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,42 +1981,45 @@ func (x *UploadUpdatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadUpdatesResponse.ProtoReflect.Descriptor instead.
-func (*UploadUpdatesResponse) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *UploadUpdatesResponse) GetUploadUrl() string {
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
 	if x != nil {
-		return x.UploadUrl
+		return x.Collections
 	}
-	return ""
+	return nil
 }
 
-type ExpirePasteRequest struct {
+// Puts a paste into a collection. A paste is in at most one collection, moving it takes it out
+// of the previous one
+type MovePasteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Key          string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId       string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId int64  `protobuf:"varint,3,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"` // 0 takes the paste out of its collection
 }
 
-func (x *ExpirePasteRequest) Reset() {
-	*x = ExpirePasteRequest{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[4]
+func (x *MovePasteRequest) Reset() {
+	*x = MovePasteRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExpirePasteRequest) String() string {
+func (x *MovePasteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpirePasteRequest) ProtoMessage() {}
+func (*MovePasteRequest) ProtoMessage() {}
 
-func (x *ExpirePasteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[4]
+func (x *MovePasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,48 +2030,91 @@ func (x *ExpirePasteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpirePasteRequest.ProtoReflect.Descriptor instead.
-func (*ExpirePasteRequest) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use MovePasteRequest.ProtoReflect.Descriptor instead.
+func (*MovePasteRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ExpirePasteRequest) GetKey() string {
+func (x *MovePasteRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-func (x *ExpirePasteRequest) GetUserId() string {
+func (x *MovePasteRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type ExpirePasteResponse struct {
+func (x *MovePasteRequest) GetCollectionId() int64 {
+	if x != nil {
+		return x.CollectionId
+	}
+	return 0
+}
+
+type MovePasteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+func (x *MovePasteResponse) Reset() {
+	*x = MovePasteResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExpirePasteResponse) Reset() {
-	*x = ExpirePasteResponse{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[5]
+func (x *MovePasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePasteResponse) ProtoMessage() {}
+
+func (x *MovePasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePasteResponse.ProtoReflect.Descriptor instead.
+func (*MovePasteResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{31}
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExpirePasteResponse) String() string {
+func (x *ListTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpirePasteResponse) ProtoMessage() {}
+func (*ListTagsRequest) ProtoMessage() {}
 
-func (x *ExpirePasteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[5]
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,42 +2125,42 @@ func (x *ExpirePasteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpirePasteResponse.ProtoReflect.Descriptor instead.
-func (*ExpirePasteResponse) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ExpirePasteResponse) GetMessage() string {
+func (x *ListTagsRequest) GetUserId() string {
 	if x != nil {
-		return x.Message
+		return x.UserId
 	}
 	return ""
 }
 
-// Expire all pastes for a user
-type ExpireAllPastesByUserIDRequest struct {
+type TagCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Corrected field number
+	Tag        string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	PasteCount int32  `protobuf:"varint,2,opt,name=paste_count,json=pasteCount,proto3" json:"paste_count,omitempty"`
 }
 
-func (x *ExpireAllPastesByUserIDRequest) Reset() {
-	*x = ExpireAllPastesByUserIDRequest{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[6]
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExpireAllPastesByUserIDRequest) String() string {
+func (x *TagCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpireAllPastesByUserIDRequest) ProtoMessage() {}
+func (*TagCount) ProtoMessage() {}
 
-func (x *ExpireAllPastesByUserIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[6]
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -377,41 +2171,48 @@ func (x *ExpireAllPastesByUserIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpireAllPastesByUserIDRequest.ProtoReflect.Descriptor instead.
-func (*ExpireAllPastesByUserIDRequest) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ExpireAllPastesByUserIDRequest) GetUserId() string {
+func (x *TagCount) GetTag() string {
 	if x != nil {
-		return x.UserId
+		return x.Tag
 	}
 	return ""
 }
 
-type ExpireAllPastesByUserIDResponse struct {
+func (x *TagCount) GetPasteCount() int32 {
+	if x != nil {
+		return x.PasteCount
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Response message (e.g., "All pastes expired successfully" or error details)
+	Tags []*TagCount `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` // Most used first
 }
 
-func (x *ExpireAllPastesByUserIDResponse) Reset() {
-	*x = ExpireAllPastesByUserIDResponse{}
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[7]
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExpireAllPastesByUserIDResponse) String() string {
+func (x *ListTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpireAllPastesByUserIDResponse) ProtoMessage() {}
+func (*ListTagsResponse) ProtoMessage() {}
 
-func (x *ExpireAllPastesByUserIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_paste_upload_paste_upload_proto_msgTypes[7]
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paste_upload_paste_upload_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -422,16 +2223,16 @@ func (x *ExpireAllPastesByUserIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpireAllPastesByUserIDResponse.ProtoReflect.Descriptor instead.
-func (*ExpireAllPastesByUserIDResponse) Descriptor() ([]byte, []int) {
-	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_paste_upload_paste_upload_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ExpireAllPastesByUserIDResponse) GetMessage() string {
+func (x *ListTagsResponse) GetTags() []*TagCount {
 	if x != nil {
-		return x.Message
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
 var File_paste_upload_paste_upload_proto protoreflect.FileDescriptor
@@ -442,7 +2243,7 @@ var file_paste_upload_paste_upload_proto_rawDesc = []byte{
 	0x6f, 0x12, 0x0b, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x1f,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
 	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x9a, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52,
+	0xf4, 0x03, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
 	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
@@ -451,63 +2252,344 @@ var file_paste_upload_paste_upload_proto_rawDesc = []byte{
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x78,
-	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x22, 0x79, 0x0a, 0x13,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55,
-	0x72, 0x6c, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x22, 0x41, 0x0a, 0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x15, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55,
-	0x72, 0x6c, 0x22, 0x3f, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78,
+	0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x75, 0x72, 0x6e, 0x5f, 0x61, 0x66,
+	0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x62, 0x75, 0x72, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x61, 0x64, 0x12, 0x37, 0x0a,
+	0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x12, 0x3d, 0x0a, 0x0a, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x69, 0x65, 0x72, 0x22, 0xdd, 0x01, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x64, 0x66,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x64, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x6b,
+	0x64, 0x66, 0x5f, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6b,
+	0x64, 0x66, 0x53, 0x61, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x64, 0x66, 0x5f, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x6b, 0x64, 0x66, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a,
+	0x0e, 0x6b, 0x64, 0x66, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6b, 0x69, 0x62, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6b, 0x64, 0x66, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x4b, 0x69, 0x62, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79,
+	0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6b, 0x65,
+	0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x79, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x43, 0x0a, 0x0f,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74,
+	0x65, 0x22, 0x8c, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x97, 0x01, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa3, 0x01, 0x0a, 0x14, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x4f, 0x0a, 0x09, 0x50, 0x61, 0x73, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x22, 0xb0, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x73, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x50, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x02, 0x0a, 0x1a, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
 	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x1e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c,
-	0x6c, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
-	0x3b, 0x0a, 0x1f, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74,
-	0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xff, 0x02, 0x0a,
-	0x0b, 0x50, 0x61, 0x73, 0x74, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x50, 0x0a, 0x0b,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x61,
+	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x61, 0x64, 0x64, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x22, 0xb8, 0x01, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61,
+	0x73, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x55,
+	0x0a, 0x12, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x2f, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50,
+	0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x1e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x3b, 0x0a, 0x1f, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61,
+	0x73, 0x74, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5f,
+	0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x25, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x81, 0x02, 0x0a, 0x0b, 0x41, 0x62, 0x75, 0x73, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x42, 0x79, 0x22, 0x72, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x4e,
+	0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x8c,
+	0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x46, 0x0a,
+	0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x53, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x56, 0x0a, 0x17, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x53, 0x0a, 0x18, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x54, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x73,
+	0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x62, 0x0a, 0x10, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x73, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3d, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x74, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3d, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x2a, 0x6b, 0x0a, 0x0a, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f,
+	0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x56, 0x49, 0x53, 0x49,
+	0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x4c, 0x49, 0x53, 0x54, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f,
+	0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x49, 0x53,
+	0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x44, 0x10, 0x03,
+	0x32, 0xe7, 0x0a, 0x0a, 0x0b, 0x50, 0x61, 0x73, 0x74, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x50, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x12,
+	0x1f, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x59, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x22, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a,
+	0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x21,
+	0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x56, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x61,
 	0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70,
-	0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56,
-	0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
-	0x21, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65,
-	0x50, 0x61, 0x73, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x17, 0x45, 0x78, 0x70, 0x69,
-	0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x44, 0x12, 0x2b, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74, 0x65,
-	0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2c, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x68, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x28, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61, 0x73,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x61, 0x73, 0x74,
+	0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x50, 0x61,
+	0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x17, 0x45,
 	0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74, 0x65, 0x73, 0x42, 0x79,
-	0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x11,
-	0x5a, 0x0f, 0x2e, 0x2f, 0x3b, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x2b, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61,
+	0x73, 0x74, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x50, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x73, 0x74, 0x65,
+	0x12, 0x1f, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x62, 0x75, 0x73, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x62, 0x75, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x70, 0x61, 0x73, 0x74,
+	0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x70, 0x61, 0x73,
+	0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x25, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x61, 0x73,
+	0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x73,
+	0x74, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x4d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x73, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x47, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x2e,
+	0x70, 0x61, 0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x61,
+	0x73, 0x74, 0x65, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x2f,
+	0x3b, 0x70, 0x61, 0x73, 0x74, 0x65, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -522,34 +2604,101 @@ func file_paste_upload_paste_upload_proto_rawDescGZIP() []byte {
 	return file_paste_upload_paste_upload_proto_rawDescData
 }
 
-var file_paste_upload_paste_upload_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_paste_upload_paste_upload_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_paste_upload_paste_upload_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_paste_upload_paste_upload_proto_goTypes = []any{
-	(*UploadPasteRequest)(nil),              // 0: pasteupload.UploadPasteRequest
-	(*UploadPasteResponse)(nil),             // 1: pasteupload.UploadPasteResponse
-	(*UploadUpdatesRequest)(nil),            // 2: pasteupload.UploadUpdatesRequest
-	(*UploadUpdatesResponse)(nil),           // 3: pasteupload.UploadUpdatesResponse
-	(*ExpirePasteRequest)(nil),              // 4: pasteupload.ExpirePasteRequest
-	(*ExpirePasteResponse)(nil),             // 5: pasteupload.ExpirePasteResponse
-	(*ExpireAllPastesByUserIDRequest)(nil),  // 6: pasteupload.ExpireAllPastesByUserIDRequest
-	(*ExpireAllPastesByUserIDResponse)(nil), // 7: pasteupload.ExpireAllPastesByUserIDResponse
-	(*timestamppb.Timestamp)(nil),           // 8: google.protobuf.Timestamp
+	(Visibility)(0),                         // 0: pasteupload.Visibility
+	(*UploadPasteRequest)(nil),              // 1: pasteupload.UploadPasteRequest
+	(*EncryptionHeader)(nil),                // 2: pasteupload.EncryptionHeader
+	(*UploadPasteResponse)(nil),             // 3: pasteupload.UploadPasteResponse
+	(*CompleteUploadRequest)(nil),           // 4: pasteupload.CompleteUploadRequest
+	(*CompleteUploadResponse)(nil),          // 5: pasteupload.CompleteUploadResponse
+	(*UploadContentRequest)(nil),            // 6: pasteupload.UploadContentRequest
+	(*PasteFile)(nil),                       // 7: pasteupload.PasteFile
+	(*UploadContentResponse)(nil),           // 8: pasteupload.UploadContentResponse
+	(*UploadUpdatesRequest)(nil),            // 9: pasteupload.UploadUpdatesRequest
+	(*UploadUpdatesResponse)(nil),           // 10: pasteupload.UploadUpdatesResponse
+	(*UpdatePasteMetadataRequest)(nil),      // 11: pasteupload.UpdatePasteMetadataRequest
+	(*UpdatePasteMetadataResponse)(nil),     // 12: pasteupload.UpdatePasteMetadataResponse
+	(*ExpirePasteRequest)(nil),              // 13: pasteupload.ExpirePasteRequest
+	(*ExpirePasteResponse)(nil),             // 14: pasteupload.ExpirePasteResponse
+	(*ExpireAllPastesByUserIDRequest)(nil),  // 15: pasteupload.ExpireAllPastesByUserIDRequest
+	(*ExpireAllPastesByUserIDResponse)(nil), // 16: pasteupload.ExpireAllPastesByUserIDResponse
+	(*ReportPasteRequest)(nil),              // 17: pasteupload.ReportPasteRequest
+	(*ReportPasteResponse)(nil),             // 18: pasteupload.ReportPasteResponse
+	(*AbuseReport)(nil),                     // 19: pasteupload.AbuseReport
+	(*ListAbuseReportsRequest)(nil),         // 20: pasteupload.ListAbuseReportsRequest
+	(*ListAbuseReportsResponse)(nil),        // 21: pasteupload.ListAbuseReportsResponse
+	(*Collection)(nil),                      // 22: pasteupload.Collection
+	(*CreateCollectionRequest)(nil),         // 23: pasteupload.CreateCollectionRequest
+	(*CreateCollectionResponse)(nil),        // 24: pasteupload.CreateCollectionResponse
+	(*RenameCollectionRequest)(nil),         // 25: pasteupload.RenameCollectionRequest
+	(*RenameCollectionResponse)(nil),        // 26: pasteupload.RenameCollectionResponse
+	(*DeleteCollectionRequest)(nil),         // 27: pasteupload.DeleteCollectionRequest
+	(*DeleteCollectionResponse)(nil),        // 28: pasteupload.DeleteCollectionResponse
+	(*ListCollectionsRequest)(nil),          // 29: pasteupload.ListCollectionsRequest
+	(*ListCollectionsResponse)(nil),         // 30: pasteupload.ListCollectionsResponse
+	(*MovePasteRequest)(nil),                // 31: pasteupload.MovePasteRequest
+	(*MovePasteResponse)(nil),               // 32: pasteupload.MovePasteResponse
+	(*ListTagsRequest)(nil),                 // 33: pasteupload.ListTagsRequest
+	(*TagCount)(nil),                        // 34: pasteupload.TagCount
+	(*ListTagsResponse)(nil),                // 35: pasteupload.ListTagsResponse
+	(*timestamppb.Timestamp)(nil),           // 36: google.protobuf.Timestamp
 }
 var file_paste_upload_paste_upload_proto_depIdxs = []int32{
-	8, // 0: pasteupload.UploadPasteRequest.expiration_date:type_name -> google.protobuf.Timestamp
-	8, // 1: pasteupload.UploadPasteResponse.expiration_date:type_name -> google.protobuf.Timestamp
-	0, // 2: pasteupload.PasteUpload.UploadPaste:input_type -> pasteupload.UploadPasteRequest
-	2, // 3: pasteupload.PasteUpload.UploadUpdates:input_type -> pasteupload.UploadUpdatesRequest
-	4, // 4: pasteupload.PasteUpload.ExpirePaste:input_type -> pasteupload.ExpirePasteRequest
-	6, // 5: pasteupload.PasteUpload.ExpireAllPastesByUserID:input_type -> pasteupload.ExpireAllPastesByUserIDRequest
-	1, // 6: pasteupload.PasteUpload.UploadPaste:output_type -> pasteupload.UploadPasteResponse
-	3, // 7: pasteupload.PasteUpload.UploadUpdates:output_type -> pasteupload.UploadUpdatesResponse
-	5, // 8: pasteupload.PasteUpload.ExpirePaste:output_type -> pasteupload.ExpirePasteResponse
-	7, // 9: pasteupload.PasteUpload.ExpireAllPastesByUserID:output_type -> pasteupload.ExpireAllPastesByUserIDResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	36, // 0: pasteupload.UploadPasteRequest.expiration_date:type_name -> google.protobuf.Timestamp
+	0,  // 1: pasteupload.UploadPasteRequest.visibility:type_name -> pasteupload.Visibility
+	2,  // 2: pasteupload.UploadPasteRequest.encryption:type_name -> pasteupload.EncryptionHeader
+	36, // 3: pasteupload.UploadPasteResponse.expiration_date:type_name -> google.protobuf.Timestamp
+	1,  // 4: pasteupload.UploadContentRequest.metadata:type_name -> pasteupload.UploadPasteRequest
+	7,  // 5: pasteupload.UploadContentRequest.file:type_name -> pasteupload.PasteFile
+	36, // 6: pasteupload.UploadContentResponse.expiration_date:type_name -> google.protobuf.Timestamp
+	7,  // 7: pasteupload.UploadContentResponse.files:type_name -> pasteupload.PasteFile
+	36, // 8: pasteupload.UpdatePasteMetadataRequest.expiration_date:type_name -> google.protobuf.Timestamp
+	36, // 9: pasteupload.UpdatePasteMetadataResponse.expiration_date:type_name -> google.protobuf.Timestamp
+	36, // 10: pasteupload.AbuseReport.created_at:type_name -> google.protobuf.Timestamp
+	36, // 11: pasteupload.AbuseReport.resolved_at:type_name -> google.protobuf.Timestamp
+	19, // 12: pasteupload.ListAbuseReportsResponse.reports:type_name -> pasteupload.AbuseReport
+	36, // 13: pasteupload.Collection.created_at:type_name -> google.protobuf.Timestamp
+	22, // 14: pasteupload.CreateCollectionResponse.collection:type_name -> pasteupload.Collection
+	22, // 15: pasteupload.RenameCollectionResponse.collection:type_name -> pasteupload.Collection
+	22, // 16: pasteupload.ListCollectionsResponse.collections:type_name -> pasteupload.Collection
+	34, // 17: pasteupload.ListTagsResponse.tags:type_name -> pasteupload.TagCount
+	1,  // 18: pasteupload.PasteUpload.UploadPaste:input_type -> pasteupload.UploadPasteRequest
+	4,  // 19: pasteupload.PasteUpload.CompleteUpload:input_type -> pasteupload.CompleteUploadRequest
+	6,  // 20: pasteupload.PasteUpload.UploadContent:input_type -> pasteupload.UploadContentRequest
+	9,  // 21: pasteupload.PasteUpload.UploadUpdates:input_type -> pasteupload.UploadUpdatesRequest
+	11, // 22: pasteupload.PasteUpload.UpdatePasteMetadata:input_type -> pasteupload.UpdatePasteMetadataRequest
+	13, // 23: pasteupload.PasteUpload.ExpirePaste:input_type -> pasteupload.ExpirePasteRequest
+	15, // 24: pasteupload.PasteUpload.ExpireAllPastesByUserID:input_type -> pasteupload.ExpireAllPastesByUserIDRequest
+	17, // 25: pasteupload.PasteUpload.ReportPaste:input_type -> pasteupload.ReportPasteRequest
+	20, // 26: pasteupload.PasteUpload.ListAbuseReports:input_type -> pasteupload.ListAbuseReportsRequest
+	23, // 27: pasteupload.PasteUpload.CreateCollection:input_type -> pasteupload.CreateCollectionRequest
+	25, // 28: pasteupload.PasteUpload.RenameCollection:input_type -> pasteupload.RenameCollectionRequest
+	27, // 29: pasteupload.PasteUpload.DeleteCollection:input_type -> pasteupload.DeleteCollectionRequest
+	29, // 30: pasteupload.PasteUpload.ListCollections:input_type -> pasteupload.ListCollectionsRequest
+	31, // 31: pasteupload.PasteUpload.MovePaste:input_type -> pasteupload.MovePasteRequest
+	33, // 32: pasteupload.PasteUpload.ListTags:input_type -> pasteupload.ListTagsRequest
+	3,  // 33: pasteupload.PasteUpload.UploadPaste:output_type -> pasteupload.UploadPasteResponse
+	5,  // 34: pasteupload.PasteUpload.CompleteUpload:output_type -> pasteupload.CompleteUploadResponse
+	8,  // 35: pasteupload.PasteUpload.UploadContent:output_type -> pasteupload.UploadContentResponse
+	10, // 36: pasteupload.PasteUpload.UploadUpdates:output_type -> pasteupload.UploadUpdatesResponse
+	12, // 37: pasteupload.PasteUpload.UpdatePasteMetadata:output_type -> pasteupload.UpdatePasteMetadataResponse
+	14, // 38: pasteupload.PasteUpload.ExpirePaste:output_type -> pasteupload.ExpirePasteResponse
+	16, // 39: pasteupload.PasteUpload.ExpireAllPastesByUserID:output_type -> pasteupload.ExpireAllPastesByUserIDResponse
+	18, // 40: pasteupload.PasteUpload.ReportPaste:output_type -> pasteupload.ReportPasteResponse
+	21, // 41: pasteupload.PasteUpload.ListAbuseReports:output_type -> pasteupload.ListAbuseReportsResponse
+	24, // 42: pasteupload.PasteUpload.CreateCollection:output_type -> pasteupload.CreateCollectionResponse
+	26, // 43: pasteupload.PasteUpload.RenameCollection:output_type -> pasteupload.RenameCollectionResponse
+	28, // 44: pasteupload.PasteUpload.DeleteCollection:output_type -> pasteupload.DeleteCollectionResponse
+	30, // 45: pasteupload.PasteUpload.ListCollections:output_type -> pasteupload.ListCollectionsResponse
+	32, // 46: pasteupload.PasteUpload.MovePaste:output_type -> pasteupload.MovePasteResponse
+	35, // 47: pasteupload.PasteUpload.ListTags:output_type -> pasteupload.ListTagsResponse
+	33, // [33:48] is the sub-list for method output_type
+	18, // [18:33] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_paste_upload_paste_upload_proto_init() }
@@ -557,18 +2706,25 @@ func file_paste_upload_paste_upload_proto_init() {
 	if File_paste_upload_paste_upload_proto != nil {
 		return
 	}
+	file_paste_upload_paste_upload_proto_msgTypes[5].OneofWrappers = []any{
+		(*UploadContentRequest_Metadata)(nil),
+		(*UploadContentRequest_Chunk)(nil),
+		(*UploadContentRequest_File)(nil),
+	}
+	file_paste_upload_paste_upload_proto_msgTypes[10].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_paste_upload_paste_upload_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_paste_upload_paste_upload_proto_goTypes,
 		DependencyIndexes: file_paste_upload_paste_upload_proto_depIdxs,
+		EnumInfos:         file_paste_upload_paste_upload_proto_enumTypes,
 		MessageInfos:      file_paste_upload_paste_upload_proto_msgTypes,
 	}.Build()
 	File_paste_upload_paste_upload_proto = out.File
@@ -20,9 +20,20 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PasteUpload_UploadPaste_FullMethodName             = "/pasteupload.PasteUpload/UploadPaste"
+	PasteUpload_CompleteUpload_FullMethodName          = "/pasteupload.PasteUpload/CompleteUpload"
+	PasteUpload_UploadContent_FullMethodName           = "/pasteupload.PasteUpload/UploadContent"
 	PasteUpload_UploadUpdates_FullMethodName           = "/pasteupload.PasteUpload/UploadUpdates"
+	PasteUpload_UpdatePasteMetadata_FullMethodName     = "/pasteupload.PasteUpload/UpdatePasteMetadata"
 	PasteUpload_ExpirePaste_FullMethodName             = "/pasteupload.PasteUpload/ExpirePaste"
 	PasteUpload_ExpireAllPastesByUserID_FullMethodName = "/pasteupload.PasteUpload/ExpireAllPastesByUserID"
+	PasteUpload_ReportPaste_FullMethodName             = "/pasteupload.PasteUpload/ReportPaste"
+	PasteUpload_ListAbuseReports_FullMethodName        = "/pasteupload.PasteUpload/ListAbuseReports"
+	PasteUpload_CreateCollection_FullMethodName        = "/pasteupload.PasteUpload/CreateCollection"
+	PasteUpload_RenameCollection_FullMethodName        = "/pasteupload.PasteUpload/RenameCollection"
+	PasteUpload_DeleteCollection_FullMethodName        = "/pasteupload.PasteUpload/DeleteCollection"
+	PasteUpload_ListCollections_FullMethodName         = "/pasteupload.PasteUpload/ListCollections"
+	PasteUpload_MovePaste_FullMethodName               = "/pasteupload.PasteUpload/MovePaste"
+	PasteUpload_ListTags_FullMethodName                = "/pasteupload.PasteUpload/ListTags"
 )
 
 // PasteUploadClient is the client API for PasteUpload service.
@@ -32,9 +43,20 @@ const (
 // Upload RPC definition
 type PasteUploadClient interface {
 	UploadPaste(ctx context.Context, in *UploadPasteRequest, opts ...grpc.CallOption) (*UploadPasteResponse, error)
+	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error)
+	UploadContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse], error)
 	UploadUpdates(ctx context.Context, in *UploadUpdatesRequest, opts ...grpc.CallOption) (*UploadUpdatesResponse, error)
+	UpdatePasteMetadata(ctx context.Context, in *UpdatePasteMetadataRequest, opts ...grpc.CallOption) (*UpdatePasteMetadataResponse, error)
 	ExpirePaste(ctx context.Context, in *ExpirePasteRequest, opts ...grpc.CallOption) (*ExpirePasteResponse, error)
 	ExpireAllPastesByUserID(ctx context.Context, in *ExpireAllPastesByUserIDRequest, opts ...grpc.CallOption) (*ExpireAllPastesByUserIDResponse, error)
+	ReportPaste(ctx context.Context, in *ReportPasteRequest, opts ...grpc.CallOption) (*ReportPasteResponse, error)
+	ListAbuseReports(ctx context.Context, in *ListAbuseReportsRequest, opts ...grpc.CallOption) (*ListAbuseReportsResponse, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	MovePaste(ctx context.Context, in *MovePasteRequest, opts ...grpc.CallOption) (*MovePasteResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
 }
 
 type pasteUploadClient struct {
@@ -55,6 +77,29 @@ func (c *pasteUploadClient) UploadPaste(ctx context.Context, in *UploadPasteRequ
 	return out, nil
 }
 
+func (c *pasteUploadClient) CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteUploadResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_CompleteUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) UploadContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PasteUpload_ServiceDesc.Streams[0], PasteUpload_UploadContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadContentRequest, UploadContentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteUpload_UploadContentClient = grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse]
+
 func (c *pasteUploadClient) UploadUpdates(ctx context.Context, in *UploadUpdatesRequest, opts ...grpc.CallOption) (*UploadUpdatesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UploadUpdatesResponse)
@@ -65,6 +110,16 @@ func (c *pasteUploadClient) UploadUpdates(ctx context.Context, in *UploadUpdates
 	return out, nil
 }
 
+func (c *pasteUploadClient) UpdatePasteMetadata(ctx context.Context, in *UpdatePasteMetadataRequest, opts ...grpc.CallOption) (*UpdatePasteMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePasteMetadataResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_UpdatePasteMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pasteUploadClient) ExpirePaste(ctx context.Context, in *ExpirePasteRequest, opts ...grpc.CallOption) (*ExpirePasteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExpirePasteResponse)
@@ -85,6 +140,86 @@ func (c *pasteUploadClient) ExpireAllPastesByUserID(ctx context.Context, in *Exp
 	return out, nil
 }
 
+func (c *pasteUploadClient) ReportPaste(ctx context.Context, in *ReportPasteRequest, opts ...grpc.CallOption) (*ReportPasteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportPasteResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_ReportPaste_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) ListAbuseReports(ctx context.Context, in *ListAbuseReportsRequest, opts ...grpc.CallOption) (*ListAbuseReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAbuseReportsResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_ListAbuseReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameCollectionResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_RenameCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) MovePaste(ctx context.Context, in *MovePasteRequest, opts ...grpc.CallOption) (*MovePasteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovePasteResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_MovePaste_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteUploadClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, PasteUpload_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PasteUploadServer is the server API for PasteUpload service.
 // All implementations must embed UnimplementedPasteUploadServer
 // for forward compatibility.
@@ -92,9 +227,20 @@ func (c *pasteUploadClient) ExpireAllPastesByUserID(ctx context.Context, in *Exp
 // Upload RPC definition
 type PasteUploadServer interface {
 	UploadPaste(context.Context, *UploadPasteRequest) (*UploadPasteResponse, error)
+	CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error)
+	UploadContent(grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]) error
 	UploadUpdates(context.Context, *UploadUpdatesRequest) (*UploadUpdatesResponse, error)
+	UpdatePasteMetadata(context.Context, *UpdatePasteMetadataRequest) (*UpdatePasteMetadataResponse, error)
 	ExpirePaste(context.Context, *ExpirePasteRequest) (*ExpirePasteResponse, error)
 	ExpireAllPastesByUserID(context.Context, *ExpireAllPastesByUserIDRequest) (*ExpireAllPastesByUserIDResponse, error)
+	ReportPaste(context.Context, *ReportPasteRequest) (*ReportPasteResponse, error)
+	ListAbuseReports(context.Context, *ListAbuseReportsRequest) (*ListAbuseReportsResponse, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	MovePaste(context.Context, *MovePasteRequest) (*MovePasteResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
 	mustEmbedUnimplementedPasteUploadServer()
 }
 
@@ -108,15 +254,48 @@ type UnimplementedPasteUploadServer struct{}
 func (UnimplementedPasteUploadServer) UploadPaste(context.Context, *UploadPasteRequest) (*UploadPasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadPaste not implemented")
 }
+func (UnimplementedPasteUploadServer) CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedPasteUploadServer) UploadContent(grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadContent not implemented")
+}
 func (UnimplementedPasteUploadServer) UploadUpdates(context.Context, *UploadUpdatesRequest) (*UploadUpdatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadUpdates not implemented")
 }
+func (UnimplementedPasteUploadServer) UpdatePasteMetadata(context.Context, *UpdatePasteMetadataRequest) (*UpdatePasteMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePasteMetadata not implemented")
+}
 func (UnimplementedPasteUploadServer) ExpirePaste(context.Context, *ExpirePasteRequest) (*ExpirePasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpirePaste not implemented")
 }
 func (UnimplementedPasteUploadServer) ExpireAllPastesByUserID(context.Context, *ExpireAllPastesByUserIDRequest) (*ExpireAllPastesByUserIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpireAllPastesByUserID not implemented")
 }
+func (UnimplementedPasteUploadServer) ReportPaste(context.Context, *ReportPasteRequest) (*ReportPasteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPaste not implemented")
+}
+func (UnimplementedPasteUploadServer) ListAbuseReports(context.Context, *ListAbuseReportsRequest) (*ListAbuseReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAbuseReports not implemented")
+}
+func (UnimplementedPasteUploadServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedPasteUploadServer) RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCollection not implemented")
+}
+func (UnimplementedPasteUploadServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedPasteUploadServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedPasteUploadServer) MovePaste(context.Context, *MovePasteRequest) (*MovePasteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MovePaste not implemented")
+}
+func (UnimplementedPasteUploadServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
 func (UnimplementedPasteUploadServer) mustEmbedUnimplementedPasteUploadServer() {}
 func (UnimplementedPasteUploadServer) testEmbeddedByValue()                     {}
 
@@ -156,6 +335,31 @@ func _PasteUpload_UploadPaste_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PasteUpload_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).CompleteUpload(ctx, req.(*CompleteUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_UploadContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PasteUploadServer).UploadContent(&grpc.GenericServerStream[UploadContentRequest, UploadContentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteUpload_UploadContentServer = grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]
+
 func _PasteUpload_UploadUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UploadUpdatesRequest)
 	if err := dec(in); err != nil {
@@ -174,6 +378,24 @@ func _PasteUpload_UploadUpdates_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PasteUpload_UpdatePasteMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePasteMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).UpdatePasteMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_UpdatePasteMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).UpdatePasteMetadata(ctx, req.(*UpdatePasteMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PasteUpload_ExpirePaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpirePasteRequest)
 	if err := dec(in); err != nil {
@@ -210,6 +432,150 @@ func _PasteUpload_ExpireAllPastesByUserID_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PasteUpload_ReportPaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPasteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).ReportPaste(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_ReportPaste_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).ReportPaste(ctx, req.(*ReportPasteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_ListAbuseReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAbuseReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).ListAbuseReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_ListAbuseReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).ListAbuseReports(ctx, req.(*ListAbuseReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_RenameCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).RenameCollection(ctx, req.(*RenameCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_MovePaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovePasteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).MovePaste(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_MovePaste_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).MovePaste(ctx, req.(*MovePasteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteUpload_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteUploadServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteUpload_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteUploadServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PasteUpload_ServiceDesc is the grpc.ServiceDesc for PasteUpload service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,10 +587,18 @@ var PasteUpload_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadPaste",
 			Handler:    _PasteUpload_UploadPaste_Handler,
 		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _PasteUpload_CompleteUpload_Handler,
+		},
 		{
 			MethodName: "UploadUpdates",
 			Handler:    _PasteUpload_UploadUpdates_Handler,
 		},
+		{
+			MethodName: "UpdatePasteMetadata",
+			Handler:    _PasteUpload_UpdatePasteMetadata_Handler,
+		},
 		{
 			MethodName: "ExpirePaste",
 			Handler:    _PasteUpload_ExpirePaste_Handler,
@@ -233,7 +607,45 @@ var PasteUpload_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExpireAllPastesByUserID",
 			Handler:    _PasteUpload_ExpireAllPastesByUserID_Handler,
 		},
+		{
+			MethodName: "ReportPaste",
+			Handler:    _PasteUpload_ReportPaste_Handler,
+		},
+		{
+			MethodName: "ListAbuseReports",
+			Handler:    _PasteUpload_ListAbuseReports_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _PasteUpload_CreateCollection_Handler,
+		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _PasteUpload_RenameCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _PasteUpload_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _PasteUpload_ListCollections_Handler,
+		},
+		{
+			MethodName: "MovePaste",
+			Handler:    _PasteUpload_MovePaste_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _PasteUpload_ListTags_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadContent",
+			Handler:       _PasteUpload_UploadContent_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "paste_upload/paste_upload.proto",
 }
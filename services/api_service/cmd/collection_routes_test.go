@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pkggrpc "github.com/NesterovYehor/TextNest/pkg/grpc"
+	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/upload_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/config"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// missingID is a collection no user owns.
+const missingID = 404
+
+// fakeAuthServer accepts the access token "valid" of user-1.
+type fakeAuthServer struct {
+	auth.UnimplementedAuthServiceServer
+}
+
+func (fakeAuthServer) AuthorizeUser(ctx context.Context, req *auth.AuthorizeUserRequest) (*auth.AuthorizeUserResponse, error) {
+	if req.Tocken != "valid" {
+		return nil, status.Error(codes.Unauthenticated, "token is invalid")
+	}
+	return &auth.AuthorizeUserResponse{UserId: "user-1", Role: "user"}, nil
+}
+
+// fakeUploadServer owns the collection "work" of user-1 and records the pastes it moves.
+type fakeUploadServer struct {
+	pb.UnimplementedPasteUploadServer
+
+	mu    sync.Mutex
+	moves []*pb.MovePasteRequest
+}
+
+func (s *fakeUploadServer) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	return &pb.ListCollectionsResponse{Collections: []*pb.Collection{
+		{Id: 1, Name: "work", CreatedAt: timestamppb.Now(), PasteCount: 2},
+	}}, nil
+}
+
+func (s *fakeUploadServer) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	if req.Name == "work" {
+		return nil, status.Error(codes.AlreadyExists, "collection already exists")
+	}
+	return &pb.CreateCollectionResponse{Collection: &pb.Collection{Id: 2, Name: req.Name, CreatedAt: timestamppb.Now()}}, nil
+}
+
+func (s *fakeUploadServer) RenameCollection(ctx context.Context, req *pb.RenameCollectionRequest) (*pb.RenameCollectionResponse, error) {
+	if req.Id == missingID {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	return &pb.RenameCollectionResponse{Collection: &pb.Collection{Id: req.Id, Name: req.Name, CreatedAt: timestamppb.Now()}}, nil
+}
+
+func (s *fakeUploadServer) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	if req.Id == missingID {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+func (s *fakeUploadServer) MovePaste(ctx context.Context, req *pb.MovePasteRequest) (*pb.MovePasteResponse, error) {
+	if req.Key == "foreign" {
+		return nil, status.Error(codes.PermissionDenied, "paste belongs to another user")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.moves = append(s.moves, req)
+	return &pb.MovePasteResponse{}, nil
+}
+
+func (s *fakeUploadServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	return &pb.ListTagsResponse{Tags: []*pb.TagCount{{Tag: "go", PasteCount: 3}}}, nil
+}
+
+// serveGRPC starts the fakes on a local port, the gateway clients connect to it like to the real services.
+func serveGRPC(t *testing.T, upload *fakeUploadServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	pb.RegisterPasteUploadServer(srv, upload)
+	auth.RegisterAuthServiceServer(srv, fakeAuthServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestCollectionRoutes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	upload := &fakeUploadServer{}
+	target := serveGRPC(t, upload)
+	cfg := &config.Config{
+		UploadService:   &pkggrpc.GrpcConfig{Port: target},
+		DownloadService: &pkggrpc.GrpcConfig{Port: target},
+		AuthService:     &pkggrpc.GrpcConfig{Port: target},
+		KeyService:      &pkggrpc.GrpcConfig{Port: target},
+	}
+	cfg.Auth.CacheSize = 10
+	cfg.Auth.CacheTTL = time.Minute
+	cfg.Auth.RevocationRetention = time.Hour
+	appContext, err := app.NewAppContext(cfg, ctx, jsonlog.New(io.Discard, slog.LevelError))
+	require.NoError(t, err)
+	t.Cleanup(func() { appContext.Close() })
+	mux := routes(ctx, cfg, appContext)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "list collections", method: http.MethodGet, path: "/v1/collections", token: "valid", wantStatus: http.StatusOK, wantBody: `"name":"work"`},
+		{name: "list collections anonymously", method: http.MethodGet, path: "/v1/collections", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", method: http.MethodGet, path: "/v1/collections", token: "stolen", wantStatus: http.StatusUnauthorized},
+		{name: "create collection", method: http.MethodPost, path: "/v1/collections", token: "valid", body: `{"name":"personal"}`, wantStatus: http.StatusCreated, wantBody: `"name":"personal"`},
+		{name: "create duplicate collection", method: http.MethodPost, path: "/v1/collections", token: "valid", body: `{"name":"work"}`, wantStatus: http.StatusConflict},
+		{name: "rename collection", method: http.MethodPatch, path: "/v1/collections/1", token: "valid", body: `{"name":"jobs"}`, wantStatus: http.StatusOK, wantBody: `"name":"jobs"`},
+		{name: "rename missing collection", method: http.MethodPatch, path: "/v1/collections/404", token: "valid", body: `{"name":"jobs"}`, wantStatus: http.StatusNotFound},
+		{name: "rename with invalid ID", method: http.MethodPatch, path: "/v1/collections/abc", token: "valid", body: `{"name":"jobs"}`, wantStatus: http.StatusBadRequest},
+		{name: "delete collection", method: http.MethodDelete, path: "/v1/collections/1", token: "valid", wantStatus: http.StatusOK},
+		{name: "delete missing collection", method: http.MethodDelete, path: "/v1/collections/404", token: "valid", wantStatus: http.StatusNotFound},
+		{name: "move paste", method: http.MethodPut, path: "/v1/collections/pastes/abc123", token: "valid", body: `{"collection_id":1}`, wantStatus: http.StatusOK},
+		{name: "move paste without collection", method: http.MethodPut, path: "/v1/collections/pastes/abc123", token: "valid", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "move paste of another user", method: http.MethodPut, path: "/v1/collections/pastes/foreign", token: "valid", body: `{"collection_id":1}`, wantStatus: http.StatusForbidden},
+		{name: "remove paste from its collection", method: http.MethodDelete, path: "/v1/collections/pastes/abc123", token: "valid", wantStatus: http.StatusOK},
+		{name: "list tags", method: http.MethodGet, path: "/v1/tags", token: "valid", wantStatus: http.StatusOK, wantBody: `"tag":"go"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			assert.True(t, json.Valid(rec.Body.Bytes()))
+			if tt.wantBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+
+	// Moving names the collection, removing takes the paste out of whichever it is in
+	upload.mu.Lock()
+	defer upload.mu.Unlock()
+	if assert.Len(t, upload.moves, 2) {
+		assert.Equal(t, "abc123", upload.moves[0].Key)
+		assert.Equal(t, "user-1", upload.moves[0].UserId)
+		assert.Equal(t, int64(1), upload.moves[0].CollectionId)
+		assert.Zero(t, upload.moves[1].CollectionId)
+	}
+}
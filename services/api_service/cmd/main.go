@@ -22,7 +22,6 @@ import (
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	"github.com/NesterovYehor/TextNest/services/api_service/config"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
-	"github.com/NesterovYehor/TextNest/services/api_service/internal/handler"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/middlewares"
 )
 
@@ -53,22 +52,16 @@ func main() {
 		}
 	}()
 
-	mux := http.NewServeMux()
-	mux.Handle("POST /v1/pastes/upload", middlewares.Authenticate(http.HandlerFunc(handler.UploadPasteHandler(appContext))))
-	mux.Handle("GET /v1/pastes/download", middlewares.Authenticate(handler.DownloadPaste(cfg, appContext)))
-	mux.Handle("GET /v1/pastes/download/all", middlewares.Authenticate(handler.DownloadAllPastesOfUser(cfg, appContext)))
-	mux.Handle("GET /v1/pastes/update/{key}", middlewares.Authenticate(handler.UpdatePasteHandler(appContext)))
-	mux.Handle("DELETE /v1/pastes/expire/{key}", middlewares.Authenticate(handler.ExpirePasteHandler(appContext)))
-	mux.Handle("DELETE /v1/pastes/expire/all", middlewares.Authenticate(handler.ExpireAllUserPastesHandler(appContext)))
-	mux.HandleFunc("POST /v1/users/signup", handler.SignUpHandler(appContext, ctx))
-	mux.HandleFunc("GET /v1/users/login", handler.LogInHandler(appContext, ctx))
-	mux.HandleFunc("GET /v1/users/activate/{token}", handler.ActivateUser(appContext))
-	mux.Handle("POST /v1/users/password/{token}", middlewares.Authenticate(handler.ResetPassword(appContext)))
-	mux.Handle("GET /v1/tokens/password-reset", middlewares.Authenticate(handler.SendPasswordResetEmail(appContext)))
-	mux.Handle("POST /v1/tokens/refresh", http.HandlerFunc(handler.RefreshTokens(appContext)))
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	// Without the revocation consumer every access token is checked by the auth service
+	if cfg.Kafka != nil {
+		go func() {
+			if err := appContext.RunRevocationConsumer(cfg, ctx); err != nil {
+				logger.PrintError(ctx, fmt.Errorf("revocation consumer stopped, access tokens are checked by the auth service: %w", err), nil)
+			}
+		}()
+	}
+
+	mux := routes(ctx, cfg, appContext)
 
 	server := &http.Server{
 		Addr:         cfg.HttpAddr,
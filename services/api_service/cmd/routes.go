@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/services/api_service/config"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/handler"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/middlewares"
+)
+
+// routes registers every endpoint of the gateway. ServeMux panics on patterns that overlap
+// without one being more specific, so a conflicting route fails the tests instead of the startup.
+func routes(ctx context.Context, cfg *config.Config, appContext *app.AppContext) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("POST /v1/pastes", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, http.HandlerFunc(handler.UploadContentHandler(appContext)))))
+	mux.Handle("POST /v1/pastes/upload", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, http.HandlerFunc(handler.UploadPasteHandler(appContext)))))
+	mux.Handle("PATCH /v1/pastes/{key}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.UpdatePasteMetadataHandler(appContext))))
+	mux.Handle("POST /v1/pastes/{key}/complete", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.CompleteUploadHandler(appContext))))
+	mux.Handle("GET /v1/pastes/download", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.DownloadPaste(cfg, appContext))))
+	mux.Handle("GET /v1/pastes/download/all", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.DownloadAllPastesOfUser(cfg, appContext))))
+	mux.Handle("PUT /v1/pastes/update/{key}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.UpdatePasteHandler(appContext))))
+	mux.Handle("GET /v1/pastes/search", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.SearchPastesHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/versions", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.PasteVersionsHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/diff", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.PasteDiffHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/render", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.RenderPasteHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/raw", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.RawPasteHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/files/{name}/render", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.RenderPasteHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/files/{name}/raw", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.RawPasteHandler(appContext))))
+	mux.Handle("GET /v1/pastes/{key}/archive", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.PasteArchiveHandler(appContext))))
+	mux.Handle("DELETE /v1/pastes/expire/{key}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteDelete, handler.ExpirePasteHandler(appContext))))
+	mux.Handle("PUT /v1/collections/pastes/{key}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.MovePasteHandler(appContext))))
+	mux.Handle("DELETE /v1/collections/pastes/{key}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.RemovePasteFromCollectionHandler(appContext))))
+	mux.Handle("GET /v1/collections", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.ListCollectionsHandler(appContext))))
+	mux.Handle("POST /v1/collections", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.CreateCollectionHandler(appContext))))
+	mux.Handle("PATCH /v1/collections/{id}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.RenameCollectionHandler(appContext))))
+	mux.Handle("DELETE /v1/collections/{id}", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteWrite, handler.DeleteCollectionHandler(appContext))))
+	mux.Handle("GET /v1/tags", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteRead, handler.ListTagsHandler(appContext))))
+	mux.Handle("POST /v1/pastes/{key}/report", middlewares.Authenticate(http.HandlerFunc(handler.ReportPasteHandler(appContext))))
+	mux.Handle("DELETE /v1/pastes/expire/all", middlewares.Authenticate(middlewares.RequireScope(middlewares.ScopePasteDelete, handler.ExpireAllUserPastesHandler(appContext))))
+	mux.HandleFunc("POST /v1/users/signup", handler.SignUpHandler(appContext, ctx))
+	mux.HandleFunc("GET /v1/users/login", handler.LogInHandler(appContext, ctx))
+	mux.HandleFunc("POST /v1/users/login/mfa", handler.LogInMFAHandler(appContext))
+	mux.HandleFunc("GET /v1/users/sso/{provider}", handler.StartSSOHandler(appContext))
+	mux.HandleFunc("GET /v1/users/sso/{provider}/callback", handler.SSOCallbackHandler(appContext))
+	mux.HandleFunc("GET /v1/users/activate/{token}", handler.ActivateUser(appContext))
+	mux.Handle("POST /v1/users/password/{token}", middlewares.Authenticate(middlewares.RequireSession(handler.ResetPassword(appContext))))
+	mux.Handle("GET /v1/tokens/password-reset", middlewares.Authenticate(middlewares.RequireSession(handler.SendPasswordResetEmail(appContext))))
+	mux.Handle("POST /v1/tokens/refresh", http.HandlerFunc(handler.RefreshTokens(appContext)))
+	mux.Handle("POST /v1/users/logout", http.HandlerFunc(handler.LogOutHandler(appContext)))
+	mux.Handle("POST /v1/users/logout/all", middlewares.Authenticate(middlewares.RequireSession(handler.LogOutAllHandler(appContext))))
+	mux.Handle("POST /v1/users/api-keys", middlewares.Authenticate(middlewares.RequireSession(handler.CreateAPIKeyHandler(appContext))))
+	mux.Handle("GET /v1/users/api-keys", middlewares.Authenticate(middlewares.RequireSession(handler.ListAPIKeysHandler(appContext))))
+	mux.Handle("DELETE /v1/users/api-keys/{id}", middlewares.Authenticate(middlewares.RequireSession(handler.RevokeAPIKeyHandler(appContext))))
+	mux.Handle("POST /v1/users/mfa/totp", middlewares.Authenticate(middlewares.RequireSession(handler.EnrollTOTPHandler(appContext))))
+	mux.Handle("POST /v1/users/mfa/totp/confirm", middlewares.Authenticate(middlewares.RequireSession(handler.ConfirmTOTPHandler(appContext))))
+	mux.Handle("DELETE /v1/users/mfa/totp", middlewares.Authenticate(middlewares.RequireSession(handler.DisableTOTPHandler(appContext))))
+	mux.Handle("GET /v1/users/me", middlewares.Authenticate(middlewares.RequireSession(handler.GetProfileHandler(appContext))))
+	mux.Handle("PATCH /v1/users/me", middlewares.Authenticate(middlewares.RequireSession(handler.UpdateProfileHandler(appContext))))
+	mux.Handle("DELETE /v1/users/me", middlewares.Authenticate(middlewares.RequireSession(handler.DeleteAccountHandler(appContext))))
+	mux.Handle("PUT /v1/users/me/password", middlewares.Authenticate(middlewares.RequireSession(handler.ChangePasswordHandler(appContext))))
+	mux.Handle("POST /v1/users/me/email", middlewares.Authenticate(middlewares.RequireSession(handler.ChangeEmailHandler(appContext))))
+	mux.Handle("GET /v1/users/sessions", middlewares.Authenticate(middlewares.RequireSession(handler.ListSessionsHandler(appContext))))
+	mux.Handle("DELETE /v1/users/sessions/{id}", middlewares.Authenticate(middlewares.RequireSession(handler.RevokeSessionHandler(appContext))))
+	mux.HandleFunc("GET /v1/users/email/confirm/{token}", handler.ConfirmEmailChangeHandler(appContext))
+	mux.Handle("GET /v1/admin/users", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleAdmin, handler.ListUsersHandler(appContext)))))
+	mux.Handle("PUT /v1/admin/users/{id}/status", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleAdmin, handler.SetUserStatusHandler(appContext)))))
+	mux.Handle("PUT /v1/admin/users/{id}/role", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleAdmin, handler.SetUserRoleHandler(appContext)))))
+	mux.Handle("GET /v1/admin/audit-log", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleAdmin, handler.ListAuditLogHandler(appContext)))))
+	mux.Handle("DELETE /v1/admin/pastes/{key}", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleModerator, handler.ForceExpirePasteHandler(appContext)))))
+	mux.Handle("GET /v1/admin/reports", middlewares.Authenticate(middlewares.RequireSession(middlewares.RequireRole(middlewares.RoleModerator, handler.ListAbuseReportsHandler(appContext)))))
+	mux.HandleFunc("GET /.well-known/jwks.json", handler.JWKSHandler(appContext))
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NesterovYehor/TextNest/services/api_service/config"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutes(t *testing.T) {
+	// Registering overlapping patterns panics, the gateway would not start
+	mux := routes(context.Background(), &config.Config{}, &app.AppContext{})
+
+	tests := []struct {
+		method      string
+		path        string
+		wantPattern string
+	}{
+		{http.MethodPut, "/v1/pastes/update/collection", "PUT /v1/pastes/update/{key}"},
+		{http.MethodDelete, "/v1/pastes/expire/collection", "DELETE /v1/pastes/expire/{key}"},
+		{http.MethodDelete, "/v1/pastes/expire/all", "DELETE /v1/pastes/expire/all"},
+		{http.MethodPut, "/v1/collections/pastes/abc123", "PUT /v1/collections/pastes/{key}"},
+		{http.MethodDelete, "/v1/collections/pastes/abc123", "DELETE /v1/collections/pastes/{key}"},
+		{http.MethodGet, "/v1/collections", "GET /v1/collections"},
+		{http.MethodPost, "/v1/collections", "POST /v1/collections"},
+		{http.MethodPatch, "/v1/collections/7", "PATCH /v1/collections/{id}"},
+		{http.MethodDelete, "/v1/collections/7", "DELETE /v1/collections/{id}"},
+		{http.MethodGet, "/v1/tags", "GET /v1/tags"},
+		{http.MethodGet, "/v1/pastes/download/all", "GET /v1/pastes/download/all"},
+		{http.MethodGet, "/v1/pastes/abc123/raw", "GET /v1/pastes/{key}/raw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			_, pattern := mux.Handler(httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.wantPattern, pattern)
+		})
+	}
+}
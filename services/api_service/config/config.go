@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	"github.com/NesterovYehor/TextNest/pkg/logger"
 	"gopkg.in/yaml.v3"
 )
@@ -24,6 +25,12 @@ type Config struct {
 		Enabled         bool          `yaml:"enabled"`
 		CleanupInterval time.Duration `yaml:"cleanup_interval"`
 	} `yaml:"limiter"`
+	Auth struct {
+		CacheSize           int           `yaml:"cache_size"`           // Users whose existence is remembered
+		CacheTTL            time.Duration `yaml:"cache_ttl"`            // How long a user lookup is trusted
+		RevocationRetention time.Duration `yaml:"revocation_retention"` // At least the lifetime of an access token
+	} `yaml:"auth"`
+	Kafka *kafka.KafkaConfig `yaml:"kafka"` // Optional, delivers user revocations of the auth service
 }
 
 // LoadConfig loads the gRPC service configuration from a YAML file.
@@ -58,5 +65,15 @@ func LoadConfig(ctx context.Context, log *jsonlog.Logger) (*Config, error) {
 		return nil, fmt.Errorf("key service configuration is missing")
 	}
 
+	if cfg.Auth.CacheSize <= 0 {
+		cfg.Auth.CacheSize = 10000
+	}
+	if cfg.Auth.CacheTTL <= 0 {
+		cfg.Auth.CacheTTL = time.Minute
+	}
+	if cfg.Auth.RevocationRetention <= 0 {
+		cfg.Auth.RevocationRetention = time.Hour
+	}
+
 	return &cfg, nil
 }
@@ -3,11 +3,16 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 
+	"github.com/IBM/sarama"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	"github.com/NesterovYehor/TextNest/services/api_service/config"
 	grpc_clients "github.com/NesterovYehor/TextNest/services/api_service/internal/grpc_client"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/jwks"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/verifier"
 )
 
 type AppContext struct {
@@ -15,6 +20,8 @@ type AppContext struct {
 	UploadClient   *grpc_clients.UploadClient
 	AuthClient     *grpc_clients.AuthClient
 	DownloadClient *grpc_clients.DownloadClient
+	KeySet         *jwks.KeySet
+	Verifier       *verifier.Verifier
 	closers        []func() error
 	Logger         *jsonlog.Logger
 }
@@ -60,12 +67,15 @@ func NewAppContext(cfg *config.Config, ctx context.Context, logger *jsonlog.Logg
 			return
 		}
 		instance.closers = append(instance.closers, authClient.Close)
+		keySet := jwks.NewKeySet(authClient)
 		// Set the singleton instance
 		instance = &AppContext{
 			KeyGenClient:   keyGenClient,
 			UploadClient:   uploadPasteClient,
 			DownloadClient: downloadPasteClient,
 			AuthClient:     authClient,
+			KeySet:         keySet,
+			Verifier:       verifier.NewVerifier(keySet, authClient, cfg.Auth.CacheSize, cfg.Auth.CacheTTL, cfg.Auth.RevocationRetention),
 			Logger:         logger,
 		}
 	})
@@ -77,6 +87,39 @@ func GetAppContext() *AppContext {
 	return instance
 }
 
+// RunRevocationConsumer applies the user revocations published by the auth service until ctx is done.
+// Access tokens are verified by the gateway itself only while it runs.
+// Every gateway instance has to see every revocation, so each one consumes in its own group.
+func (app *AppContext) RunRevocationConsumer(cfg *config.Config, ctx context.Context) error {
+	kafkaCfg := *cfg.Kafka
+	kafkaCfg.Topics = []string{verifier.UserRevokedTopic}
+	if hostname, err := os.Hostname(); err == nil {
+		kafkaCfg.GroupID = fmt.Sprintf("%s-%s", kafkaCfg.GroupID, hostname)
+	}
+
+	handlers := map[string]kafka.MessageHandler{
+		verifier.UserRevokedTopic: func(msg *sarama.ConsumerMessage) error {
+			return app.Verifier.HandleRevocation(msg)
+		},
+	}
+
+	consumer, err := kafka.NewKafkaConsumer(&kafkaCfg, handlers, ctx)
+	if err != nil {
+		app.Logger.PrintError(ctx, fmt.Errorf("Failed to create a new Kafka consumer: %w", err), nil)
+		return err
+	}
+
+	// Until the consumer is running tokens are checked by the auth service
+	app.Verifier.SetConsumingRevocations(true)
+	defer app.Verifier.SetConsumingRevocations(false)
+	if err := consumer.Start(); err != nil {
+		app.Logger.PrintError(ctx, fmt.Errorf("Kafka consumer stopped with error: %w", err), nil)
+		consumer.Close()
+		return err
+	}
+	return nil
+}
+
 func (app *AppContext) Close() error {
 	var errs []error
 	for _, close := range app.closers {
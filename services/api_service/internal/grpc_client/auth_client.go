@@ -7,6 +7,7 @@ import (
 	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type AuthClient struct {
@@ -42,10 +43,12 @@ func (c *AuthClient) SignUp(name, email, password string) (*auth.CreateUserRespo
 	return c.client.CreateUser(ctx, req)
 }
 
-func (c *AuthClient) LogIn(email, password string) (*auth.AuthenticateUserResponse, error) {
+func (c *AuthClient) LogIn(email, password, ip, userAgent string) (*auth.AuthenticateUserResponse, error) {
 	req := auth.AuthenticateUserRequest{
-		Email:    email,
-		Password: password,
+		Email:     email,
+		Password:  password,
+		Ip:        ip,
+		UserAgent: userAgent,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -58,7 +61,7 @@ func (c *AuthClient) LogIn(email, password string) (*auth.AuthenticateUserRespon
 	return res, nil
 }
 
-func (c *AuthClient) AuthorizeUser(token string) (string, error) {
+func (c *AuthClient) AuthorizeUser(token string) (string, string, error) {
 	req := auth.AuthorizeUserRequest{
 		Tocken: token,
 	}
@@ -68,15 +71,17 @@ func (c *AuthClient) AuthorizeUser(token string) (string, error) {
 
 	res, err := c.client.AuthorizeUser(ctx, &req)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return res.UserId, nil
+	return res.UserId, res.Role, nil
 }
 
-func (c *AuthClient) RefreshTokens(token string) (*auth.RefreshTokensResponse, error) {
+func (c *AuthClient) RefreshTokens(token, ip, userAgent string) (*auth.RefreshTokensResponse, error) {
 	req := auth.RefreshTokensRequest{
-		Tocken: token,
+		Tocken:    token,
+		Ip:        ip,
+		UserAgent: userAgent,
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -120,3 +125,245 @@ func (c *AuthClient) ResetPassword(password, token string) (string, error) {
 	}
 	return res.Message, nil
 }
+
+// Logout revokes the session the refresh token belongs to.
+func (c *AuthClient) Logout(refreshToken string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	res, err := c.client.Logout(ctx, &auth.LogoutRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// LogoutAll revokes every session of the user.
+func (c *AuthClient) LogoutAll(userId string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	res, err := c.client.LogoutAll(ctx, &auth.LogoutAllRequest{UserId: userId})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// GetJwks fetches the public keys access tokens are signed with.
+func (c *AuthClient) GetJwks() (*auth.GetJwksResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.GetJwks(ctx, &auth.GetJwksRequest{})
+}
+
+// AuthorizeAPIKey returns the owner and scopes of a personal access token.
+func (c *AuthClient) AuthorizeAPIKey(token string) (*auth.AuthorizeAPIKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.AuthorizeAPIKey(ctx, &auth.AuthorizeAPIKeyRequest{Token: token})
+}
+
+// CreateAPIKey issues a personal access token, expiresAt may be nil for the default lifetime.
+func (c *AuthClient) CreateAPIKey(userId, name string, scopes []string, expiresAt *timestamppb.Timestamp) (*auth.CreateAPIKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.CreateAPIKey(ctx, &auth.CreateAPIKeyRequest{
+		UserId:    userId,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (c *AuthClient) ListAPIKeys(userId string) ([]*auth.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ListAPIKeys(ctx, &auth.ListAPIKeysRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	return res.Keys, nil
+}
+
+func (c *AuthClient) RevokeAPIKey(userId, id string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.RevokeAPIKey(ctx, &auth.RevokeAPIKeyRequest{UserId: userId, Id: id})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// VerifyMFA exchanges the challenge of a login and a TOTP or recovery code for the session tokens.
+func (c *AuthClient) VerifyMFA(mfaToken, code, ip, userAgent string) (*auth.VerifyMFAResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.VerifyMFA(ctx, &auth.VerifyMFARequest{MfaToken: mfaToken, Code: code, Ip: ip, UserAgent: userAgent})
+}
+
+func (c *AuthClient) EnrollTOTP(userId string) (*auth.EnrollTOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.EnrollTOTP(ctx, &auth.EnrollTOTPRequest{UserId: userId})
+}
+
+func (c *AuthClient) ConfirmTOTP(userId, code string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ConfirmTOTP(ctx, &auth.ConfirmTOTPRequest{UserId: userId, Code: code})
+	if err != nil {
+		return nil, err
+	}
+	return res.RecoveryCodes, nil
+}
+
+func (c *AuthClient) DisableTOTP(userId, code string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.DisableTOTP(ctx, &auth.DisableTOTPRequest{UserId: userId, Code: code})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// StartOIDCLogin returns the URL of the identity provider to redirect the user to.
+func (c *AuthClient) StartOIDCLogin(provider string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.StartOIDCLogin(ctx, &auth.StartOIDCLoginRequest{Provider: provider})
+	if err != nil {
+		return "", err
+	}
+	return res.AuthorizationUrl, nil
+}
+
+// CompleteOIDCLogin exchanges the code of an identity provider callback for the session tokens,
+// or for a challenge when the user has two-factor authentication.
+func (c *AuthClient) CompleteOIDCLogin(provider, state, code, ip, userAgent string) (*auth.AuthenticateUserResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.CompleteOIDCLogin(ctx, &auth.CompleteOIDCLoginRequest{Provider: provider, State: state, Code: code, Ip: ip, UserAgent: userAgent})
+}
+
+func (c *AuthClient) ListUsers(actorId string, limit, offset int32) ([]*auth.AdminUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ListUsers(ctx, &auth.ListUsersRequest{ActorId: actorId, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return res.Users, nil
+}
+
+func (c *AuthClient) SetUserStatus(actorId, userId, status, reason string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.SetUserStatus(ctx, &auth.SetUserStatusRequest{ActorId: actorId, UserId: userId, Status: status, Reason: reason})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+func (c *AuthClient) SetUserRole(actorId, userId, role string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.SetUserRole(ctx, &auth.SetUserRoleRequest{ActorId: actorId, UserId: userId, Role: role})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// RecordAdminAction writes an action a moderator took on a paste to the audit log of the auth service.
+func (c *AuthClient) RecordAdminAction(actorId, action, target, details string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	_, err := c.client.RecordAdminAction(ctx, &auth.RecordAdminActionRequest{ActorId: actorId, Action: action, Target: target, Details: details})
+	return err
+}
+
+func (c *AuthClient) ListAuditLog(actorId string, limit, offset int32) ([]*auth.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ListAuditLog(ctx, &auth.ListAuditLogRequest{ActorId: actorId, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return res.Entries, nil
+}
+
+func (c *AuthClient) GetProfile(userId string) (*auth.Profile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.GetProfile(ctx, &auth.GetProfileRequest{UserId: userId})
+}
+
+func (c *AuthClient) UpdateProfile(userId, name string) (*auth.Profile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return c.client.UpdateProfile(ctx, &auth.UpdateProfileRequest{UserId: userId, Name: name})
+}
+
+func (c *AuthClient) ChangePassword(userId, currentPassword, newPassword string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ChangePassword(ctx, &auth.ChangePasswordRequest{UserId: userId, CurrentPassword: currentPassword, NewPassword: newPassword})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+// ChangeEmail mails a confirmation link to the new address, the email changes once it is followed.
+func (c *AuthClient) ChangeEmail(userId, password, newEmail string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ChangeEmail(ctx, &auth.ChangeEmailRequest{UserId: userId, Password: password, NewEmail: newEmail})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+func (c *AuthClient) ConfirmEmailChange(token string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ConfirmEmailChange(ctx, &auth.ConfirmEmailChangeRequest{Token: token})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+func (c *AuthClient) DeleteAccount(userId, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.DeleteAccount(ctx, &auth.DeleteAccountRequest{UserId: userId, Password: password})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
+
+func (c *AuthClient) ListSessions(userId string) ([]*auth.Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.ListSessions(ctx, &auth.ListSessionsRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	return res.Sessions, nil
+}
+
+// RevokeSession signs out one device of the user.
+func (c *AuthClient) RevokeSession(userId, sessionId string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	res, err := c.client.RevokeSession(ctx, &auth.RevokeSessionRequest{UserId: userId, SessionId: sessionId})
+	if err != nil {
+		return "", err
+	}
+	return res.Message, nil
+}
@@ -41,9 +41,11 @@ func (c *DownloadClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *DownloadClient) DownloadByKey(key string) (*paste_download.DownloadByKeyResponse, error) {
+func (c *DownloadClient) DownloadByKey(key, userId, password string) (*paste_download.DownloadByKeyResponse, error) {
 	req := downloadByKeyReqPool.Get().(*paste_download.DownloadByKeyRequest)
 	req.Key = key
+	req.UserId = userId
+	req.Password = password
 	defer downloadByKeyReqPool.Put(req)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
@@ -55,11 +57,17 @@ func (c *DownloadClient) DownloadByKey(key string) (*paste_download.DownloadByKe
 	return resp, nil
 }
 
-func (c *DownloadClient) DownloadByUserId(userId string, limit, offset int32) (*paste_download.DownloadByUserIdResponse, error) {
+// DownloadByUserId lists the pastes of a user. An empty tag and a collection ID of 0 do not filter.
+// The cursor continues a previous listing, offset is only used without one.
+func (c *DownloadClient) DownloadByUserId(userId, tag string, collectionId int64, sort paste_download.PasteSort, cursor string, limit, offset int32) (*paste_download.DownloadByUserIdResponse, error) {
 	req := downloadByUserIdReqPool.Get().(*paste_download.DownloadByUserIdRequest)
 	req.UserId = userId
 	req.Limit = limit
 	req.Offset = offset
+	req.Tag = tag
+	req.CollectionId = collectionId
+	req.Sort = sort
+	req.Cursor = cursor
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -71,3 +79,58 @@ func (c *DownloadClient) DownloadByUserId(userId string, limit, offset int32) (*
 
 	return resp, nil
 }
+
+func (c *DownloadClient) ListVersions(ctx context.Context, key, userId, password string) (*paste_download.ListVersionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ListVersions(ctx, &paste_download.ListVersionsRequest{Key: key, UserId: userId, Password: password})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *DownloadClient) GetVersion(ctx context.Context, key string, version int32, userId, password string) (*paste_download.GetVersionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetVersion(ctx, &paste_download.GetVersionRequest{Key: key, Version: version, UserId: userId, Password: password})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *DownloadClient) SearchPastes(ctx context.Context, userId, query string, limit, offset int32) (*paste_download.SearchPastesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.SearchPastes(ctx, &paste_download.SearchPastesRequest{UserId: userId, Query: query, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *DownloadClient) RenderPaste(ctx context.Context, key, file, userId, password string, format paste_download.RenderFormat) (*paste_download.RenderPasteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.RenderPaste(ctx, &paste_download.RenderPasteRequest{Key: key, File: file, UserId: userId, Password: password, Format: format})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamContent opens the content stream of a paste. It runs as long as ctx, large pastes
+// may take longer to transfer than any fixed timeout.
+func (c *DownloadClient) StreamContent(ctx context.Context, req *paste_download.StreamContentRequest) (paste_download.PasteDownload_StreamContentClient, error) {
+	return c.client.StreamContent(ctx, req)
+}
+
+// DownloadBundle opens the archive stream of a bundle. Like StreamContent it runs as long as ctx.
+func (c *DownloadClient) DownloadBundle(ctx context.Context, req *paste_download.DownloadBundleRequest) (paste_download.PasteDownload_DownloadBundleClient, error) {
+	return c.client.DownloadBundle(ctx, req)
+}
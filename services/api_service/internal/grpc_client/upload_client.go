@@ -2,13 +2,17 @@ package grpc_clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	paste_upload "github.com/NesterovYehor/TextNest/services/api_service/api/upload_service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+const uploadChunkSize = 64 * 1024
+
 type UploadClient struct {
 	client paste_upload.PasteUploadClient
 	conn   *grpc.ClientConn
@@ -36,6 +40,157 @@ func (c *UploadClient) UploadPaste(ctx context.Context, req *paste_upload.Upload
 	return resp.UploadUrl, nil
 }
 
+// CompleteUpload activates a paste once its content was uploaded to the URL of UploadPaste.
+// The tier selects the size limit the content is checked against.
+func (c *UploadClient) CompleteUpload(ctx context.Context, key, userID, checksum, tier string, version int32) (*paste_upload.CompleteUploadResponse, error) {
+	return c.client.CompleteUpload(ctx, &paste_upload.CompleteUploadRequest{Key: key, UserId: userID, Checksum: checksum, Tier: tier, Version: version})
+}
+
+// UpdatePasteMetadata edits the title, expiration and tags of a paste.
+func (c *UploadClient) UpdatePasteMetadata(ctx context.Context, req *paste_upload.UpdatePasteMetadataRequest) (*paste_upload.UpdatePasteMetadataResponse, error) {
+	return c.client.UpdatePasteMetadata(ctx, req)
+}
+
+func (c *UploadClient) CreateCollection(ctx context.Context, userID, name string) (*paste_upload.Collection, error) {
+	res, err := c.client.CreateCollection(ctx, &paste_upload.CreateCollectionRequest{UserId: userID, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return res.Collection, nil
+}
+
+func (c *UploadClient) RenameCollection(ctx context.Context, id int64, userID, name string) (*paste_upload.Collection, error) {
+	res, err := c.client.RenameCollection(ctx, &paste_upload.RenameCollectionRequest{Id: id, UserId: userID, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return res.Collection, nil
+}
+
+func (c *UploadClient) DeleteCollection(ctx context.Context, id int64, userID string) error {
+	_, err := c.client.DeleteCollection(ctx, &paste_upload.DeleteCollectionRequest{Id: id, UserId: userID})
+	return err
+}
+
+func (c *UploadClient) ListCollections(ctx context.Context, userID string) ([]*paste_upload.Collection, error) {
+	res, err := c.client.ListCollections(ctx, &paste_upload.ListCollectionsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Collections, nil
+}
+
+// MovePaste puts a paste into a collection, a collection ID of 0 takes it out of its collection.
+func (c *UploadClient) MovePaste(ctx context.Context, key, userID string, collectionID int64) error {
+	_, err := c.client.MovePaste(ctx, &paste_upload.MovePasteRequest{Key: key, UserId: userID, CollectionId: collectionID})
+	return err
+}
+
+func (c *UploadClient) ListTags(ctx context.Context, userID string) ([]*paste_upload.TagCount, error) {
+	res, err := c.client.ListTags(ctx, &paste_upload.ListTagsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Tags, nil
+}
+
+// UploadContent streams the paste content to the upload service in chunks of uploadChunkSize bytes.
+// The metadata goes first, followed by the content read from body.
+func (c *UploadClient) UploadContent(ctx context.Context, metadata *paste_upload.UploadPasteRequest, body io.Reader) (*paste_upload.UploadContentResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.UploadContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&paste_upload.UploadContentRequest{
+		Data: &paste_upload.UploadContentRequest_Metadata{Metadata: metadata},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send paste metadata: %w", err)
+	}
+	// Cancelling the context on a read error aborts the stream, so no partial paste is stored
+	if _, err := sendChunks(stream, body); err != nil {
+		return nil, err
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// UploadBundle streams a multi-file paste to the upload service. Every file returned by next is
+// sent as its header followed by its content, next reports the end of the bundle with io.EOF.
+func (c *UploadClient) UploadBundle(
+	ctx context.Context,
+	metadata *paste_upload.UploadPasteRequest,
+	next func() (*paste_upload.PasteFile, io.Reader, error),
+) (*paste_upload.UploadContentResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.UploadContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&paste_upload.UploadContentRequest{
+		Data: &paste_upload.UploadContentRequest_Metadata{Metadata: metadata},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send paste metadata: %w", err)
+	}
+	for {
+		file, body, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Send(&paste_upload.UploadContentRequest{
+			Data: &paste_upload.UploadContentRequest_File{File: file},
+		}); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to send file header: %w", err)
+		}
+		closed, err := sendChunks(stream, body)
+		if err != nil {
+			return nil, err
+		}
+		if closed {
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// sendChunks sends the content read from body in chunks of uploadChunkSize bytes. It reports
+// true when the server closed the stream early, its status is then returned by CloseAndRecv.
+func sendChunks(stream paste_upload.PasteUpload_UploadContentClient, body io.Reader) (bool, error) {
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&paste_upload.UploadContentRequest{
+				Data: &paste_upload.UploadContentRequest_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				if errors.Is(err, io.EOF) {
+					return true, nil
+				}
+				return false, fmt.Errorf("failed to send paste content: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			return false, nil
+		}
+		if readErr != nil {
+			return false, readErr
+		}
+	}
+}
+
 func (c *UploadClient) ExpirePaste(ctx context.Context, key, userID string) (string, error) {
 	resp, err := c.client.ExpirePaste(ctx, &paste_upload.ExpirePasteRequest{Key: key, UserId: userID})
 	if err != nil {
@@ -44,6 +199,31 @@ func (c *UploadClient) ExpirePaste(ctx context.Context, key, userID string) (str
 	return resp.Message, nil
 }
 
+// ForceExpirePaste expires a paste of any owner on behalf of a moderator.
+func (c *UploadClient) ForceExpirePaste(ctx context.Context, key, moderatorId string) (string, error) {
+	resp, err := c.client.ExpirePaste(ctx, &paste_upload.ExpirePasteRequest{Key: key, UserId: moderatorId, Force: true})
+	if err != nil {
+		return "", err
+	}
+	return resp.Message, nil
+}
+
+func (c *UploadClient) ReportPaste(ctx context.Context, key, reporterId, reason string) (int64, error) {
+	resp, err := c.client.ReportPaste(ctx, &paste_upload.ReportPasteRequest{Key: key, ReporterId: reporterId, Reason: reason})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Id, nil
+}
+
+func (c *UploadClient) ListAbuseReports(ctx context.Context, includeResolved bool, limit, offset int32) ([]*paste_upload.AbuseReport, error) {
+	resp, err := c.client.ListAbuseReports(ctx, &paste_upload.ListAbuseReportsRequest{IncludeResolved: includeResolved, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Reports, nil
+}
+
 // Upload method calls the gRPC Upload RPC
 func (c *UploadClient) ExpireAllUserPastes(ctx context.Context, userId string) (string, error) {
 	resp, err := c.client.ExpireAllPastesByUserID(ctx, &paste_upload.ExpireAllPastesByUserIDRequest{
@@ -59,7 +239,7 @@ func (c *UploadClient) UpdatePaste(
 	ctx context.Context,
 	key string,
 	userId string,
-) (string, error) {
+) (*paste_upload.UploadUpdatesResponse, error) {
 	req := &paste_upload.UploadUpdatesRequest{
 		Key:    key,
 		UserId: userId,
@@ -68,7 +248,7 @@ func (c *UploadClient) UpdatePaste(
 	// Send gRPC request
 	resp, err := c.client.UploadUpdates(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("gRPC UploadUpdates failed: %w", err)
+		return nil, fmt.Errorf("gRPC UploadUpdates failed: %w", err)
 	}
-	return resp.UploadUrl, nil
+	return resp, nil
 }
@@ -0,0 +1,360 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetProfileHandler godoc
+// @Summary Show the account
+// @Description Returns the profile of the authenticated user.
+// @Tags account
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Profile"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me [get]
+func GetProfileHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		profile, err := app.AuthClient.GetProfile(userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"profile": profileEnvelope(profile)}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// UpdateProfileHandler godoc
+// @Summary Update the account
+// @Description Changes the display name of the authenticated user.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name body string true "Display name, at most 100 characters"
+// @Success 200 {object} map[string]interface{} "Updated profile"
+// @Failure 400 {object} map[string]string "Invalid name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me [patch]
+func UpdateProfileHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Name string `json:"name"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		profile, err := app.AuthClient.UpdateProfile(userId, input.Name)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"profile": profileEnvelope(profile)}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ChangePasswordHandler godoc
+// @Summary Change the password
+// @Description Sets a new password after checking the current one. Every session is signed out, including the current one.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param current_password body string true "Current password"
+// @Param new_password body string true "New password, 8 to 72 characters"
+// @Success 200 {object} map[string]string "Password changed"
+// @Failure 400 {object} map[string]string "Invalid new password"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Current password is incorrect"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/password [put]
+func ChangePasswordHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			CurrentPassword string `json:"current_password"`
+			NewPassword     string `json:"new_password"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		message, err := app.AuthClient.ChangePassword(userId, input.CurrentPassword, input.NewPassword)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ChangeEmailHandler godoc
+// @Summary Change the email
+// @Description Mails a confirmation link to the new address. The account keeps its current email until the link is followed.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param email body string true "New email"
+// @Param password body string true "Current password"
+// @Success 202 {object} map[string]string "Confirmation email sent"
+// @Failure 400 {object} map[string]string "Invalid email"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Password is incorrect"
+// @Failure 409 {object} map[string]string "Email is already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/email [post]
+func ChangeEmailHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		message, err := app.AuthClient.ChangeEmail(userId, input.Password, input.Email)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusAccepted, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ConfirmEmailChangeHandler godoc
+// @Summary Confirm a new email
+// @Description Swaps the email of the account for the address the token was mailed to. The previous address is told about the change.
+// @Tags account
+// @Produce json
+// @Param token path string true "Token from the confirmation email"
+// @Success 200 {object} map[string]string "Email changed"
+// @Failure 400 {object} map[string]string "Invalid or expired token"
+// @Failure 409 {object} map[string]string "Email is already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/email/confirm/{token} [get]
+func ConfirmEmailChangeHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		message, err := app.AuthClient.ConfirmEmailChange(r.PathValue("token"))
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// DeleteAccountHandler godoc
+// @Summary Delete the account
+// @Description Deletes the account after checking the password. All pastes of the user are expired and every session is signed out.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param password body string true "Current password"
+// @Success 200 {object} map[string]string "Account deleted"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Password is incorrect"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me [delete]
+func DeleteAccountHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Password string `json:"password"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		message, err := app.AuthClient.DeleteAccount(userId, input.Password)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		// The account is gone either way, the cleanup service also purges the pastes when it
+		// receives the deletion event
+		if _, err := app.UploadClient.ExpireAllUserPastes(ctx, userId); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("failed to expire pastes of deleted user %s: %w", userId, err), nil)
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ListSessionsHandler godoc
+// @Summary List the sessions
+// @Description Returns the devices the authenticated user is logged in on, the most recently used first.
+// @Tags account
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Sessions"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/sessions [get]
+func ListSessionsHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		sessions, err := app.AuthClient.ListSessions(userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		res := make([]helpers.Envelope, 0, len(sessions))
+		for _, session := range sessions {
+			res = append(res, helpers.Envelope{
+				"id":           session.Id,
+				"user_agent":   session.UserAgent,
+				"ip":           session.Ip,
+				"created_at":   session.CreatedAt.AsTime(),
+				"last_seen_at": session.LastSeenAt.AsTime(),
+			})
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"sessions": res}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// RevokeSessionHandler godoc
+// @Summary Revoke a session
+// @Description Signs out one device of the authenticated user. Its refresh token stops working and its access tokens are rejected.
+// @Tags account
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string "Session revoked"
+// @Failure 400 {object} map[string]string "Invalid session ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Session not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/sessions/{id} [delete]
+func RevokeSessionHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		message, err := app.AuthClient.RevokeSession(userId, r.PathValue("id"))
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			accountErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+func profileEnvelope(profile *auth.Profile) helpers.Envelope {
+	return helpers.Envelope{
+		"id":          profile.Id,
+		"name":        profile.Name,
+		"email":       profile.Email,
+		"role":        profile.Role,
+		"mfa_enabled": profile.MfaEnabled,
+		"created_at":  profile.CreatedAt.AsTime(),
+	}
+}
+
+// accountErrorResponse translates the auth service errors of account self-service into HTTP statuses.
+func accountErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.AlreadyExists:
+		code = http.StatusConflict
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
@@ -0,0 +1,377 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	paste_upload "github.com/NesterovYehor/TextNest/services/api_service/api/upload_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// auditExpirePaste is the audit log action of a moderator expiring a paste.
+const auditExpirePaste = "paste.expire"
+
+// ListUsersHandler godoc
+// @Summary List users
+// @Description Lists all accounts, newest first. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of users, at most 200"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} map[string]interface{} "Users"
+// @Failure 400 {object} map[string]string "Invalid limit or offset"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the admin role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/users [get]
+func ListUsersHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		actorId, _ := ctx.Value("user_id").(string)
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		users, err := app.AuthClient.ListUsers(actorId, limit, offset)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		envelopes := make([]helpers.Envelope, 0, len(users))
+		for _, user := range users {
+			envelopes = append(envelopes, adminUserEnvelope(user))
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"users": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// SetUserStatusHandler godoc
+// @Summary Activate, deactivate or ban a user
+// @Description Deactivated and banned users cannot log in and are signed out of every session. Admins cannot change their own account. Requires the admin role.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param status body string true "active, deactivated or banned"
+// @Param reason body string false "Reason recorded in the audit log"
+// @Success 200 {object} map[string]interface{} "Status changed"
+// @Failure 400 {object} map[string]string "Invalid status"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the admin role"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Admins cannot change their own account"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/users/{id}/status [put]
+func SetUserStatusHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		actorId, _ := ctx.Value("user_id").(string)
+		var input struct {
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := app.AuthClient.SetUserStatus(actorId, r.PathValue("id"), input.Status, input.Reason)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": res}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// SetUserRoleHandler godoc
+// @Summary Change the role of a user
+// @Description The user is signed out of access tokens carrying the old role. Admins cannot change their own role. Requires the admin role.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param role body string true "user, moderator or admin"
+// @Success 200 {object} map[string]interface{} "Role changed"
+// @Failure 400 {object} map[string]string "Invalid role"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the admin role"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Admins cannot change their own account"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/users/{id}/role [put]
+func SetUserRoleHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		actorId, _ := ctx.Value("user_id").(string)
+		var input struct {
+			Role string `json:"role"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := app.AuthClient.SetUserRole(actorId, r.PathValue("id"), input.Role)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": res}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ForceExpirePasteHandler godoc
+// @Summary Expire any paste
+// @Description Expires a paste regardless of its owner and resolves its abuse reports. The action is written to the audit log before the paste is expired. Requires the moderator role.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Paste Key"
+// @Param reason query string false "Reason recorded in the audit log"
+// @Success 200 {object} map[string]interface{} "Paste expired"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the moderator role"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/pastes/{key} [delete]
+func ForceExpirePasteHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		actorId, _ := ctx.Value("user_id").(string)
+		key := r.PathValue("key")
+
+		// The auth service keeps the audit log of all services and checks the role of the actor
+		// against the database, so a demoted moderator is turned away before the paste is touched
+		if err := app.AuthClient.RecordAdminAction(actorId, auditExpirePaste, key, r.URL.Query().Get("reason")); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("failed to record expiring paste %s: %w", key, err), nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		res, err := app.UploadClient.ForceExpirePaste(ctx, key, actorId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": res}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ListAbuseReportsHandler godoc
+// @Summary List abuse reports
+// @Description Lists reports of pastes, oldest first. Resolved reports are only included when asked for. Requires the moderator role.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param resolved query bool false "Include resolved reports"
+// @Param limit query int false "Maximum number of reports, at most 100"
+// @Param offset query int false "Number of reports to skip"
+// @Success 200 {object} map[string]interface{} "Abuse reports"
+// @Failure 400 {object} map[string]string "Invalid limit or offset"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the moderator role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/reports [get]
+func ListAbuseReportsHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		includeResolved := r.URL.Query().Get("resolved") == "true"
+
+		reports, err := app.UploadClient.ListAbuseReports(ctx, includeResolved, limit, offset)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		envelopes := make([]helpers.Envelope, 0, len(reports))
+		for _, report := range reports {
+			envelopes = append(envelopes, abuseReportEnvelope(report))
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"reports": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ListAuditLogHandler godoc
+// @Summary List the audit log
+// @Description Lists the actions of moderators and admins, newest first. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of entries, at most 200"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} map[string]interface{} "Audit log entries"
+// @Failure 400 {object} map[string]string "Invalid limit or offset"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires the admin role"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/audit-log [get]
+func ListAuditLogHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		actorId, _ := ctx.Value("user_id").(string)
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		entries, err := app.AuthClient.ListAuditLog(actorId, limit, offset)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		envelopes := make([]helpers.Envelope, 0, len(entries))
+		for _, entry := range entries {
+			envelopes = append(envelopes, helpers.Envelope{
+				"id":         entry.Id,
+				"actor_id":   entry.ActorId,
+				"action":     entry.Action,
+				"target":     entry.Target,
+				"details":    entry.Details,
+				"created_at": entry.CreatedAt.AsTime(),
+			})
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"entries": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ReportPasteHandler godoc
+// @Summary Report a paste
+// @Description Reports a paste that breaks the rules to the moderators. Reports can be sent without logging in.
+// @Tags paste
+// @Accept json
+// @Produce json
+// @Param key path string true "Paste Key"
+// @Param reason body string true "What is wrong with the paste, at most 1000 characters"
+// @Success 201 {object} map[string]interface{} "Report received"
+// @Failure 400 {object} map[string]string "Missing or too long reason"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/report [post]
+func ReportPasteHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		reporterId, _ := ctx.Value("user_id").(string)
+		var input struct {
+			Reason string `json:"reason"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		id, err := app.UploadClient.ReportPaste(ctx, r.PathValue("key"), reporterId, input.Reason)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			adminErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"id": id, "message": "report received"}, http.StatusCreated, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+func parsePage(r *http.Request) (int32, int32, error) {
+	limit, err := parseCountParam(r, "limit")
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := parseCountParam(r, "offset")
+	if err != nil {
+		return 0, 0, err
+	}
+	return limit, offset, nil
+}
+
+func adminUserEnvelope(user *auth.AdminUser) helpers.Envelope {
+	envelope := helpers.Envelope{
+		"id":          user.Id,
+		"name":        user.Name,
+		"email":       user.Email,
+		"role":        user.Role,
+		"activated":   user.Activated,
+		"mfa_enabled": user.MfaEnabled,
+		"created_at":  user.CreatedAt.AsTime(),
+		"banned_at":   nil,
+	}
+	if user.BannedAt != nil {
+		envelope["banned_at"] = user.BannedAt.AsTime()
+	}
+	return envelope
+}
+
+func abuseReportEnvelope(report *paste_upload.AbuseReport) helpers.Envelope {
+	envelope := helpers.Envelope{
+		"id":          report.Id,
+		"key":         report.Key,
+		"reporter_id": report.ReporterId,
+		"reason":      report.Reason,
+		"created_at":  report.CreatedAt.AsTime(),
+		"resolved_at": nil,
+		"resolved_by": report.ResolvedBy,
+	}
+	if report.ResolvedAt != nil {
+		envelope["resolved_at"] = report.ResolvedAt.AsTime()
+	}
+	return envelope
+}
+
+// adminErrorResponse translates the errors of moderation and abuse reports into HTTP statuses.
+func adminErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
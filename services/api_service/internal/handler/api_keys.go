@@ -0,0 +1,171 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateAPIKeyHandler godoc
+// @Summary Create a personal access token
+// @Description Issues a long-lived token for CLI and CI use, sent as "Authorization: Token <token>". The token is only shown in this response. Without expires_at the key expires after 90 days.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name body string true "Key name"
+// @Param scopes body []string true "Scopes: paste:read, paste:write, paste:delete"
+// @Param expires_at body string false "Expiry, RFC 3339, at most a year away"
+// @Success 201 {object} map[string]interface{} "Token and key"
+// @Failure 400 {object} map[string]string "Invalid name, scopes or expiry"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/api-keys [post]
+func CreateAPIKeyHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Name      string     `json:"name"`
+			Scopes    []string   `json:"scopes"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		var expiresAt *timestamppb.Timestamp
+		if input.ExpiresAt != nil {
+			expiresAt = timestamppb.New(*input.ExpiresAt)
+		}
+
+		res, err := app.AuthClient.CreateAPIKey(userId, input.Name, input.Scopes, expiresAt)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			apiKeyErrorResponse(w, err)
+			return
+		}
+
+		response := helpers.Envelope{"token": res.Token, "key": apiKeyEnvelope(res.Key)}
+		if err := helpers.WriteJSON(w, response, http.StatusCreated, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ListAPIKeysHandler godoc
+// @Summary List personal access tokens
+// @Description Lists the API keys of the authenticated user, newest first. Tokens themselves are never returned.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "API keys"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/api-keys [get]
+func ListAPIKeysHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		keys, err := app.AuthClient.ListAPIKeys(userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			apiKeyErrorResponse(w, err)
+			return
+		}
+
+		envelopes := make([]helpers.Envelope, 0, len(keys))
+		for _, key := range keys {
+			envelopes = append(envelopes, apiKeyEnvelope(key))
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"api_keys": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary Revoke a personal access token
+// @Description Deletes an API key of the authenticated user, the token stops working immediately.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 200 {object} map[string]string "API key revoked"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "API key not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/api-keys/{id} [delete]
+func RevokeAPIKeyHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		message, err := app.AuthClient.RevokeAPIKey(userId, r.PathValue("id"))
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			apiKeyErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+func apiKeyEnvelope(key *auth.APIKey) helpers.Envelope {
+	envelope := helpers.Envelope{
+		"id":           key.Id,
+		"name":         key.Name,
+		"scopes":       key.Scopes,
+		"expires_at":   key.ExpiresAt.AsTime(),
+		"created_at":   key.CreatedAt.AsTime(),
+		"last_used_at": nil,
+	}
+	if key.LastUsedAt != nil {
+		envelope["last_used_at"] = key.LastUsedAt.AsTime()
+	}
+	return envelope
+}
+
+// apiKeyErrorResponse translates the auth service errors of API key management into HTTP statuses.
+func apiKeyErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
@@ -0,0 +1,348 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/upload_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListCollectionsHandler godoc
+// @Summary List collections
+// @Description Lists the collections of the authenticated user by name, with the number of active pastes in each.
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Collections"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections [get]
+func ListCollectionsHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		collections, err := app.UploadClient.ListCollections(ctx, userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		envelopes := make([]helpers.Envelope, 0, len(collections))
+		for _, collection := range collections {
+			envelopes = append(envelopes, collectionEnvelope(collection))
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"collections": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// CreateCollectionHandler godoc
+// @Summary Create a collection
+// @Description Creates a named collection (folder) for the pastes of the authenticated user. Names are unique per user.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name body string true "Collection name"
+// @Success 201 {object} map[string]interface{} "Collection"
+// @Failure 400 {object} map[string]string "Invalid name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "A collection with this name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections [post]
+func CreateCollectionHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Name string `json:"name"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		collection, err := app.UploadClient.CreateCollection(ctx, userId, input.Name)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"collection": collectionEnvelope(collection)}, http.StatusCreated, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// RenameCollectionHandler godoc
+// @Summary Rename a collection
+// @Description Renames a collection of the authenticated user.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Collection ID"
+// @Param name body string true "New collection name"
+// @Success 200 {object} map[string]interface{} "Collection"
+// @Failure 400 {object} map[string]string "Invalid ID or name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 409 {object} map[string]string "A collection with this name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections/{id} [patch]
+func RenameCollectionHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		id, err := parseIDParam(r.PathValue("id"))
+		if err != nil || id == 0 {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("invalid collection ID"))
+			return
+		}
+		var input struct {
+			Name string `json:"name"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		collection, err := app.UploadClient.RenameCollection(ctx, id, userId, input.Name)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"collection": collectionEnvelope(collection)}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// DeleteCollectionHandler godoc
+// @Summary Delete a collection
+// @Description Deletes a collection of the authenticated user. Its pastes are kept and no longer belong to a collection.
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Collection ID"
+// @Success 200 {object} map[string]string "Collection deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections/{id} [delete]
+func DeleteCollectionHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		id, err := parseIDParam(r.PathValue("id"))
+		if err != nil || id == 0 {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("invalid collection ID"))
+			return
+		}
+
+		if err := app.UploadClient.DeleteCollection(ctx, id, userId); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": "collection deleted"}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// MovePasteHandler godoc
+// @Summary Move a paste into a collection
+// @Description Moves a paste of the authenticated user into one of their collections, out of the collection it was in before.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Paste key"
+// @Param collection_id body int true "Collection ID"
+// @Success 200 {object} map[string]string "Paste moved"
+// @Failure 400 {object} map[string]string "Invalid collection ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Paste belongs to another user"
+// @Failure 404 {object} map[string]string "Paste or collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections/pastes/{key} [put]
+func MovePasteHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			CollectionID int64 `json:"collection_id"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		if input.CollectionID <= 0 {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("collection_id must be provided"))
+			return
+		}
+
+		if err := app.UploadClient.MovePaste(ctx, r.PathValue("key"), userId, input.CollectionID); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": "paste moved"}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// RemovePasteFromCollectionHandler godoc
+// @Summary Take a paste out of its collection
+// @Description Removes a paste of the authenticated user from the collection it is in, the paste itself is kept.
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Paste key"
+// @Success 200 {object} map[string]string "Paste removed from its collection"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Paste belongs to another user"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections/pastes/{key} [delete]
+func RemovePasteFromCollectionHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		if err := app.UploadClient.MovePaste(ctx, r.PathValue("key"), userId, 0); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": "paste removed from its collection"}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ListTagsHandler godoc
+// @Summary List tags
+// @Description Lists the tags the authenticated user put on their active pastes, most used first.
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Tags with their paste counts"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /tags [get]
+func ListTagsHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+
+		tags, err := app.UploadClient.ListTags(ctx, userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			collectionErrorResponse(w, err)
+			return
+		}
+
+		envelopes := make([]helpers.Envelope, 0, len(tags))
+		for _, tag := range tags {
+			envelopes = append(envelopes, helpers.Envelope{"tag": tag.Tag, "paste_count": tag.PasteCount})
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"tags": envelopes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+func collectionEnvelope(collection *pb.Collection) helpers.Envelope {
+	return helpers.Envelope{
+		"id":          collection.Id,
+		"name":        collection.Name,
+		"created_at":  collection.CreatedAt.AsTime(),
+		"paste_count": collection.PasteCount,
+	}
+}
+
+// parseIDParam reads an optional positive numeric ID. An empty value is reported as 0.
+func parseIDParam(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid ID %q", raw)
+	}
+	return id, nil
+}
+
+// collectionErrorResponse translates the upload service errors of collection calls into HTTP statuses.
+func collectionErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.AlreadyExists:
+		code = http.StatusConflict
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
@@ -1,14 +1,20 @@
 package handler
 
 import (
+	stdErrors "errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/NesterovYehor/TextNest/pkg/errors"
 	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/download_service"
 	"github.com/NesterovYehor/TextNest/services/api_service/config"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // DownloadPasteRequest represents the request body to download a paste.
@@ -23,7 +29,8 @@ import (
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /paste/download [post]
 type DownloadPasteRequest struct {
-	Key string `json:"key"`
+	Key      string `json:"key"`
+	Password string `json:"password,omitempty"`
 }
 
 // DownloadPaste downloads a paste by its key.
@@ -32,9 +39,12 @@ type DownloadPasteRequest struct {
 // @Tags paste
 // @Accept json
 // @Produce json
-// @Param input body DownloadPasteRequest true "Paste Key"
+// @Param input body DownloadPasteRequest true "Paste Key and optional password"
 // @Success 200 {object} map[string]interface{} "Successful response"
 // @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Paste requires a password"
+// @Failure 403 {object} map[string]string "Invalid paste password"
+// @Failure 404 {object} map[string]string "Paste not found or already burned"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /paste/download [post]
 func DownloadPaste(cfg *config.Config, app *app.AppContext) http.HandlerFunc {
@@ -49,11 +59,14 @@ func DownloadPaste(cfg *config.Config, app *app.AppContext) http.HandlerFunc {
 			return
 		}
 
+		// Anonymous callers have an empty user id and can only read public and unlisted pastes
+		userId, _ := ctx.Value("user_id").(string)
+
 		// Download paste by key
-		downloadResp, err := app.DownloadClient.DownloadByKey(input.Key)
+		downloadResp, err := app.DownloadClient.DownloadByKey(input.Key, userId, input.Password)
 		if err != nil {
 			app.Logger.PrintError(ctx, fmt.Errorf("error downloading paste: %w", err), nil)
-			errors.ServerErrorResponse(w, err)
+			pasteAccessErrorResponse(w, err)
 			return
 		}
 
@@ -63,6 +76,41 @@ func DownloadPaste(cfg *config.Config, app *app.AppContext) http.HandlerFunc {
 			"content_url":     downloadResp.DownlaodUrl,
 			"title":           downloadResp.Metadata.Title,
 			"expiration_date": downloadResp.Metadata.ExpiredDate.AsTime(),
+			"language":        downloadResp.Metadata.Language,
+		}
+		if downloadResp.Metadata.UserId == userId && userId != "" {
+			response["visibility"] = visibilityName(downloadResp.Metadata.Visibility)
+			response["metadata_version"] = downloadResp.Metadata.MetadataVersion
+			response["tags"] = downloadResp.Metadata.Tags
+			if downloadResp.Metadata.CollectionId != 0 {
+				response["collection_id"] = downloadResp.Metadata.CollectionId
+			}
+		}
+		if downloadResp.Encryption != nil {
+			response["encryption"] = helpers.Envelope{
+				"algorithm":      downloadResp.Encryption.Algorithm,
+				"kdf":            downloadResp.Encryption.Kdf,
+				"kdf_salt":       downloadResp.Encryption.KdfSalt,
+				"kdf_iterations": downloadResp.Encryption.KdfIterations,
+				"kdf_memory_kib": downloadResp.Encryption.KdfMemoryKib,
+				"nonce":          downloadResp.Encryption.Nonce,
+				"key_check":      downloadResp.Encryption.KeyCheck,
+			}
+		}
+		// Bundles have no single content URL, every file is read through the files endpoints
+		if len(downloadResp.Files) > 0 {
+			files := make([]helpers.Envelope, 0, len(downloadResp.Files))
+			for _, f := range downloadResp.Files {
+				files = append(files, helpers.Envelope{
+					"name":     f.Name,
+					"language": f.Language,
+					"size":     f.Size,
+				})
+			}
+			response["files"] = files
+		}
+		if downloadResp.Metadata.ViewLimited {
+			response["remaining_views"] = downloadResp.Metadata.RemainingViews
 		}
 
 		// Send response
@@ -75,14 +123,19 @@ func DownloadPaste(cfg *config.Config, app *app.AppContext) http.HandlerFunc {
 
 // DownloadAllPastesOfUser retrieves all pastes created by the authenticated user.
 // @Summary Get all pastes for a user
-// @Description Retrieves a list of pastes for the currently authenticated user
+// @Description Retrieves a page of pastes of the currently authenticated user with the total number of matching pastes. The next page is fetched by passing next_cursor as the cursor parameter, it is also linked in the Link header with rel="next".
 // @Tags paste
 // @Accept json
 // @Produce json
 // @Param limit query int false "Limit the number of results (default: 10)"
-// @Param offset query int false "Offset for pagination (default: 0)"
+// @Param offset query int false "Offset for pagination (default: 0), ignored when a cursor is given"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param tag query string false "Only pastes with this tag"
+// @Param collection query int false "Only pastes in this collection"
+// @Param sort query string false "created_at (newest first, default), expiration (expiring soonest first) or title"
 // @Security BearerAuth
 // @Success 200 {object} map[string]interface{} "Successful response with pastes"
+// @Failure 400 {object} map[string]string "Invalid filter, sort order or cursor"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /pastes [get]
@@ -118,23 +171,67 @@ func DownloadAllPastesOfUser(cfg *config.Config, app *app.AppContext) http.Handl
 			return
 		}
 
+		collectionId, err := parseIDParam(r.URL.Query().Get("collection"))
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("collection must be a collection ID"))
+			return
+		}
+		sort, ok := pasteSorts[r.URL.Query().Get("sort")]
+		if !ok {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("sort must be created_at, expiration or title"))
+			return
+		}
+
 		// Fetch pastes for user
-		pastes, err := app.DownloadClient.DownloadByUserId(userId, int32(limitInt), int32(offsetInt))
+		pastes, err := app.DownloadClient.DownloadByUserId(userId, r.URL.Query().Get("tag"), collectionId, sort, r.URL.Query().Get("cursor"), int32(limitInt), int32(offsetInt))
 		if err != nil {
 			app.Logger.PrintError(ctx, fmt.Errorf("Failed to fetch pastes: %v", err), nil)
+			if status.Code(err) == codes.InvalidArgument {
+				errors.BadRequestResponse(w, http.StatusBadRequest, stdErrors.New(status.Convert(err).Message()))
+				return
+			}
 			errors.ServerErrorResponse(w, err)
 			return
 		}
 
 		// Create response
 		response := helpers.Envelope{
-			"pastes": pastes.Objects,
+			"pastes":      pastes.Objects,
+			"total_count": pastes.TotalCount,
+			"next_cursor": pastes.NextCursor,
+		}
+		var headers http.Header
+		if pastes.NextCursor != "" {
+			headers = http.Header{"Link": []string{fmt.Sprintf(`<%s>; rel="next"`, nextPageURL(r, pastes.NextCursor))}}
 		}
 
 		// Send response
-		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+		if err := helpers.WriteJSON(w, response, http.StatusOK, headers); err != nil {
 			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
 			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
 		}
 	}
 }
+
+// nextPageURL is the URL of the request with the cursor of the next page in place of the
+// cursor and offset of the current one.
+func nextPageURL(r *http.Request, cursor string) string {
+	query := r.URL.Query()
+	query.Del("offset")
+	query.Set("cursor", cursor)
+	next := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return next.String()
+}
+
+// pasteSorts maps the sort query parameter of the paste list to the download service enum.
+var pasteSorts = map[string]pb.PasteSort{
+	"":           pb.PasteSort_PASTE_SORT_CREATED_AT,
+	"created_at": pb.PasteSort_PASTE_SORT_CREATED_AT,
+	"expiration": pb.PasteSort_PASTE_SORT_EXPIRATION,
+	"title":      pb.PasteSort_PASTE_SORT_TITLE,
+}
+
+// visibilityName maps the visibility enum to its API name, e.g. VISIBILITY_PRIVATE to "private".
+func visibilityName(visibility pb.Visibility) string {
+	return strings.ToLower(strings.TrimPrefix(visibility.String(), "VISIBILITY_"))
+}
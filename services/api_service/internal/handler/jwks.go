@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+)
+
+// JWKSHandler godoc
+// @Summary Public keys of access tokens
+// @Description Publishes the keys access tokens are signed with as a JSON Web Key Set. The set is empty when tokens are signed with a shared secret
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "JSON Web Key Set"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /.well-known/jwks.json [get]
+func JWKSHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		jwks, err := app.KeySet.Keys()
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("loading signing keys failed: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("signing keys are unavailable"))
+			return
+		}
+
+		keys := make([]helpers.Envelope, 0, len(jwks))
+		for _, k := range jwks {
+			key := helpers.Envelope{"kty": k.Kty, "kid": k.Kid, "use": k.Use, "alg": k.Alg}
+			switch k.Kty {
+			case "RSA":
+				key["n"], key["e"] = k.N, k.E
+			case "EC":
+				key["crv"], key["x"], key["y"] = k.Crv, k.X, k.Y
+			}
+			keys = append(keys, key)
+		}
+
+		headers := http.Header{}
+		headers.Set("Cache-Control", "public, max-age=300")
+		if err := helpers.WriteJSON(w, helpers.Envelope{"keys": keys}, http.StatusOK, headers); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
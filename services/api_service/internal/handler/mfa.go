@@ -0,0 +1,199 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/middlewares"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LogInMFAHandler godoc
+// @Summary Complete a login with a second factor
+// @Description Exchanges the mfa_token returned by the login and a TOTP or recovery code for an access token and refresh token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param mfa_token body string true "Challenge token of the login"
+// @Param code body string true "TOTP code or recovery code"
+// @Success 200 {object} map[string]interface{} "Tokens and expiration"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Invalid code or expired challenge"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /login/mfa [post]
+func LogInMFAHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var input struct {
+			MfaToken string `json:"mfa_token"`
+			Code     string `json:"code"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		ip, _ := middlewares.ClientIP(r)
+		ress, err := app.AuthClient.VerifyMFA(input.MfaToken, input.Code, ip, r.UserAgent())
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			mfaErrorResponse(w, err)
+			return
+		}
+
+		response := helpers.Envelope{
+			"access_token":       ress.AccessToken,
+			"refresh_token":      ress.RefreshToken,
+			"expires_at":         ress.ExpiresIn.AsTime(),
+			"refresh_expires_at": ress.RefreshExpiresAt.AsTime(),
+		}
+		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// EnrollTOTPHandler godoc
+// @Summary Start setting up an authenticator app
+// @Description Generates a TOTP secret and its otpauth:// provisioning URI to show as a QR code. Two-factor authentication is enforced once a code is confirmed.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Secret and provisioning URI"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Two-factor authentication is already enabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/mfa/totp [post]
+func EnrollTOTPHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		res, err := app.AuthClient.EnrollTOTP(userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			mfaErrorResponse(w, err)
+			return
+		}
+
+		response := helpers.Envelope{"secret": res.Secret, "provisioning_uri": res.ProvisioningUri}
+		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// ConfirmTOTPHandler godoc
+// @Summary Enable two-factor authentication
+// @Description Confirms the authenticator app with a current code and returns the recovery codes. They are only shown once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code body string true "TOTP code"
+// @Success 200 {object} map[string]interface{} "Recovery codes"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Invalid code"
+// @Failure 409 {object} map[string]string "Enrollment was not started or is already confirmed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/mfa/totp/confirm [post]
+func ConfirmTOTPHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Code string `json:"code"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		recoveryCodes, err := app.AuthClient.ConfirmTOTP(userId, input.Code)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			mfaErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"recovery_codes": recoveryCodes}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// DisableTOTPHandler godoc
+// @Summary Disable two-factor authentication
+// @Description Removes the authenticator app and the recovery codes, confirmed with a current TOTP code or a recovery code.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code body string true "TOTP code or recovery code"
+// @Success 200 {object} map[string]string "Two-factor authentication disabled"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Invalid code"
+// @Failure 409 {object} map[string]string "Two-factor authentication is not enabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/mfa/totp [delete]
+func DisableTOTPHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		var input struct {
+			Code string `json:"code"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		message, err := app.AuthClient.DisableTOTP(userId, input.Code)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			mfaErrorResponse(w, err)
+			return
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// mfaErrorResponse translates the auth service errors of two-factor authentication into HTTP statuses.
+func mfaErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	case codes.NotFound:
+		code = http.StatusNotFound
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
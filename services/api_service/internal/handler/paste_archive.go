@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/download_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+)
+
+// archiveFormat describes an archive format of the archive endpoint.
+type archiveFormat struct {
+	format      pb.ArchiveFormat
+	contentType string
+	extension   string
+}
+
+// archiveFormats maps the format query parameter of the archive endpoint to the download service format.
+var archiveFormats = map[string]archiveFormat{
+	"tar.gz": {pb.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ, "application/gzip", ".tar.gz"},
+	"zip":    {pb.ArchiveFormat_ARCHIVE_FORMAT_ZIP, "application/zip", ".zip"},
+}
+
+// PasteArchiveHandler godoc
+// @Summary Download a bundle as an archive
+// @Description Streams every file of a multi-file paste as a single archive. Downloading the archive of a view limited paste takes one view
+// @Tags pastes
+// @Produce octet-stream
+// @Param key path string true "Paste Key"
+// @Param format query string false "tar.gz or zip (default: tar.gz)"
+// @Param X-Paste-Password header string false "Access password for protected pastes"
+// @Success 200 {string} string "Archive of the bundle"
+// @Failure 400 {object} map[string]string "Invalid format"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 409 {object} map[string]string "Paste is not a bundle"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/archive [get]
+func PasteArchiveHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		key := r.PathValue("key")
+		userId, _ := ctx.Value("user_id").(string)
+
+		name := r.URL.Query().Get("format")
+		if name == "" {
+			name = "tar.gz"
+		}
+		format, ok := archiveFormats[name]
+		if !ok {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("format must be tar.gz or zip"))
+			return
+		}
+
+		stream, err := app.DownloadClient.DownloadBundle(ctx, &pb.DownloadBundleRequest{
+			Key:      key,
+			UserId:   userId,
+			Password: r.Header.Get(pastePasswordHeader),
+			Format:   format.format,
+		})
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("opening archive stream failed: %w", err), map[string]string{"key": key})
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+
+		// The first chunk is read before the status is written, so access errors keep their status
+		first, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			app.Logger.PrintError(ctx, fmt.Errorf("streaming archive failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s%s"`, key, format.extension))
+		w.WriteHeader(http.StatusOK)
+		if first == nil {
+			return
+		}
+
+		// Once the status is written errors can only cut the body short
+		rc := http.NewResponseController(w)
+		for chunk := first; ; {
+			extendWriteDeadline(rc)
+			if _, err := w.Write(chunk.Chunk); err != nil {
+				return
+			}
+			chunk, err = stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					app.Logger.PrintError(ctx, fmt.Errorf("streaming archive failed: %w", err), map[string]string{"key": key})
+				}
+				return
+			}
+		}
+	}
+}
+
+// streamWriteTimeout bounds the write of a single chunk of a streamed body. The server wide
+// WriteTimeout covers the whole response and would cut long downloads short.
+const streamWriteTimeout = 30 * time.Second
+
+// extendWriteDeadline moves the write deadline of a streamed response past the next chunk.
+func extendWriteDeadline(rc *http.ResponseController) {
+	// Writers without deadline support keep the server wide WriteTimeout
+	_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+}
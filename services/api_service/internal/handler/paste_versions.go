@@ -0,0 +1,175 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// diffContextLines is the number of unchanged lines around each hunk of a diff.
+const diffContextLines = 3
+
+// PasteVersionsHandler godoc
+// @Summary List paste versions
+// @Description Lists every stored revision of a paste, oldest first
+// @Tags pastes
+// @Produce json
+// @Param key path string true "Paste Key"
+// @Param X-Paste-Password header string false "Access password for protected pastes"
+// @Success 200 {object} map[string]interface{} "Paste versions"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/versions [get]
+func PasteVersionsHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		key := r.PathValue("key")
+		userId, _ := ctx.Value("user_id").(string)
+
+		res, err := app.DownloadClient.ListVersions(ctx, key, userId, r.Header.Get(pastePasswordHeader))
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("listing paste versions failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+
+		versions := make([]helpers.Envelope, 0, len(res.Versions))
+		for _, v := range res.Versions {
+			versions = append(versions, helpers.Envelope{
+				"version":    v.Version,
+				"created_at": v.CreatedAt.AsTime(),
+			})
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"key": key, "versions": versions}, http.StatusOK, nil); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
+
+// PasteDiffHandler godoc
+// @Summary Diff two paste versions
+// @Description Returns a unified diff between two revisions of a paste. When omitted, to defaults to the latest version and from to the one before it
+// @Tags pastes
+// @Produce plain
+// @Param key path string true "Paste Key"
+// @Param from query int false "Base version"
+// @Param to query int false "Target version"
+// @Param X-Paste-Password header string false "Access password for protected pastes"
+// @Success 200 {string} string "Unified diff"
+// @Failure 400 {object} map[string]string "Invalid version"
+// @Failure 404 {object} map[string]string "Paste or version not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/diff [get]
+func PasteDiffHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		key := r.PathValue("key")
+		userId, _ := ctx.Value("user_id").(string)
+		password := r.Header.Get(pastePasswordHeader)
+
+		from, err := parseVersionParam(r, "from")
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		to, err := parseVersionParam(r, "to")
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if to == 0 {
+			res, err := app.DownloadClient.ListVersions(ctx, key, userId, password)
+			if err != nil {
+				app.Logger.PrintError(ctx, fmt.Errorf("listing paste versions failed: %w", err), map[string]string{"key": key})
+				pasteAccessErrorResponse(w, err)
+				return
+			}
+			if len(res.Versions) == 0 {
+				errors.BadRequestResponse(w, http.StatusNotFound, fmt.Errorf("paste %s has no versions", key))
+				return
+			}
+			to = res.Versions[len(res.Versions)-1].Version
+		}
+		if from == 0 {
+			from = to - 1
+		}
+		if from < 1 {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("paste %s has no version before %d", key, to))
+			return
+		}
+
+		fromRes, err := app.DownloadClient.GetVersion(ctx, key, from, userId, password)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("fetching paste version failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+		toRes, err := app.DownloadClient.GetVersion(ctx, key, to, userId, password)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("fetching paste version failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(fromRes.Content)),
+			B:        difflib.SplitLines(string(toRes.Content)),
+			FromFile: fmt.Sprintf("%s@v%d", key, from),
+			ToFile:   fmt.Sprintf("%s@v%d", key, to),
+			Context:  diffContextLines,
+		})
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("building diff failed: %w", err), map[string]string{"key": key})
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(diff))
+	}
+}
+
+// parseVersionParam reads an optional positive version number from the query string.
+// A missing parameter is reported as 0.
+func parseVersionParam(r *http.Request, name string) (int32, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%s must be a positive version number", name)
+	}
+	return int32(version), nil
+}
+
+// pasteAccessErrorResponse translates the download service errors of a paste lookup into HTTP statuses.
+func pasteAccessErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
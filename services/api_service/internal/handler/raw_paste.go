@@ -0,0 +1,237 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/download_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RawPasteHandler godoc
+// @Summary Download the raw paste content
+// @Description Streams the bytes of the latest revision of a paste through the gateway. Files of a bundle are streamed through /pastes/{key}/files/{name}/raw. Supports conditional requests and single byte ranges, ranges are ignored for view limited pastes. Content types outside of a small allowlist of inert types are served as text/plain, and the content is sandboxed and never sniffed
+// @Tags pastes
+// @Produce octet-stream
+// @Param key path string true "Paste Key"
+// @Param name path string false "File name, required for bundles"
+// @Param Range header string false "Single byte range, e.g. bytes=0-1023"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Date of a cached copy"
+// @Param If-Range header string false "ETag or date of the cached copy the range continues"
+// @Param X-Paste-Password header string false "Access password for protected pastes"
+// @Success 200 {string} string "Paste content"
+// @Success 206 {string} string "Requested range of the paste content"
+// @Success 304 {string} string "Cached copy is up to date"
+// @Failure 404 {object} map[string]string "Paste or file not found"
+// @Failure 409 {object} map[string]string "Paste is a bundle and no file name was given"
+// @Failure 416 {object} map[string]string "Range not satisfiable"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/raw [get]
+func RawPasteHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		key := r.PathValue("key")
+		userId, _ := ctx.Value("user_id").(string)
+
+		req := &pb.StreamContentRequest{
+			Key:         key,
+			File:        r.PathValue("name"),
+			UserId:      userId,
+			Password:    r.Header.Get(pastePasswordHeader),
+			IfNoneMatch: parseETags(r.Header.Get("If-None-Match")),
+		}
+		req.HasRange, req.Offset, req.Length = parseByteRange(r.Header.Get("Range"))
+		if value := r.Header.Get("If-Range"); value != "" && req.HasRange {
+			// A validator that can never match means the cached copy may be stale, so the whole content is sent
+			var ok bool
+			req.IfRangeEtag, req.IfRangeDate, ok = parseIfRange(value)
+			req.HasRange = ok
+		}
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+			req.IfModifiedSince = timestamppb.New(since)
+		}
+
+		stream, err := app.DownloadClient.StreamContent(ctx, req)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("opening paste stream failed: %w", err), map[string]string{"key": key})
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("streaming paste failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+		info := res.GetInfo()
+		if info == nil {
+			errors.ServerErrorResponse(w, stdErrors.New("paste stream did not start with the content info"))
+			return
+		}
+
+		header := w.Header()
+		header.Set("ETag", strconv.Quote(info.Etag))
+		header.Set("Last-Modified", info.LastModified.AsTime().UTC().Format(http.TimeFormat))
+		header.Set("Accept-Ranges", "bytes")
+		if info.NotModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		// The first chunk is read before the status is written, a rejected range or an
+		// exhausted view limit still has to be reported as an error status
+		first, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+		case status.Code(err) == codes.OutOfRange:
+			header.Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			errors.BadRequestResponse(w, http.StatusRequestedRangeNotSatisfiable, stdErrors.New(status.Convert(err).Message()))
+			return
+		case err != nil:
+			app.Logger.PrintError(ctx, fmt.Errorf("streaming paste failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+
+		setContentHeaders(header, info.ContentType, contentFileName(key, r.PathValue("name")))
+		header.Set("Content-Length", strconv.FormatInt(info.Length, 10))
+		if info.Partial {
+			header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", info.Offset, info.Offset+info.Length-1, info.Size))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		if first == nil {
+			return
+		}
+
+		// Once the status is written errors can only cut the body short
+		rc := http.NewResponseController(w)
+		for chunk := first; ; {
+			extendWriteDeadline(rc)
+			if _, err := w.Write(chunk.GetChunk()); err != nil {
+				return
+			}
+			chunk, err = stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					app.Logger.PrintError(ctx, fmt.Errorf("streaming paste failed: %w", err), map[string]string{"key": key})
+				}
+				return
+			}
+		}
+	}
+}
+
+// inlineContentTypes are the content types served as stored. Anything else, including the
+// text/html or image/svg+xml a client may declare, is served as plain text so the browser never
+// runs it on the origin of the gateway.
+var inlineContentTypes = map[string]bool{
+	"application/json":         true,
+	"application/octet-stream": true,
+	"application/pdf":          true,
+	"image/gif":                true,
+	"image/jpeg":               true,
+	"image/png":                true,
+	"image/webp":               true,
+}
+
+// setContentHeaders sets the type of the raw content together with the headers that keep the
+// browser from sniffing it or running scripts in it.
+func setContentHeaders(header http.Header, contentType, fileName string) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !inlineContentTypes[mediaType] {
+		contentType = "text/plain; charset=utf-8"
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "sandbox")
+	disposition := mime.FormatMediaType("inline", map[string]string{"filename": fileName})
+	if disposition == "" {
+		disposition = "inline"
+	}
+	header.Set("Content-Disposition", disposition)
+}
+
+// contentFileName names the downloaded content after the bundle file or the paste key.
+func contentFileName(key, file string) string {
+	if file != "" {
+		return path.Base(file)
+	}
+	return key
+}
+
+// parseByteRange reads a Range header with a single byte range into the offset and length of
+// StreamContentRequest. Missing, malformed and multi-range headers are ignored, which makes the
+// whole content to be sent.
+func parseByteRange(value string) (bool, int64, int64) {
+	spec, ok := strings.CutPrefix(value, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return false, 0, 0
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return false, 0, 0
+	}
+
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix <= 0 {
+			return false, 0, 0
+		}
+		return true, -suffix, 0
+	}
+
+	offset, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || offset < 0 {
+		return false, 0, 0
+	}
+	if last == "" {
+		return true, offset, 0
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < offset {
+		return false, 0, 0
+	}
+	return true, offset, end - offset + 1
+}
+
+// parseIfRange reads an If-Range header into the validator of StreamContentRequest. Weak entity
+// tags never match a range request, for them and for malformed values false is returned.
+func parseIfRange(value string) (string, *timestamppb.Timestamp, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		etag := strings.Trim(value, `"`)
+		return etag, nil, etag != ""
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return "", nil, false
+	}
+	return "", timestamppb.New(date), true
+}
+
+// parseETags splits an If-None-Match header into bare entity tags. Weak tags are compared
+// like strong ones, which is what the weak comparison of If-None-Match asks for.
+func parseETags(value string) []string {
+	var etags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag = strings.Trim(tag, `"`); tag != "" {
+			etags = append(etags, tag)
+		}
+	}
+	return etags
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	pb "github.com/NesterovYehor/TextNest/services/api_service/api/download_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+)
+
+// renderFormats maps the format query parameter of the render endpoint to the download service format.
+var renderFormats = map[string]pb.RenderFormat{
+	"html": pb.RenderFormat_RENDER_FORMAT_HTML,
+	"ansi": pb.RenderFormat_RENDER_FORMAT_ANSI,
+}
+
+// RenderPasteHandler godoc
+// @Summary Render a paste with syntax highlighting
+// @Description Returns the paste content highlighted as its language, either as a standalone HTML page or as ANSI colored text for terminals. Files of a bundle are rendered through /pastes/{key}/files/{name}/render. Reading a view limited paste this way takes a view
+// @Tags pastes
+// @Produce html
+// @Produce plain
+// @Param key path string true "Paste Key"
+// @Param name path string false "File name, required for bundles"
+// @Param format query string false "html or ansi (default: html)"
+// @Param X-Paste-Password header string false "Access password for protected pastes"
+// @Success 200 {string} string "Highlighted paste"
+// @Failure 400 {object} map[string]string "Invalid format"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 409 {object} map[string]string "Paste is encrypted, binary, too large to render or a bundle without a file name"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/render [get]
+func RenderPasteHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		key := r.PathValue("key")
+		userId, _ := ctx.Value("user_id").(string)
+
+		name := r.URL.Query().Get("format")
+		if name == "" {
+			name = "html"
+		}
+		format, ok := renderFormats[name]
+		if !ok {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("format must be html or ansi"))
+			return
+		}
+
+		res, err := app.DownloadClient.RenderPaste(ctx, key, r.PathValue("name"), userId, r.Header.Get(pastePasswordHeader), format)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("rendering paste failed: %w", err), map[string]string{"key": key})
+			pasteAccessErrorResponse(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", res.ContentType)
+		w.Header().Set("X-Paste-Language", res.Language)
+		w.WriteHeader(http.StatusOK)
+		w.Write(res.Content)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchPastesHandler godoc
+// @Summary Search pastes
+// @Description Full-text search over the titles, tags and content of the authenticated user's pastes. Matches in the snippet are wrapped in <mark> tags
+// @Tags pastes
+// @Produce json
+// @Param q query string true "Search query, supports quoted phrases, OR and -exclusions"
+// @Param limit query int false "Limit the number of results (default: 20, max: 100)"
+// @Param offset query int false "Offset for pagination (default: 0)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Ranked search results"
+// @Failure 400 {object} map[string]string "Invalid query"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/search [get]
+func SearchPastesHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.NoTokenProvided(w)
+			return
+		}
+
+		query := r.URL.Query().Get("q")
+		if query == "" {
+			errors.BadRequestResponse(w, http.StatusBadRequest, stdErrors.New("query parameter q is required"))
+			return
+		}
+		limit, err := parseCountParam(r, "limit")
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		offset, err := parseCountParam(r, "offset")
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := app.DownloadClient.SearchPastes(ctx, userId, query, limit, offset)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("searching pastes failed: %w", err), map[string]string{"user_id": userId})
+			if status.Code(err) == codes.InvalidArgument {
+				errors.BadRequestResponse(w, http.StatusBadRequest, stdErrors.New(status.Convert(err).Message()))
+				return
+			}
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+
+		results := make([]helpers.Envelope, 0, len(res.Results))
+		for _, result := range res.Results {
+			results = append(results, helpers.Envelope{
+				"key":             result.Metadata.Key,
+				"title":           result.Metadata.Title,
+				"created_at":      result.Metadata.CreatedAt.AsTime(),
+				"expiration_date": result.Metadata.ExpiredDate.AsTime(),
+				"visibility":      visibilityName(result.Metadata.Visibility),
+				"rank":            result.Rank,
+				"snippet":         result.Snippet,
+			})
+		}
+
+		if err := helpers.WriteJSON(w, helpers.Envelope{"query": query, "results": results}, http.StatusOK, nil); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
+
+// parseCountParam reads an optional non-negative integer from the query string.
+// A missing parameter is reported as 0, which lets the service apply its default.
+func parseCountParam(r *http.Request, name string) (int32, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative number", name)
+	}
+	return int32(n), nil
+}
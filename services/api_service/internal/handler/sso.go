@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+	"github.com/NesterovYehor/TextNest/pkg/helpers"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/middlewares"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ssoStateCookie binds a login to the browser that started it, so a callback URL of another
+	// login cannot be slipped to a victim.
+	ssoStateCookie = "sso_state"
+	// ssoStateExpiry matches how long the auth service keeps the state of a login.
+	ssoStateExpiry = 10 * time.Minute
+)
+
+// StartSSOHandler godoc
+// @Summary Log in with an identity provider
+// @Description Redirects to the sign in page of a configured OpenID Connect provider. The provider sends the user back to /users/sso/{provider}/callback.
+// @Tags auth
+// @Param provider path string true "Name of the identity provider"
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} map[string]string "Identity provider is not configured"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/sso/{provider} [get]
+func StartSSOHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		authURL, err := app.AuthClient.StartOIDCLogin(r.PathValue("provider"))
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			ssoErrorResponse(w, err)
+			return
+		}
+		parsed, err := url.Parse(authURL)
+		if err != nil || parsed.Query().Get("state") == "" {
+			errors.ServerErrorResponse(w, fmt.Errorf("authorization URL carries no state: %v", err))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     ssoStateCookie,
+			Value:    hashSSOState(parsed.Query().Get("state")),
+			Path:     "/v1/users/sso/",
+			MaxAge:   int(ssoStateExpiry.Seconds()),
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// SSOCallbackHandler godoc
+// @Summary Complete a login with an identity provider
+// @Description Handles the redirect of the identity provider and returns an access token and refresh token. Accounts are linked to users by their verified email. Users with two-factor authentication receive an mfa_token instead, the login is completed at /login/mfa.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Name of the identity provider"
+// @Param state query string true "State of the login"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} map[string]interface{} "Tokens and expiration"
+// @Failure 400 {object} map[string]string "Missing state or code, or the login was started in another browser"
+// @Failure 401 {object} map[string]string "Login was denied, failed or expired"
+// @Failure 403 {object} map[string]string "Email is not verified by the identity provider"
+// @Failure 404 {object} map[string]string "Identity provider is not configured"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/sso/{provider}/callback [get]
+func SSOCallbackHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+		// The provider reports a denied or failed sign in instead of a code
+		if idpErr := query.Get("error"); idpErr != "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("identity provider login failed: %s", idpErr))
+			return
+		}
+		state, code := query.Get("state"), query.Get("code")
+		if state == "" || code == "" {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("state and code are required"))
+			return
+		}
+		// The state is single use, the cookie is dropped whatever the outcome
+		cookie, err := r.Cookie(ssoStateCookie)
+		http.SetCookie(w, &http.Cookie{Name: ssoStateCookie, Path: "/v1/users/sso/", MaxAge: -1, HttpOnly: true})
+		if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(hashSSOState(state))) != 1 {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("login was not started in this browser"))
+			return
+		}
+
+		ip, _ := middlewares.ClientIP(r)
+		ress, err := app.AuthClient.CompleteOIDCLogin(r.PathValue("provider"), state, code, ip, r.UserAgent())
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			ssoErrorResponse(w, err)
+			return
+		}
+		response := helpers.Envelope{
+			"access_token":       ress.AccessToken,
+			"refresh_token":      ress.RefreshToken,
+			"expires_at":         ress.ExpiresIn.AsTime(),
+			"refresh_expires_at": ress.RefreshExpiresAt.AsTime(),
+		}
+		// Users with two-factor authentication finish the login with LogInMFAHandler
+		if ress.MfaRequired {
+			response = helpers.Envelope{
+				"mfa_required":   true,
+				"mfa_token":      ress.MfaToken,
+				"mfa_expires_at": ress.MfaExpiresAt.AsTime(),
+			}
+		}
+		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// hashSSOState keeps the state itself out of the cookie.
+func hashSSOState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// ssoErrorResponse translates the auth service errors of single sign-on into HTTP statuses.
+func ssoErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	default:
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+	errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+}
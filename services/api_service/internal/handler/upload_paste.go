@@ -1,20 +1,51 @@
 package handler
 
 import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	stdErrors "errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/errors"
 	"github.com/NesterovYehor/TextNest/pkg/helpers"
 	pb "github.com/NesterovYehor/TextNest/services/api_service/api/upload_service"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxPasteContentSize caps the body accepted by the content upload endpoint. The upload service
+// enforces the smaller limits of each role below it.
+const maxPasteContentSize = 10 << 20
+
+// pastePasswordHeader carries the access password of a protected paste.
+const pastePasswordHeader = "X-Paste-Password"
+
+// pasteChecksumHeader carries the hex MD5 of content uploaded to a presigned URL.
+const pasteChecksumHeader = "X-Paste-Checksum"
+
+// pasteEncryptionHeader carries the base64 encoded JSON envelope of a client side encrypted paste.
+const pasteEncryptionHeader = "X-Paste-Encryption"
+
+// ciphertextSampleSize is how much of an encrypted upload is inspected to reject plaintext.
+const ciphertextSampleSize = 512
+
+// errInvalidBundleBody is returned for multipart bodies that cannot be turned into a bundle.
+var errInvalidBundleBody = stdErrors.New("invalid multipart body")
+
 // UploadPasteHandler godoc
 // @Summary Upload a paste
-// @Description Upload a paste with title and expiration date
+// @Description Upload a paste with title and expiration date. The content is uploaded to the returned URL, the paste cannot be read until the upload is completed with POST /v1/pastes/{key}/complete
 // @Tags pastes
 // @Accept json
 // @Produce json
@@ -42,11 +73,9 @@ func UploadPasteHandler(app *app.AppContext) http.HandlerFunc {
 			errors.BadRequestResponse(w, http.StatusBadRequest, err)
 			return
 		}
-
-		key, err := app.KeyGenClient.GetKey(ctx)
-		if err != nil {
-			app.Logger.PrintError(ctx, fmt.Errorf("error generating new key: %w", err), nil)
-			errors.ServerErrorResponse(w, fmt.Errorf("internal error while generating key"))
+		// Content uploaded to a presigned URL never passes the gateway, so it cannot be checked for plaintext
+		if input.Encryption != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("encrypted pastes must be uploaded with POST /v1/pastes"))
 			return
 		}
 
@@ -54,11 +83,30 @@ func UploadPasteHandler(app *app.AppContext) http.HandlerFunc {
 		if !ok {
 			userID = ""
 		}
+		if userID == "" && (input.Visibility == "private" || input.Visibility == "shared") {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("private and shared pastes require authentication"))
+			return
+		}
+
+		key, err := app.KeyGenClient.GetKey(ctx)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error generating new key: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while generating key"))
+			return
+		}
 		uploadReq := &pb.UploadPasteRequest{
 			UserId:         userID,
 			Key:            key,
 			Title:          input.Title,
 			ExpirationDate: timestamppb.New(input.ExpirationDate),
+			Password:       input.Password,
+			MaxViews:       input.MaxViews,
+			BurnAfterRead:  input.BurnAfterRead,
+			Visibility:     visibilityFromInput(input.Visibility),
+			SharedWith:     input.SharedWith,
+			Tags:           input.Tags,
+			Language:       input.Language,
+			Filename:       input.Filename,
 		}
 
 		uploadURL, err := app.UploadClient.UploadPaste(ctx, uploadReq)
@@ -82,14 +130,281 @@ func UploadPasteHandler(app *app.AppContext) http.HandlerFunc {
 	}
 }
 
+// CompleteUploadHandler godoc
+// @Summary Complete a paste upload
+// @Description Activates a paste created with POST /v1/pastes/upload once its content was uploaded to the presigned URL, or with version the revision created with PUT /v1/pastes/update/{key}. Until then readers get the previous content. Content that is too large for the role of the user, of a type that is not allowed or that does not match the checksum is deleted and can be uploaded again. Uploads that are never completed are deleted.
+// @Tags pastes
+// @Produce json
+// @Param key path string true "Paste key"
+// @Param X-Paste-Checksum header string true "Hex MD5 of the uploaded content"
+// @Param version query int false "Revision returned when updating the paste, omitted for the first upload"
+// @Success 200 {object} map[string]interface{} "Key, version, size, checksum and content type of the stored content"
+// @Failure 400 {object} map[string]string "Checksum missing, invalid version or content not uploaded yet"
+// @Failure 403 {object} map[string]string "Paste of another user"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 409 {object} map[string]string "Content does not match the checksum or the revision is already completed"
+// @Failure 413 {object} map[string]string "Content is too large"
+// @Failure 415 {object} map[string]string "Content type is not allowed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key}/complete [post]
+func CompleteUploadHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _ := ctx.Value("user_id").(string)
+		// API keys carry no role, their uploads get the limits of regular users
+		tier, _ := ctx.Value("role").(string)
+		if tier == "" {
+			tier = "user"
+		}
+
+		checksum := r.Header.Get(pasteChecksumHeader)
+		if checksum == "" {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("%s header must be provided", pasteChecksumHeader))
+			return
+		}
+
+		version, err := parseVersionParam(r, "version")
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := app.UploadClient.CompleteUpload(ctx, r.PathValue("key"), userID, checksum, tier, version)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error completing upload: %w", err), nil)
+			var code int
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				code = http.StatusBadRequest
+			case codes.NotFound:
+				code = http.StatusNotFound
+			case codes.PermissionDenied:
+				code = http.StatusForbidden
+			case codes.FailedPrecondition:
+				code = uploadRejectionStatus(err)
+			default:
+				errors.ServerErrorResponse(w, fmt.Errorf("internal error while completing upload"))
+				return
+			}
+			errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+			return
+		}
+
+		response := helpers.Envelope{
+			"key":          res.Key,
+			"version":      res.Version,
+			"size":         res.Size,
+			"checksum":     res.Checksum,
+			"content_type": res.ContentType,
+		}
+		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
+
+// uploadRejectionStatus maps the reason the upload service rejected uploaded content for to an HTTP status.
+func uploadRejectionStatus(err error) int {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			switch info.Reason {
+			case "CONTENT_TOO_LARGE":
+				return http.StatusRequestEntityTooLarge
+			case "CONTENT_TYPE_NOT_ALLOWED":
+				return http.StatusUnsupportedMediaType
+			case "CONTENT_MISSING":
+				return http.StatusBadRequest
+			}
+		}
+	}
+	return http.StatusConflict
+}
+
+// UploadContentHandler godoc
+// @Summary Upload a paste with its content
+// @Description Upload the paste content as the raw request body. The content is streamed through the upload service into storage, metadata is passed as query parameters. A multipart/form-data body with one file part per file creates a multi-file bundle
+// @Tags pastes
+// @Accept octet-stream
+// @Accept mpfd
+// @Produce json
+// @Param title query string false "Paste title"
+// @Param expiration_date query string true "Expiration date (RFC 3339)"
+// @Param max_views query int false "Number of downloads after which the paste is deleted"
+// @Param burn_after_read query bool false "Delete the paste after the first download"
+// @Param visibility query string false "public, unlisted, private or shared (default: public)"
+// @Param shared_with query string false "Comma separated user IDs a shared paste is readable by"
+// @Param tags query string false "Comma separated tags, searchable together with the title and content"
+// @Param language query string false "Language of the content, detected from the filename and content when omitted"
+// @Param filename query string false "File name, used as a hint for language detection"
+// @Param X-Paste-Password header string false "Access password for the paste"
+// @Param X-Paste-Encryption header string false "Base64 encoded JSON encryption envelope, the body must then be raw ciphertext"
+// @Success 201 {object} map[string]interface{} "Key, size, expiration date and, for bundles, the files"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 413 {object} map[string]string "Paste content is too large for the role of the user"
+// @Failure 415 {object} map[string]string "Content type is not allowed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes [post]
+func UploadContentHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		expirationDate, err := time.Parse(time.RFC3339, r.URL.Query().Get("expiration_date"))
+		if err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("expiration_date must be a valid RFC 3339 timestamp"))
+			return
+		}
+		input := validation.PasteInput{
+			Title:          r.URL.Query().Get("title"),
+			ExpirationDate: expirationDate,
+			// The password travels in a header so it never ends up in access logs
+			Password:      r.Header.Get(pastePasswordHeader),
+			BurnAfterRead: r.URL.Query().Get("burn_after_read") == "true",
+			Visibility:    r.URL.Query().Get("visibility"),
+			Language:      r.URL.Query().Get("language"),
+			Filename:      r.URL.Query().Get("filename"),
+		}
+		if sharedWith := r.URL.Query().Get("shared_with"); sharedWith != "" {
+			input.SharedWith = strings.Split(sharedWith, ",")
+		}
+		if tags := r.URL.Query().Get("tags"); tags != "" {
+			input.Tags = strings.Split(tags, ",")
+		}
+		if header := r.Header.Get(pasteEncryptionHeader); header != "" {
+			encryption, err := decodeEncryptionHeader(header)
+			if err != nil {
+				errors.BadRequestResponse(w, http.StatusBadRequest, err)
+				return
+			}
+			input.Encryption = encryption
+		}
+		if maxViews := r.URL.Query().Get("max_views"); maxViews != "" {
+			views, err := strconv.ParseInt(maxViews, 10, 32)
+			if err != nil {
+				errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("max_views must be a number"))
+				return
+			}
+			input.MaxViews = int32(views)
+		}
+		if err := validation.ValidatePasteInput(&input); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("validation error: %w", err), nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxPasteContentSize)
+		parts, err := r.MultipartReader()
+		if err != nil && err != http.ErrNotMultipart {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("%w: %v", errInvalidBundleBody, err))
+			return
+		}
+		if parts != nil && input.Encryption != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("multi-file pastes cannot be encrypted"))
+			return
+		}
+
+		userID, ok := ctx.Value("user_id").(string)
+		if !ok {
+			userID = ""
+		}
+		if userID == "" && (input.Visibility == "private" || input.Visibility == "shared") {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("private and shared pastes require authentication"))
+			return
+		}
+
+		// API keys carry no role, their uploads get the limits of regular users
+		tier, _ := ctx.Value("role").(string)
+		if tier == "" {
+			tier = "user"
+		}
+
+		key, err := app.KeyGenClient.GetKey(ctx)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error generating new key: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while generating key"))
+			return
+		}
+		uploadReq := &pb.UploadPasteRequest{
+			Tier:           tier,
+			UserId:         userID,
+			Key:            key,
+			Title:          input.Title,
+			ExpirationDate: timestamppb.New(input.ExpirationDate),
+			Password:       input.Password,
+			MaxViews:       input.MaxViews,
+			BurnAfterRead:  input.BurnAfterRead,
+			Visibility:     visibilityFromInput(input.Visibility),
+			SharedWith:     input.SharedWith,
+			Tags:           input.Tags,
+			Language:       input.Language,
+			Filename:       input.Filename,
+			Encryption:     encryptionHeaderFromInput(input.Encryption),
+		}
+
+		var res *pb.UploadContentResponse
+		if parts != nil {
+			res, err = app.UploadClient.UploadBundle(ctx, uploadReq, bundleFiles(parts))
+		} else {
+			body := bufio.NewReaderSize(r.Body, ciphertextSampleSize)
+			if input.Encryption != nil {
+				sample, err := body.Peek(ciphertextSampleSize)
+				if err != nil && err != io.EOF {
+					app.Logger.PrintError(ctx, fmt.Errorf("error reading paste content: %w", err), map[string]string{"key": key})
+					errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("failed to read paste content"))
+					return
+				}
+				if !validation.LooksLikeCiphertext(sample) {
+					errors.BadRequestResponse(w, http.StatusBadRequest, fmt.Errorf("paste is marked encrypted but the content is plaintext"))
+					return
+				}
+			}
+			res, err = app.UploadClient.UploadContent(ctx, uploadReq, body)
+		}
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error uploading paste content: %w", err), map[string]string{"key": key})
+			var maxBytesErr *http.MaxBytesError
+			switch {
+			case stdErrors.As(err, &maxBytesErr):
+				errors.BadRequestResponse(w, http.StatusRequestEntityTooLarge, fmt.Errorf("paste content must not exceed %d bytes", maxBytesErr.Limit))
+			case stdErrors.Is(err, errInvalidBundleBody):
+				errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			case status.Code(err) == codes.InvalidArgument:
+				errors.BadRequestResponse(w, http.StatusBadRequest, stdErrors.New(status.Convert(err).Message()))
+			case status.Code(err) == codes.FailedPrecondition:
+				errors.BadRequestResponse(w, uploadRejectionStatus(err), stdErrors.New(status.Convert(err).Message()))
+			default:
+				errors.ServerErrorResponse(w, fmt.Errorf("internal error while uploading paste"))
+			}
+			return
+		}
+
+		response := helpers.Envelope{
+			"key":             res.Key,
+			"size":            res.Size,
+			"expiration_date": res.ExpirationDate.AsTime(),
+		}
+		if len(res.Files) > 0 {
+			files := make([]helpers.Envelope, 0, len(res.Files))
+			for _, f := range res.Files {
+				files = append(files, helpers.Envelope{"name": f.Name, "language": f.Language, "size": f.Size})
+			}
+			response["files"] = files
+		}
+		if err := helpers.WriteJSON(w, response, http.StatusCreated, nil); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
+
 // UpdatePasteHandler godoc
 // @Summary Update a paste
-// @Description Update an existing paste based on the key provided
+// @Description Creates a new revision of the paste and returns the URL its content is uploaded to. The revision is served once it is completed with POST /v1/pastes/{key}/complete?version=<version>
 // @Tags pastes
 // @Accept json
 // @Produce json
 // @Param key path string true "Paste Key"
-// @Success 200 {object} map[string]interface{} "Updated Paste URL"
+// @Success 200 {object} map[string]interface{} "Updated Paste URL and the new version number"
 // @Failure 400 {object} map[string]string "Invalid request body"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /update/{key} [put]
@@ -116,10 +431,147 @@ func UpdatePasteHandler(app *app.AppContext) http.HandlerFunc {
 			return
 		}
 
-		response := helpers.Envelope{"update_url": updateRes}
+		response := helpers.Envelope{"update_url": updateRes.UploadUrl, "version": updateRes.Version}
+		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
+			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
+		}
+	}
+}
+
+// UpdatePasteMetadataHandler godoc
+// @Summary Edit a paste
+// @Description Changes the title, expiration date and tags of a paste. Omitted fields are left unchanged, an empty title removes it. The edit is made against the metadata_version returned when downloading the paste and fails with 409 when the paste was edited since
+// @Tags pastes
+// @Accept json
+// @Produce json
+// @Param key path string true "Paste Key"
+// @Param paste body validation.PasteMetadataInput true "Changes of the paste"
+// @Success 200 {object} map[string]interface{} "Title, expiration date, tags and the new metadata version"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 403 {object} map[string]string "Paste of another user"
+// @Failure 404 {object} map[string]string "Paste not found"
+// @Failure 409 {object} map[string]string "Paste was edited since the given version or its upload is not completed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /pastes/{key} [patch]
+func UpdatePasteMetadataHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID, _ := ctx.Value("user_id").(string)
+
+		var input validation.PasteMetadataInput
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := validation.ValidatePasteMetadataInput(&input); err != nil {
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		req := &pb.UpdatePasteMetadataRequest{
+			Key:        r.PathValue("key"),
+			UserId:     userID,
+			Version:    input.Version,
+			Title:      input.Title,
+			AddTags:    input.AddTags,
+			RemoveTags: input.RemoveTags,
+		}
+		if input.ExpirationDate != nil {
+			req.ExpirationDate = timestamppb.New(*input.ExpirationDate)
+		}
+
+		res, err := app.UploadClient.UpdatePasteMetadata(ctx, req)
+		if err != nil {
+			app.Logger.PrintError(ctx, fmt.Errorf("error updating paste metadata: %w", err), nil)
+			var code int
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				code = http.StatusBadRequest
+			case codes.NotFound:
+				code = http.StatusNotFound
+			case codes.PermissionDenied:
+				code = http.StatusForbidden
+			case codes.FailedPrecondition, codes.Aborted:
+				code = http.StatusConflict
+			default:
+				errors.ServerErrorResponse(w, fmt.Errorf("internal error while updating paste"))
+				return
+			}
+			errors.BadRequestResponse(w, code, stdErrors.New(status.Convert(err).Message()))
+			return
+		}
+
+		response := helpers.Envelope{
+			"key":              res.Key,
+			"title":            res.Title,
+			"expiration_date":  res.ExpirationDate.AsTime(),
+			"tags":             res.Tags,
+			"metadata_version": res.Version,
+		}
 		if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
 			app.Logger.PrintError(ctx, fmt.Errorf("error writing JSON response: %w", err), nil)
 			errors.ServerErrorResponse(w, fmt.Errorf("internal error while sending response"))
 		}
 	}
 }
+
+// bundleFiles turns the parts of a multipart/form-data body into the files of a bundle.
+// Every part must be a file, its name becomes the name of the file in the bundle.
+func bundleFiles(parts *multipart.Reader) func() (*pb.PasteFile, io.Reader, error) {
+	return func() (*pb.PasteFile, io.Reader, error) {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return nil, nil, io.EOF
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if stdErrors.As(err, &maxBytesErr) {
+				return nil, nil, err
+			}
+			return nil, nil, fmt.Errorf("%w: %v", errInvalidBundleBody, err)
+		}
+		name := part.FileName()
+		if name == "" {
+			return nil, nil, fmt.Errorf("%w: part %q is not a file", errInvalidBundleBody, part.FormName())
+		}
+		return &pb.PasteFile{Name: name}, part, nil
+	}
+}
+
+// visibilityFromInput maps the visibility of the request, e.g. "private", to the upload service enum.
+// An empty value means public.
+func visibilityFromInput(visibility string) pb.Visibility {
+	if visibility == "" {
+		return pb.Visibility_VISIBILITY_PUBLIC
+	}
+	return pb.Visibility(pb.Visibility_value["VISIBILITY_"+strings.ToUpper(visibility)])
+}
+
+// decodeEncryptionHeader parses the X-Paste-Encryption header. The error never echoes the header value.
+func decodeEncryptionHeader(header string) (*validation.EncryptionInput, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be base64 encoded JSON", pasteEncryptionHeader)
+	}
+	var encryption validation.EncryptionInput
+	if err := json.Unmarshal(raw, &encryption); err != nil {
+		return nil, fmt.Errorf("%s must be base64 encoded JSON", pasteEncryptionHeader)
+	}
+	return &encryption, nil
+}
+
+func encryptionHeaderFromInput(encryption *validation.EncryptionInput) *pb.EncryptionHeader {
+	if encryption == nil {
+		return nil
+	}
+	return &pb.EncryptionHeader{
+		Algorithm:     encryption.Algorithm,
+		Kdf:           encryption.KDF,
+		KdfSalt:       encryption.KDFSalt,
+		KdfIterations: encryption.KDFIterations,
+		KdfMemoryKib:  encryption.KDFMemoryKiB,
+		Nonce:         encryption.Nonce,
+		KeyCheck:      encryption.KeyCheck,
+	}
+}
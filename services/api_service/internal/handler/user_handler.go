@@ -2,11 +2,19 @@ package handler
 
 import (
 	"context"
+	stdErrors "errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/NesterovYehor/TextNest/pkg/errors"
 	"github.com/NesterovYehor/TextNest/pkg/helpers"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/middlewares"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // SignUpHandler godoc
@@ -53,7 +61,7 @@ func SignUpHandler(app *app.AppContext, ctx context.Context) http.HandlerFunc {
 
 // LogInHandler godoc
 // @Summary Log in to the application
-// @Description Log in to the application using email and password to receive an access token and refresh token.
+// @Description Log in to the application using email and password to receive an access token and refresh token. Users with two-factor authentication receive an mfa_token instead, the login is completed at /login/mfa.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -61,6 +69,7 @@ func SignUpHandler(app *app.AppContext, ctx context.Context) http.HandlerFunc {
 // @Param password body string true "User Password"
 // @Success 200 {object} map[string]interface{} "Tokens and expiration"
 // @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 429 {object} map[string]string "Too many failed logins, retry after the Retry-After header"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /login [post]
 func LogInHandler(app *app.AppContext, ctx context.Context) http.HandlerFunc {
@@ -76,12 +85,30 @@ func LogInHandler(app *app.AppContext, ctx context.Context) http.HandlerFunc {
 			return
 		}
 
-		ress, err := app.AuthClient.LogIn(input.Email, input.Password)
+		// Failed logins are throttled per IP as well, the auth service only sees the gateway
+		ip, _ := middlewares.ClientIP(r)
+		ress, err := app.AuthClient.LogIn(input.Email, input.Password, ip, r.UserAgent())
 		if err != nil {
 			app.Logger.PrintError(ctx, err, nil)
+			if status.Code(err) == codes.ResourceExhausted {
+				loginThrottledResponse(w, err)
+				return
+			}
 			errors.BadRequestResponse(w, http.StatusBadRequest, err)
 			return
 		}
+		// Users with two-factor authentication finish the login with LogInMFAHandler
+		if ress.MfaRequired {
+			response := helpers.Envelope{
+				"mfa_required":   true,
+				"mfa_token":      ress.MfaToken,
+				"mfa_expires_at": ress.MfaExpiresAt.AsTime(),
+			}
+			if err := helpers.WriteJSON(w, response, http.StatusOK, nil); err != nil {
+				errors.ServerErrorResponse(w, err)
+			}
+			return
+		}
 		response := helpers.Envelope{
 			"access_token":       ress.AccessToken,
 			"refresh_token":      ress.RefreshToken,
@@ -105,6 +132,7 @@ func LogInHandler(app *app.AppContext, ctx context.Context) http.HandlerFunc {
 // @Param refresh_token body string true "Refresh Token"
 // @Success 200 {object} map[string]interface{} "New Tokens and expiration"
 // @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Refresh token is invalid, revoked or was already used"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /refresh [post]
 func RefreshTokens(app *app.AppContext) http.HandlerFunc {
@@ -118,10 +146,11 @@ func RefreshTokens(app *app.AppContext) http.HandlerFunc {
 			errors.BadRequestResponse(w, http.StatusBadRequest, err)
 			return
 		}
-		ress, err := app.AuthClient.RefreshTokens(input.Refresh)
+		ip, _ := middlewares.ClientIP(r)
+		ress, err := app.AuthClient.RefreshTokens(input.Refresh, ip, r.UserAgent())
 		if err != nil {
 			app.Logger.PrintError(ctx, err, nil)
-			errors.ServerErrorResponse(w, err)
+			refreshTokenErrorResponse(w, err)
 			return
 		}
 
@@ -247,3 +276,89 @@ func ResetPassword(app *app.AppContext) http.HandlerFunc {
 		}
 	}
 }
+
+// LogOutHandler godoc
+// @Summary Log out of the current session
+// @Description Revokes the refresh token and every token rotated from the same login, the access tokens of the session are rejected as well.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh_token body string true "Refresh Token"
+// @Success 200 {object} map[string]string "Logged out"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Refresh token is invalid or already revoked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /logout [post]
+func LogOutHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var input struct {
+			Refresh string `json:"refresh_token"`
+		}
+		if err := helpers.ReadJSON(w, r, &input); err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.BadRequestResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		message, err := app.AuthClient.Logout(input.Refresh)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			refreshTokenErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// LogOutAllHandler godoc
+// @Summary Log out of every session
+// @Description Revokes every refresh token of the authenticated user, the access tokens issued so far are rejected as well.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Logged out of all sessions"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /logout/all [post]
+func LogOutAllHandler(app *app.AppContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := ctx.Value("user_id").(string)
+		if userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		message, err := app.AuthClient.LogoutAll(userId)
+		if err != nil {
+			app.Logger.PrintError(ctx, err, nil)
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+		if err := helpers.WriteJSON(w, helpers.Envelope{"message": message}, http.StatusOK, nil); err != nil {
+			errors.ServerErrorResponse(w, err)
+			return
+		}
+	}
+}
+
+// refreshTokenErrorResponse reports rejected refresh tokens as 401 so clients know to log in again.
+func refreshTokenErrorResponse(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.Unauthenticated {
+		errors.BadRequestResponse(w, http.StatusUnauthorized, stdErrors.New(status.Convert(err).Message()))
+		return
+	}
+	errors.ServerErrorResponse(w, err)
+}
+
+// loginThrottledResponse rejects a throttled login, telling the client when to retry.
+func loginThrottledResponse(w http.ResponseWriter, err error) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(info.RetryDelay.AsDuration().Seconds()))))
+		}
+	}
+	errors.BadRequestResponse(w, http.StatusTooManyRequests, stdErrors.New(status.Convert(err).Message()))
+}
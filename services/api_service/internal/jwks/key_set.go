@@ -0,0 +1,203 @@
+package jwks
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// refreshInterval is how long fetched keys are used before they are fetched again.
+	refreshInterval = 5 * time.Minute
+	// minRefreshInterval limits how often tokens with an unknown kid or an unavailable auth
+	// service can trigger a fetch.
+	minRefreshInterval = 30 * time.Second
+)
+
+// ErrUnknownKey is returned for tokens signed by a key the auth service does not publish.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Fetcher loads the published keys, it is implemented by the auth service client.
+type Fetcher interface {
+	GetJwks() (*auth.GetJwksResponse, error)
+}
+
+type publicKey struct {
+	alg string
+	key any
+}
+
+// KeySet caches the public keys of the auth service, so access tokens can be verified by
+// the gateway itself. Keys are fetched again periodically and when a token names a kid
+// that is not known yet, which is how rotated keys are picked up.
+type KeySet struct {
+	fetcher     Fetcher
+	mu          sync.RWMutex
+	jwks        []*auth.JsonWebKey
+	keys        map[string]publicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewKeySet(fetcher Fetcher) *KeySet {
+	return &KeySet{fetcher: fetcher}
+}
+
+// Keys returns the published keys, fetching them when the cached ones are stale.
+func (s *KeySet) Keys() ([]*auth.JsonWebKey, error) {
+	s.mu.RLock()
+	jwks, fetchedAt := s.jwks, s.fetchedAt
+	s.mu.RUnlock()
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) < refreshInterval {
+		return jwks, nil
+	}
+	if err := s.refresh(); err != nil {
+		// Stale keys are still better than none while the auth service is unavailable
+		if jwks != nil {
+			return jwks, nil
+		}
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.jwks, nil
+}
+
+// VerifiesLocally reports whether the auth service signs access tokens with key pairs.
+// With shared secrets no keys are published and tokens must be checked by the auth service.
+func (s *KeySet) VerifiesLocally() bool {
+	jwks, err := s.Keys()
+	return err == nil && len(jwks) > 0
+}
+
+// AccessClaims are the claims of a verified access token.
+type AccessClaims struct {
+	UserID    string
+	Role      string    // Empty for tokens issued before roles were added, they belong to regular users
+	SessionID string    // Empty for tokens issued before sessions were tracked
+	IssuedAt  time.Time // Zero for tokens issued before the iat claim was added
+}
+
+// VerifyAccessToken checks the signature, expiry and type of an access token and returns its claims.
+func (s *KeySet) VerifyAccessToken(tokenStr string) (*AccessClaims, error) {
+	token, err := jwt.Parse(tokenStr, s.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("unable to parse token claims")
+	}
+	if tokenType, _ := claims["type"].(string); tokenType != "access" {
+		return nil, fmt.Errorf("incorrect token type: expected access, got %s", tokenType)
+	}
+	userId, ok := claims["user_id"].(string)
+	if !ok || userId == "" {
+		return nil, errors.New("user_id not found or invalid in token claims")
+	}
+	access := &AccessClaims{UserID: userId}
+	access.Role, _ = claims["role"].(string)
+	access.SessionID, _ = claims["sid"].(string)
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		access.IssuedAt = iat.Time
+	}
+	return access, nil
+}
+
+// keyfunc resolves the key of a token from its kid header. The algorithm of the token must be
+// the one the key was published for.
+func (s *KeySet) keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.lookup(kid)
+	if !ok {
+		if err := s.refresh(); err != nil {
+			return nil, err
+		}
+		if key, ok = s.lookup(kid); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+		}
+	}
+	if token.Method.Alg() != key.alg {
+		return nil, fmt.Errorf("unexpected signing method %s for key %s", token.Method.Alg(), kid)
+	}
+	return key.key, nil
+}
+
+func (s *KeySet) lookup(kid string) (publicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the keys unless the last attempt was less than minRefreshInterval ago.
+func (s *KeySet) refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.attemptedAt) < minRefreshInterval {
+		return nil
+	}
+	s.attemptedAt = time.Now()
+
+	res, err := s.fetcher.GetJwks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	keys := make(map[string]publicKey, len(res.Keys))
+	for _, jwk := range res.Keys {
+		key, err := parseKey(jwk)
+		if err != nil {
+			return fmt.Errorf("invalid signing key %s: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = publicKey{alg: jwk.Alg, key: key}
+	}
+	s.jwks, s.keys, s.fetchedAt = res.Keys, keys, time.Now()
+	return nil
+}
+
+func parseKey(jwk *auth.JsonWebKey) (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
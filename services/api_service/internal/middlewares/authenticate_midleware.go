@@ -2,12 +2,16 @@ package middlewares
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/NesterovYehor/TextNest/pkg/errors"
 	"github.com/NesterovYehor/TextNest/services/api_service/internal/app"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/verifier"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func Authenticate(next http.Handler) http.Handler {
@@ -23,6 +27,10 @@ func Authenticate(next http.Handler) http.Handler {
 		}
 
 		headerParts := strings.Split(authorizationHeader, " ")
+		if len(headerParts) == 2 && headerParts[0] == "Token" {
+			authenticateAPIKey(appCtx, w, r, headerParts[1], next)
+			return
+		}
 		if len(headerParts) != 2 || headerParts[0] != "Bearer" {
 			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("Invalid Authentication Token Response"))
 			return
@@ -30,15 +38,39 @@ func Authenticate(next http.Handler) http.Handler {
 
 		token := headerParts[1]
 
-		userId, err := appCtx.AuthClient.AuthorizeUser(token)
+		userId, role, err := appCtx.Verifier.Verify(token)
 		if err != nil {
+			if stdErrors.Is(err, verifier.ErrInvalidToken) || stdErrors.Is(err, verifier.ErrUnknownUser) || stdErrors.Is(err, verifier.ErrRevokedToken) {
+				appCtx.Logger.PrintError(r.Context(), fmt.Errorf("Failed to verify access token: %v", err), nil)
+				errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("Invalid Authentication Token Response"))
+				return
+			}
 			appCtx.Logger.PrintError(context.Background(), fmt.Errorf("Failed to authorize user: %v", err), nil)
 			errors.ServerErrorResponse(w, err)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), "user_id", userId)
-		fmt.Println(ctx)
+		ctx = context.WithValue(ctx, "role", role)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// authenticateAPIKey serves requests carrying a personal access token. The scopes of the key are
+// stored in the request context, RequireScope checks them per route.
+func authenticateAPIKey(appCtx *app.AppContext, w http.ResponseWriter, r *http.Request, token string, next http.Handler) {
+	res, err := appCtx.AuthClient.AuthorizeAPIKey(token)
+	if err != nil {
+		appCtx.Logger.PrintError(r.Context(), fmt.Errorf("Failed to authorize API key: %v", err), nil)
+		if status.Code(err) == codes.Unauthenticated {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("Invalid API key"))
+			return
+		}
+		errors.ServerErrorResponse(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "user_id", res.UserId)
+	ctx = context.WithValue(ctx, "scopes", res.Scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -34,7 +34,7 @@ func RateLimit(cfg *config.Config, next http.Handler) http.Handler {
 			return
 		}
 
-		ip, err := getClientIP(r)
+		ip, err := ClientIP(r)
 		if err != nil {
 			errors.BadRequestResponse(w, http.StatusInternalServerError, fmt.Errorf("Could not validate client address"))
 			return
@@ -66,7 +66,8 @@ func RateLimit(cfg *config.Config, next http.Handler) http.Handler {
 	})
 }
 
-func getClientIP(r *http.Request) (string, error) {
+// ClientIP returns the IP of the client that sent the request.
+func ClientIP(r *http.Request) (string, error) {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		return "", err
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/NesterovYehor/TextNest/pkg/errors"
+)
+
+// Scopes of personal access tokens.
+const (
+	ScopePasteRead   = "paste:read"
+	ScopePasteWrite  = "paste:write"
+	ScopePasteDelete = "paste:delete"
+)
+
+// RequireScope rejects requests authenticated with an API key that was not granted scope.
+// Logged in users and anonymous requests are not limited by scopes. It has to be wrapped by Authenticate.
+func RequireScope(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes, isAPIKey := r.Context().Value("scopes").([]string)
+		if isAPIKey && !slices.Contains(scopes, scope) {
+			errors.BadRequestResponse(w, http.StatusForbidden, fmt.Errorf("API key is missing the %s scope", scope))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireSession rejects requests authenticated with an API key, the account itself can only be
+// managed by a logged in user. It has to be wrapped by Authenticate.
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, isAPIKey := r.Context().Value("scopes").([]string); isAPIKey {
+			errors.BadRequestResponse(w, http.StatusForbidden, fmt.Errorf("API keys cannot be used to manage the account"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Roles of users, every role can do what the roles before it can.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// RequireRole rejects requests of users without at least the given role. The role is taken from
+// the access token, anonymous requests and API keys have none. It has to be wrapped by Authenticate.
+func RequireRole(role string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userId, _ := r.Context().Value("user_id").(string); userId == "" {
+			errors.BadRequestResponse(w, http.StatusUnauthorized, fmt.Errorf("authentication required"))
+			return
+		}
+		userRole, _ := r.Context().Value("role").(string)
+		if roleRank(userRole) < roleRank(role) {
+			errors.BadRequestResponse(w, http.StatusForbidden, fmt.Errorf("this action requires the %s role", role))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func roleRank(role string) int {
+	switch role {
+	case RoleAdmin:
+		return 2
+	case RoleModerator:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -2,19 +2,111 @@ package validation
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
+	"unicode/utf8"
 )
 
 // PasteInput represents the input for a paste request.
 type PasteInput struct {
-	Title          string    `json:"title"`
-	ExpirationDate time.Time `json:"expiration_date"`
+	Title          string           `json:"title"`
+	ExpirationDate time.Time        `json:"expiration_date"`
+	Password       string           `json:"password,omitempty"`
+	MaxViews       int32            `json:"max_views,omitempty"`
+	BurnAfterRead  bool             `json:"burn_after_read,omitempty"`
+	Visibility     string           `json:"visibility,omitempty"`
+	SharedWith     []string         `json:"shared_with,omitempty"`
+	Tags           []string         `json:"tags,omitempty"`
+	Language       string           `json:"language,omitempty"`
+	Filename       string           `json:"filename,omitempty"`
+	Encryption     *EncryptionInput `json:"encryption,omitempty"`
 }
 
+// EncryptionInput is the envelope of a paste encrypted on the client. Byte fields are base64 encoded.
+// It holds key material and must never be logged.
+type EncryptionInput struct {
+	Algorithm     string `json:"algorithm"`
+	KDF           string `json:"kdf"`
+	KDFSalt       []byte `json:"kdf_salt,omitempty"`
+	KDFIterations int32  `json:"kdf_iterations,omitempty"`
+	KDFMemoryKiB  int32  `json:"kdf_memory_kib,omitempty"`
+	Nonce         []byte `json:"nonce"`
+	KeyCheck      []byte `json:"key_check"`
+}
+
+// String keeps the key material out of logs and error messages that format the input.
+func (e EncryptionInput) String() string {
+	return "EncryptionInput{redacted}"
+}
+
+// minCiphertextSize is the size of an AEAD authentication tag, no valid ciphertext is shorter.
+const minCiphertextSize = 16
+
+// LooksLikeCiphertext reports whether the first bytes of an upload can be ciphertext.
+// AEAD output is indistinguishable from random bytes and practically never valid UTF-8,
+// so text content is taken as a plaintext upload.
+func LooksLikeCiphertext(sample []byte) bool {
+	return len(sample) >= minCiphertextSize && !utf8.Valid(sample)
+}
+
+// Visibilities lists the accepted values of PasteInput.Visibility, an empty value means public.
+var Visibilities = []string{"public", "unlisted", "private", "shared"}
+
 // ValidatePasteInput validates the input for uploading a paste.
 func ValidatePasteInput(input *PasteInput) error {
 	if input.ExpirationDate.Before(time.Now()) {
 		return errors.New("expiration date cannot be in the past")
 	}
+	if len(input.Password) > 72 {
+		return errors.New("password must not be longer than 72 bytes")
+	}
+	if input.MaxViews < 0 {
+		return errors.New("max views cannot be negative")
+	}
+	if input.BurnAfterRead && input.MaxViews > 1 {
+		return errors.New("burn after read pastes can be viewed only once")
+	}
+	if input.Visibility != "" && !slices.Contains(Visibilities, input.Visibility) {
+		return fmt.Errorf("visibility must be one of %v", Visibilities)
+	}
+	if input.Visibility == "shared" && len(input.SharedWith) == 0 {
+		return errors.New("shared pastes must be shared with at least one user")
+	}
+	if input.Visibility != "shared" && len(input.SharedWith) > 0 {
+		return errors.New("only shared pastes can have a list of users")
+	}
+	if len(input.Tags) > 20 {
+		return errors.New("a paste can have at most 20 tags")
+	}
+	if input.Encryption != nil && (input.Encryption.Algorithm == "" || len(input.Encryption.Nonce) == 0 || len(input.Encryption.KeyCheck) == 0) {
+		return errors.New("encryption header must contain the algorithm, nonce and key check value")
+	}
+	return nil
+}
+
+// PasteMetadataInput is an edit of a paste by its owner. Omitted fields are left unchanged.
+type PasteMetadataInput struct {
+	Version        int64      `json:"version"`
+	Title          *string    `json:"title,omitempty"`
+	ExpirationDate *time.Time `json:"expiration_date,omitempty"`
+	AddTags        []string   `json:"add_tags,omitempty"`
+	RemoveTags     []string   `json:"remove_tags,omitempty"`
+}
+
+// ValidatePasteMetadataInput validates the input for editing a paste.
+func ValidatePasteMetadataInput(input *PasteMetadataInput) error {
+	if input.Version <= 0 {
+		return errors.New("version of the metadata the edit was made against must be provided")
+	}
+	if input.Title == nil && input.ExpirationDate == nil && len(input.AddTags) == 0 && len(input.RemoveTags) == 0 {
+		return errors.New("at least one change must be provided")
+	}
+	if input.ExpirationDate != nil && input.ExpirationDate.Before(time.Now()) {
+		return errors.New("expiration date cannot be in the past")
+	}
+	if len(input.AddTags) > 20 {
+		return errors.New("a paste can have at most 20 tags")
+	}
 	return nil
 }
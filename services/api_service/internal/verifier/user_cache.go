@@ -0,0 +1,76 @@
+package verifier
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type userEntry struct {
+	userId    string
+	exists    bool
+	expiresAt time.Time
+}
+
+// userCache remembers whether users exist for a limited time. It holds at most size users,
+// the least recently used one is dropped first.
+type userCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	order *list.List // Front is the most recently used entry
+	users map[string]*list.Element
+}
+
+func newUserCache(size int, ttl time.Duration) *userCache {
+	return &userCache{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		users: make(map[string]*list.Element, size),
+	}
+}
+
+// get reports whether the user exists and whether that is known at all.
+func (c *userCache) get(userId string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.users[userId]
+	if !ok {
+		return false, false
+	}
+	entry := elem.Value.(*userEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.users, userId)
+		return false, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.exists, true
+}
+
+func (c *userCache) set(userId string, exists bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.users[userId]; ok {
+		entry := elem.Value.(*userEntry)
+		entry.exists, entry.expiresAt = exists, time.Now().Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.users[userId] = c.order.PushFront(&userEntry{userId: userId, exists: exists, expiresAt: time.Now().Add(c.ttl)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.users, oldest.Value.(*userEntry).userId)
+	}
+}
+
+func (c *userCache) delete(userId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.users[userId]; ok {
+		c.order.Remove(elem)
+		delete(c.users, userId)
+	}
+}
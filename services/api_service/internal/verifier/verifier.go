@@ -0,0 +1,168 @@
+package verifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/jwks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UserRevokedTopic is the topic the auth service announces ended sessions on.
+const UserRevokedTopic = "user-revoked"
+
+var (
+	ErrInvalidToken = errors.New("invalid access token")
+	ErrUnknownUser  = errors.New("user does not exist")
+	ErrRevokedToken = errors.New("access token was revoked")
+)
+
+// Authorizer checks an access token with the auth service, it is implemented by the auth service client.
+type Authorizer interface {
+	AuthorizeUser(token string) (userId string, role string, err error)
+}
+
+// Verifier authenticates access tokens. Signatures are checked with the published keys of the
+// auth service and whether the user exists is cached, so a cached user costs no network round
+// trip. Tokens of users whose sessions were revoked are rejected when they were issued before
+// the revocation, tokens of a single revoked session are rejected altogether. Revocations are
+// only learned from UserRevokedTopic, without published keys or while its consumer is not
+// running every token is checked by the auth service.
+type Verifier struct {
+	keys       *jwks.KeySet
+	authorizer Authorizer
+	users      *userCache
+
+	mu          sync.RWMutex
+	revocations map[string]time.Time // Kept for retention, the longest lifetime of an access token
+	sessions    map[string]time.Time // Revoked sessions, kept for retention as well
+	retention   time.Duration
+
+	consuming atomic.Bool // Set while the consumer of UserRevokedTopic runs
+}
+
+func NewVerifier(keys *jwks.KeySet, authorizer Authorizer, cacheSize int, cacheTTL, retention time.Duration) *Verifier {
+	return &Verifier{
+		keys:        keys,
+		authorizer:  authorizer,
+		users:       newUserCache(cacheSize, cacheTTL),
+		revocations: make(map[string]time.Time),
+		sessions:    make(map[string]time.Time),
+		retention:   retention,
+	}
+}
+
+// Verify returns the user ID and the role of a valid access token.
+func (v *Verifier) Verify(token string) (string, string, error) {
+	if !v.consuming.Load() || !v.keys.VerifiesLocally() {
+		userId, role, err := v.authorizer.AuthorizeUser(token)
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			return "", "", fmt.Errorf("%w: %v", ErrRevokedToken, err)
+		case codes.NotFound:
+			return "", "", ErrUnknownUser
+		}
+		return userId, role, err
+	}
+
+	claims, err := v.keys.VerifyAccessToken(token)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	userId := claims.UserID
+	if v.revoked(userId, claims.SessionID, claims.IssuedAt) {
+		return "", "", ErrRevokedToken
+	}
+
+	exists, known := v.users.get(userId)
+	if !known {
+		// The auth service verifies the token again, only whether the user exists is of interest here
+		if _, _, err := v.authorizer.AuthorizeUser(token); err != nil {
+			// The session of the token ended, the other sessions of the user may still be valid
+			if status.Code(err) == codes.Unauthenticated {
+				return "", "", fmt.Errorf("%w: %v", ErrRevokedToken, err)
+			}
+			if status.Code(err) != codes.NotFound {
+				return "", "", err
+			}
+			v.users.set(userId, false)
+			return "", "", ErrUnknownUser
+		}
+		exists = true
+		v.users.set(userId, true)
+	}
+	if !exists {
+		return "", "", ErrUnknownUser
+	}
+	// A changed role revokes the tokens of the user, the role claim of a valid token is current
+	return userId, claims.Role, nil
+}
+
+// SetConsumingRevocations reports whether revocations are received. Tokens are only verified
+// locally while they are, a token of an ended session would pass otherwise.
+func (v *Verifier) SetConsumingRevocations(consuming bool) {
+	v.consuming.Store(consuming)
+}
+
+// Revoke rejects the access tokens of the user issued before at.
+func (v *Verifier) Revoke(userId string, at time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// iat has a resolution of seconds, tokens issued in the second of the revocation stay valid
+	v.revocations[userId] = at.Truncate(time.Second)
+	for id, revokedAt := range v.revocations {
+		if time.Since(revokedAt) > v.retention {
+			delete(v.revocations, id)
+		}
+	}
+	v.users.delete(userId)
+}
+
+// RevokeSession rejects every access token issued for the session.
+func (v *Verifier) RevokeSession(sessionId string, at time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.sessions[sessionId] = at
+	for id, revokedAt := range v.sessions {
+		if time.Since(revokedAt) > v.retention {
+			delete(v.sessions, id)
+		}
+	}
+}
+
+func (v *Verifier) revoked(userId, sessionId string, issuedAt time.Time) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if _, ok := v.sessions[sessionId]; ok && sessionId != "" {
+		return true
+	}
+	revokedAt, ok := v.revocations[userId]
+	return ok && issuedAt.Before(revokedAt)
+}
+
+// HandleRevocation applies a message of UserRevokedTopic.
+func (v *Verifier) HandleRevocation(msg *sarama.ConsumerMessage) error {
+	var revocation struct {
+		UserID    string    `json:"user_id"`
+		SessionID string    `json:"session_id"`
+		RevokedAt time.Time `json:"revoked_at"`
+	}
+	if err := json.Unmarshal(msg.Value, &revocation); err != nil {
+		return fmt.Errorf("failed to decode revocation: %w", err)
+	}
+	if revocation.UserID == "" {
+		return errors.New("revocation without user_id")
+	}
+	if revocation.SessionID != "" {
+		v.RevokeSession(revocation.SessionID, revocation.RevokedAt)
+		return nil
+	}
+	v.Revoke(revocation.UserID, revocation.RevokedAt)
+	return nil
+}
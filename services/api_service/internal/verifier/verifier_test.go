@@ -0,0 +1,322 @@
+package verifier
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	auth "github.com/NesterovYehor/TextNest/services/api_service/api/auth_service"
+	"github.com/NesterovYehor/TextNest/services/api_service/internal/jwks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testKid = "test-key"
+
+// fakeFetcher publishes a single key, or none when key is nil.
+type fakeFetcher struct {
+	key *ecdsa.PublicKey
+}
+
+func (f *fakeFetcher) GetJwks() (*auth.GetJwksResponse, error) {
+	if f.key == nil {
+		return &auth.GetJwksResponse{}, nil
+	}
+	return &auth.GetJwksResponse{Keys: []*auth.JsonWebKey{{
+		Kty: "EC",
+		Kid: testKid,
+		Use: "sig",
+		Alg: "ES256",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(f.key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(f.key.Y.FillBytes(make([]byte, 32))),
+	}}}, nil
+}
+
+// fakeAuthorizer answers like the auth service and counts the calls.
+type fakeAuthorizer struct {
+	err   error
+	calls int
+}
+
+func (a *fakeAuthorizer) AuthorizeUser(token string) (string, string, error) {
+	a.calls++
+	if a.err != nil {
+		return "", "", a.err
+	}
+	return "user-1", "user", nil
+}
+
+func signToken(t *testing.T, key *ecdsa.PrivateKey, userId, sessionId string, issuedAt time.Time) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"type":    "access",
+		"user_id": userId,
+		"role":    "user",
+		"sid":     sessionId,
+		"iat":     issuedAt.Unix(),
+		"exp":     issuedAt.Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = testKid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		publishKeys    bool
+		consuming      bool
+		authorizerErr  error
+		prepare        func(v *Verifier)
+		token          string
+		wantUserId     string
+		wantErr        error
+		wantAuthorizes int
+	}{
+		{
+			name:           "no published keys falls back to the auth service",
+			consuming:      true,
+			token:          "opaque",
+			wantUserId:     "user-1",
+			wantAuthorizes: 1,
+		},
+		{
+			name:           "fallback reports ended sessions as revoked",
+			consuming:      true,
+			authorizerErr:  status.Error(codes.Unauthenticated, "session ended"),
+			token:          "opaque",
+			wantErr:        ErrRevokedToken,
+			wantAuthorizes: 1,
+		},
+		{
+			name:           "fallback reports deleted users as unknown",
+			consuming:      true,
+			authorizerErr:  status.Error(codes.NotFound, "user not found"),
+			token:          "opaque",
+			wantErr:        ErrUnknownUser,
+			wantAuthorizes: 1,
+		},
+		{
+			name:           "keys without the revocation consumer fall back to the auth service",
+			publishKeys:    true,
+			token:          signToken(t, key, "user-1", "session-1", now),
+			wantUserId:     "user-1",
+			wantAuthorizes: 1,
+		},
+		{
+			name:           "cache miss asks whether the user exists",
+			publishKeys:    true,
+			consuming:      true,
+			token:          signToken(t, key, "user-1", "session-1", now),
+			wantUserId:     "user-1",
+			wantAuthorizes: 1,
+		},
+		{
+			name:        "cache hit is verified locally",
+			publishKeys: true,
+			consuming:   true,
+			prepare: func(v *Verifier) {
+				v.users.set("user-1", true)
+			},
+			token:      signToken(t, key, "user-1", "session-1", now),
+			wantUserId: "user-1",
+		},
+		{
+			name:        "cached unknown user",
+			publishKeys: true,
+			consuming:   true,
+			prepare: func(v *Verifier) {
+				v.users.set("user-1", false)
+			},
+			token:   signToken(t, key, "user-1", "session-1", now),
+			wantErr: ErrUnknownUser,
+		},
+		{
+			name:           "deleted user",
+			publishKeys:    true,
+			consuming:      true,
+			authorizerErr:  status.Error(codes.NotFound, "user not found"),
+			token:          signToken(t, key, "user-1", "session-1", now),
+			wantErr:        ErrUnknownUser,
+			wantAuthorizes: 1,
+		},
+		{
+			name:        "revoked session",
+			publishKeys: true,
+			consuming:   true,
+			prepare: func(v *Verifier) {
+				v.users.set("user-1", true)
+				v.RevokeSession("session-1", now)
+			},
+			token:   signToken(t, key, "user-1", "session-1", now),
+			wantErr: ErrRevokedToken,
+		},
+		{
+			name:        "revoked user",
+			publishKeys: true,
+			consuming:   true,
+			prepare: func(v *Verifier) {
+				v.Revoke("user-1", now)
+			},
+			token:   signToken(t, key, "user-1", "session-1", now.Add(-time.Minute)),
+			wantErr: ErrRevokedToken,
+		},
+		{
+			name:        "token issued after the user was revoked",
+			publishKeys: true,
+			consuming:   true,
+			prepare: func(v *Verifier) {
+				v.Revoke("user-1", now.Add(-time.Minute))
+				v.users.set("user-1", true)
+			},
+			token:      signToken(t, key, "user-1", "session-1", now),
+			wantUserId: "user-1",
+		},
+		{
+			name:        "invalid signature",
+			publishKeys: true,
+			consuming:   true,
+			token:       signToken(t, key, "user-1", "session-1", now) + "x",
+			wantErr:     ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &fakeFetcher{}
+			if tt.publishKeys {
+				fetcher.key = &key.PublicKey
+			}
+			authorizer := &fakeAuthorizer{err: tt.authorizerErr}
+			v := NewVerifier(jwks.NewKeySet(fetcher), authorizer, 10, time.Minute, time.Hour)
+			v.SetConsumingRevocations(tt.consuming)
+			if tt.prepare != nil {
+				tt.prepare(v)
+			}
+
+			userId, _, err := v.Verify(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantUserId, userId)
+			}
+			assert.Equal(t, tt.wantAuthorizes, authorizer.calls)
+		})
+	}
+}
+
+func TestRevoked(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	v := NewVerifier(nil, nil, 10, time.Minute, time.Hour)
+	v.Revoke("user-1", now)
+	v.RevokeSession("session-1", now)
+
+	tests := []struct {
+		name      string
+		userId    string
+		sessionId string
+		issuedAt  time.Time
+		want      bool
+	}{
+		{name: "revoked session", userId: "user-2", sessionId: "session-1", issuedAt: now.Add(time.Hour), want: true},
+		{name: "issued before the user was revoked", userId: "user-1", sessionId: "session-2", issuedAt: now.Add(-time.Second), want: true},
+		{name: "issued in the second of the revocation", userId: "user-1", sessionId: "session-2", issuedAt: now, want: false},
+		{name: "token without iat of a revoked user", userId: "user-1", want: true},
+		{name: "other user", userId: "user-2", sessionId: "session-2", issuedAt: now.Add(-time.Hour), want: false},
+		{name: "token without session", userId: "user-2", issuedAt: now, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, v.revoked(tt.userId, tt.sessionId, tt.issuedAt))
+		})
+	}
+}
+
+func TestHandleRevocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantErr     bool
+		wantUser    bool
+		wantSession bool
+	}{
+		{name: "user", value: `{"user_id":"user-1","revoked_at":"2025-01-02T03:04:05Z"}`, wantUser: true},
+		{name: "session", value: `{"user_id":"user-1","session_id":"session-1","revoked_at":"2025-01-02T03:04:05Z"}`, wantSession: true},
+		{name: "missing user", value: `{"session_id":"session-1"}`, wantErr: true},
+		{name: "malformed", value: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVerifier(nil, nil, 10, time.Minute, 100*365*24*time.Hour)
+			v.users.set("user-1", true)
+
+			err := v.HandleRevocation(&sarama.ConsumerMessage{Value: []byte(tt.value)})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			_, userRevoked := v.revocations["user-1"]
+			_, sessionRevoked := v.sessions["session-1"]
+			assert.Equal(t, tt.wantUser, userRevoked)
+			assert.Equal(t, tt.wantSession, sessionRevoked)
+			// Revoking a user forgets it, the next token asks the auth service again
+			_, known := v.users.get("user-1")
+			assert.Equal(t, !tt.wantUser, known)
+		})
+	}
+}
+
+func TestUserCache(t *testing.T) {
+	t.Run("miss and hit", func(t *testing.T) {
+		c := newUserCache(2, time.Minute)
+		_, known := c.get("user-1")
+		assert.False(t, known)
+
+		c.set("user-1", true)
+		exists, known := c.get("user-1")
+		assert.True(t, known)
+		assert.True(t, exists)
+
+		c.delete("user-1")
+		_, known = c.get("user-1")
+		assert.False(t, known)
+	})
+
+	t.Run("expired entries are misses", func(t *testing.T) {
+		c := newUserCache(2, -time.Second)
+		c.set("user-1", true)
+		_, known := c.get("user-1")
+		assert.False(t, known)
+		assert.Empty(t, c.users)
+	})
+
+	t.Run("least recently used entry is dropped", func(t *testing.T) {
+		c := newUserCache(2, time.Minute)
+		c.set("user-1", true)
+		c.set("user-2", false)
+		c.get("user-1")
+		c.set("user-3", true)
+
+		_, known := c.get("user-2")
+		assert.False(t, known)
+		_, known = c.get("user-1")
+		assert.True(t, known)
+		exists, known := c.get("user-3")
+		assert.True(t, known)
+		assert.True(t, exists)
+	})
+}
@@ -8,11 +8,13 @@ import (
 	"os"
 
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	pb "github.com/NesterovYehor/textnest/services/auth_service/api"
 	"github.com/NesterovYehor/textnest/services/auth_service/config"
 	controllers "github.com/NesterovYehor/textnest/services/auth_service/internal/controlers"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/database"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/keys"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/mailer"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/services"
@@ -42,9 +44,34 @@ func main() {
 	model := models.New(db.Pool)
 
 	userSrv := services.NewUserService(model.User)
-	tokenSrv := services.NewTokenService(cfg.JwtConfig, model.Token)
+	var keyManager *keys.KeyManager
+	if cfg.JwtConfig.SigningKeyID != "" {
+		keyManager, err = keys.NewKeyManager(cfg.JwtConfig.SigningMethod, cfg.JwtConfig.SigningKeyID, cfg.JwtConfig.Keys)
+		if err != nil {
+			logger.PrintFatal(ctx, err, nil)
+			return
+		}
+	}
+	tokenSrv := services.NewTokenService(cfg.JwtConfig, model.Token, model.RefreshToken, keyManager)
+	var producer *kafka.KafkaProducer
+	if cfg.Kafka != nil {
+		producer, err = kafka.NewProducer(*cfg.Kafka, ctx)
+		if err != nil {
+			logger.PrintFatal(ctx, err, nil)
+			return
+		}
+		defer producer.Close()
+	}
+	revocationSrv := services.NewRevocationService(producer)
+	apiKeySrv := services.NewAPIKeyService(model.APIKey)
+	mfaSrv := services.NewMFAService(model.MFA, tokenSrv)
+	oidcSrv := services.NewOIDCService(cfg.OIDC, model.Identity)
+	loginGuard := services.NewLoginGuard(cfg.Login, model.LoginAttempt)
+	adminSrv := services.NewAdminService(model.Admin, model.User, tokenSrv, revocationSrv)
 	mailer := mailer.NewMailer(cfg)
-	controler := controllers.NewAuthController(logger, userSrv, tokenSrv, mailer)
+	accountSrv := services.NewAccountService(model.User, model.EmailChange)
+	sessionSrv := services.NewSessionService(model.Session)
+	controler := controllers.NewAuthController(logger, userSrv, tokenSrv, revocationSrv, apiKeySrv, mfaSrv, oidcSrv, loginGuard, adminSrv, accountSrv, sessionSrv, mailer)
 
 	server := grpc.NewGrpcServer(cfg.Grpc)
 	pb.RegisterAuthServiceServer(server.Grpc, controler)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +21,47 @@ type JwtConfig struct {
 	ActivateExpiry   time.Duration `mapstructure:"activate_expiry"`
 	SigningMethodStr string        `mapstructure:"signing_method"`
 	SigningMethod    jwt.SigningMethod
+	// Key pairs for RS* and ES* signing methods. SigningKeyID selects the key that signs access
+	// tokens, every listed key can verify them. Retired keys are kept with only a public key until
+	// the tokens they signed have expired.
+	SigningKeyID string      `mapstructure:"signing_key_id"`
+	Keys         []KeyConfig `mapstructure:"keys"`
+	// Challenge tokens of logins waiting for a second factor, the secret defaults to the refresh secret
+	MFASecret string        `mapstructure:"mfa_secret"`
+	MFAExpiry time.Duration `mapstructure:"mfa_expiry"`
+}
+
+// KeyConfig points to the PEM files of a signing key. The public key is derived from the
+// private key when both are given.
+type KeyConfig struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyPath string `mapstructure:"private_key"`
+	PublicKeyPath  string `mapstructure:"public_key"`
+}
+
+// OIDCProviderConfig is an external identity provider users can sign in with. RedirectURL is
+// the callback route of the API gateway registered with the provider.
+type OIDCProviderConfig struct {
+	Name         string   `mapstructure:"name"`
+	Issuer       string   `mapstructure:"issuer"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"` // openid, email and profile are always requested
+}
+
+// LoginProtectionConfig throttles password guessing. Failed logins of an account or an IP are
+// delayed exponentially once the free attempts are used up, and blocked for LockoutDuration
+// when they reach the lockout threshold. Failures older than Window are forgotten.
+type LoginProtectionConfig struct {
+	FreeAttempts       int           `mapstructure:"free_attempts"`
+	IPFreeAttempts     int           `mapstructure:"ip_free_attempts"`
+	LockoutThreshold   int           `mapstructure:"lockout_threshold"`
+	IPLockoutThreshold int           `mapstructure:"ip_lockout_threshold"`
+	BaseDelay          time.Duration `mapstructure:"base_delay"`
+	MaxDelay           time.Duration `mapstructure:"max_delay"`
+	LockoutDuration    time.Duration `mapstructure:"lockout_duration"`
+	Window             time.Duration `mapstructure:"window"`
 }
 
 type MailerConfig struct {
@@ -43,6 +85,9 @@ type Config struct {
 	JwtConfig *JwtConfig                       `mapstructure:"jwt"`
 	Mailer    *MailerConfig                    `mapstructure:"mailer"`
 	CBConfig  *middleware.CircuitBreakerConfig `mapstructure:"circuit_breacker"`
+	Kafka     *kafka.KafkaConfig               `mapstructure:"kafka"` // Optional, publishes user revocations and deletions to the other services
+	OIDC      []OIDCProviderConfig             `mapstructure:"oidc"`  // Optional, identity providers for single sign-on
+	Login     *LoginProtectionConfig           `mapstructure:"login_protection"`
 }
 
 func LoadConfig(log *jsonlog.Logger) (*Config, error) {
@@ -81,10 +126,62 @@ func LoadConfig(log *jsonlog.Logger) (*Config, error) {
 		return nil, err
 	}
 	config.JwtConfig.SigningMethod = signingMethod
+	if _, ok := signingMethod.(*jwt.SigningMethodHMAC); !ok && config.JwtConfig.SigningKeyID == "" {
+		return nil, errors.New("signing_key_id is required for asymmetric signing methods")
+	}
+	if config.JwtConfig.MFASecret == "" {
+		config.JwtConfig.MFASecret = config.JwtConfig.RefreshSecret
+	}
+	if config.JwtConfig.MFAExpiry == 0 {
+		config.JwtConfig.MFAExpiry = time.Minute * 5 // Set default
+	}
+
+	if config.Login == nil {
+		config.Login = &LoginProtectionConfig{}
+	}
+	setLoginProtectionDefaults(config.Login)
+
+	providers := make(map[string]bool, len(config.OIDC))
+	for _, provider := range config.OIDC {
+		if provider.Name == "" || provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			return nil, errors.New("oidc providers need a name, issuer, client_id and redirect_url")
+		}
+		if providers[provider.Name] {
+			return nil, errors.New("duplicate oidc provider: " + provider.Name)
+		}
+		providers[provider.Name] = true
+	}
 
 	return &config, nil
 }
 
+func setLoginProtectionDefaults(cfg *LoginProtectionConfig) {
+	if cfg.FreeAttempts == 0 {
+		cfg.FreeAttempts = 3
+	}
+	if cfg.IPFreeAttempts == 0 {
+		cfg.IPFreeAttempts = 20
+	}
+	if cfg.LockoutThreshold == 0 {
+		cfg.LockoutThreshold = 10
+	}
+	if cfg.IPLockoutThreshold == 0 {
+		cfg.IPLockoutThreshold = 100
+	}
+	if cfg.BaseDelay == 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay == 0 {
+		cfg.MaxDelay = time.Minute * 5
+	}
+	if cfg.LockoutDuration == 0 {
+		cfg.LockoutDuration = time.Minute * 30
+	}
+	if cfg.Window == 0 {
+		cfg.Window = time.Hour
+	}
+}
+
 // Helper function to convert string to the appropriate jwt.SigningMethod
 func getSigningMethod(algorithm string) (jwt.SigningMethod, error) {
 	if algorithm == "" {
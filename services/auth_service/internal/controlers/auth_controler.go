@@ -2,13 +2,19 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	auth "github.com/NesterovYehor/textnest/services/auth_service/api"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/mailer"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/services"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -19,19 +25,35 @@ const (
 )
 
 type AuthController struct {
-	userSrv  *services.UserService
-	tokenSrv *services.TokenService
-	log      *jsonlog.Logger
-	mailer   *mailer.Mailer
+	userSrv       *services.UserService
+	tokenSrv      *services.TokenService
+	revocationSrv *services.RevocationService
+	apiKeySrv     *services.APIKeyService
+	mfaSrv        *services.MFAService
+	oidcSrv       *services.OIDCService
+	loginGuard    *services.LoginGuard
+	adminSrv      *services.AdminService
+	accountSrv    *services.AccountService
+	sessionSrv    *services.SessionService
+	log           *jsonlog.Logger
+	mailer        *mailer.Mailer
 	auth.UnimplementedAuthServiceServer
 }
 
-func NewAuthController(log *jsonlog.Logger, userService *services.UserService, tokenSrv *services.TokenService, mailer *mailer.Mailer) *AuthController {
+func NewAuthController(log *jsonlog.Logger, userService *services.UserService, tokenSrv *services.TokenService, revocationSrv *services.RevocationService, apiKeySrv *services.APIKeyService, mfaSrv *services.MFAService, oidcSrv *services.OIDCService, loginGuard *services.LoginGuard, adminSrv *services.AdminService, accountSrv *services.AccountService, sessionSrv *services.SessionService, mailer *mailer.Mailer) *AuthController {
 	return &AuthController{
-		log:      log,
-		userSrv:  userService,
-		tokenSrv: tokenSrv,
-		mailer:   mailer,
+		log:           log,
+		userSrv:       userService,
+		tokenSrv:      tokenSrv,
+		revocationSrv: revocationSrv,
+		apiKeySrv:     apiKeySrv,
+		mfaSrv:        mfaSrv,
+		oidcSrv:       oidcSrv,
+		loginGuard:    loginGuard,
+		adminSrv:      adminSrv,
+		accountSrv:    accountSrv,
+		sessionSrv:    sessionSrv,
+		mailer:        mailer,
 	}
 }
 
@@ -63,70 +85,212 @@ func (ctr *AuthController) ActivateUser(ctx context.Context, req *auth.ActivateU
 }
 
 func (ctr *AuthController) AuthenticateUser(ctx context.Context, req *auth.AuthenticateUserRequest) (*auth.AuthenticateUserResponse, error) {
-	userId, err := ctr.userSrv.AuthenticateUserByEmail(req.Email, req.Password)
+	wait, err := ctr.loginGuard.Check(req.Email, req.Ip)
 	if err != nil {
 		ctr.log.PrintError(ctx, err, nil)
+		return nil, loginThrottledError(err, wait)
+	}
+
+	userId, mfaEnabled, err := ctr.userSrv.AuthenticateUserByEmail(req.Email, req.Password)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		lockedUntil, err := ctr.loginGuard.Failed(req.Email, req.Ip)
+		if err != nil {
+			ctr.log.PrintError(ctx, err, nil)
+		}
+		if !lockedUntil.IsZero() {
+			go func() {
+				if err := ctr.mailer.Send(req.Email, "account_locked.tmpl", map[string]any{
+					"lockedUntil": lockedUntil.UTC().Format(time.RFC1123),
+				}); err != nil {
+					ctr.log.PrintError(ctx, err, nil)
+				}
+			}()
+		}
 		return nil, status.Error(codes.Unauthenticated, "Authentication failed. Check your credentials and try again.")
 	}
+	if err := ctr.loginGuard.Succeeded(userId, req.Email, req.Ip); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	return ctr.completeLogin(ctx, userId, mfaEnabled, req.Ip, req.UserAgent)
+}
 
-	accessToken, expiresAt, err := ctr.tokenSrv.GenerateJWTToken(userId, accessType)
+// loginThrottledError tells the client when to try again in a RetryInfo detail.
+func loginThrottledError(err error, wait time.Duration) error {
+	var st *status.Status
+	switch {
+	case errors.Is(err, services.ErrAccountLocked):
+		st = status.New(codes.ResourceExhausted, "Account is temporarily locked after too many failed logins. Try again later or reset your password.")
+	case errors.Is(err, services.ErrLoginThrottled):
+		st = status.New(codes.ResourceExhausted, "Too many failed logins. Try again later.")
+	default:
+		return status.Error(codes.Internal, "Authentication failed. Please try again.")
+	}
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait.Round(time.Second))})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// completeLogin issues the tokens of a user whose first factor was checked, or a challenge for
+// the second factor when the user has one.
+func (ctr *AuthController) completeLogin(ctx context.Context, userId string, mfaEnabled bool, ip, userAgent string) (*auth.AuthenticateUserResponse, error) {
+	// The tokens are only issued by VerifyMFA once the second factor is checked
+	if mfaEnabled {
+		mfaToken, mfaExpiresAt, err := ctr.mfaSrv.StartChallenge(userId)
+		if err != nil {
+			ctr.log.PrintError(ctx, err, nil)
+			return nil, status.Error(codes.Internal, "Failed to start two-factor authentication. Please try again.")
+		}
+		return &auth.AuthenticateUserResponse{
+			MfaRequired:  true,
+			MfaToken:     mfaToken,
+			MfaExpiresAt: timestamppb.New(mfaExpiresAt),
+		}, nil
+	}
+
+	session, err := ctr.issueSession(ctx, userId, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.AuthenticateUserResponse{
+		AccessToken:      session.accessToken,
+		RefreshToken:     session.refreshToken,
+		ExpiresIn:        timestamppb.New(session.expiresAt),
+		RefreshExpiresAt: timestamppb.New(session.refreshExpiresAt),
+	}, nil
+}
+
+// VerifyMFA completes a login that requires a second factor.
+func (ctr *AuthController) VerifyMFA(ctx context.Context, req *auth.VerifyMFARequest) (*auth.VerifyMFAResponse, error) {
+	userId, err := ctr.mfaSrv.CompleteChallenge(req.MfaToken, req.Code)
 	if err != nil {
 		ctr.log.PrintError(ctx, err, nil)
-		return nil, status.Error(codes.Internal, "Failed to generate access token: %v.")
+		return nil, mfaError(err)
 	}
 
-	refreshToken, refreshExpiresAt, err := ctr.tokenSrv.GenerateJWTToken(userId, refreshType)
+	session, err := ctr.issueSession(ctx, userId, req.Ip, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.VerifyMFAResponse{
+		AccessToken:      session.accessToken,
+		RefreshToken:     session.refreshToken,
+		ExpiresIn:        timestamppb.New(session.expiresAt),
+		RefreshExpiresAt: timestamppb.New(session.refreshExpiresAt),
+	}, nil
+}
+
+// session is the token pair of a completed login.
+type session struct {
+	accessToken      string
+	refreshToken     string
+	expiresAt        time.Time
+	refreshExpiresAt time.Time
+}
+
+// issueSession starts a session for the device the login came from and issues its tokens.
+func (ctr *AuthController) issueSession(ctx context.Context, userId, ip, userAgent string) (*session, error) {
+	sessionID, err := ctr.sessionSrv.Start(userId, ip, userAgent)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to start the session. Please try again.")
+	}
+
+	accessToken, expiresAt, err := ctr.accessToken(ctx, userId, sessionID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := ctr.tokenSrv.IssueRefreshToken(userId, sessionID)
 	if err != nil {
 		ctr.log.PrintError(ctx, err, nil)
 		return nil, status.Error(codes.Internal, "Failed to generate refresh token: %v")
 	}
 
-	return &auth.AuthenticateUserResponse{
-		AccessToken:      accessToken,
-		RefreshToken:     refreshToken,
-		ExpiresIn:        timestamppb.New(expiresAt),
-		RefreshExpiresAt: timestamppb.New(refreshExpiresAt),
+	return &session{
+		accessToken:      accessToken,
+		refreshToken:     refreshToken,
+		expiresAt:        expiresAt,
+		refreshExpiresAt: refreshExpiresAt,
 	}, nil
 }
 
+// accessToken signs an access token with the current role of the user. Users that were
+// deactivated or banned since the session started do not get one.
+func (ctr *AuthController) accessToken(ctx context.Context, userId, sessionId string) (string, time.Time, error) {
+	role, active, err := ctr.userSrv.GetAccess(userId)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return "", time.Time{}, status.Error(codes.Internal, "Failed to generate access token. Please try again.")
+	}
+	if !active {
+		return "", time.Time{}, status.Error(codes.PermissionDenied, "Account is deactivated or banned.")
+	}
+	accessToken, expiresAt, err := ctr.tokenSrv.GenerateAccessToken(userId, role, sessionId)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return "", time.Time{}, status.Error(codes.Internal, "Failed to generate access token. Please try again.")
+	}
+	return accessToken, expiresAt, nil
+}
+
 func (ctr *AuthController) AuthorizeUser(ctx context.Context, req *auth.AuthorizeUserRequest) (*auth.AuthorizeUserResponse, error) {
-	userId, err := ctr.tokenSrv.ExtractUserID(req.Tocken, accessType)
+	userId, sessionId, err := ctr.tokenSrv.ParseAccessToken(req.Tocken)
 	if err != nil {
 		ctr.log.PrintError(ctx, err, nil)
 		return nil, status.Error(status.Code(err), "Failed to extract user ID from the token. Please check the token and try again.")
 	}
 
-	exist, err := ctr.userSrv.UserExists(userId)
-	if err != nil {
+	// Tokens issued before sessions were tracked carry none and live until they expire
+	if sessionId != "" {
+		active, err := ctr.sessionSrv.IsActive(sessionId)
+		if err != nil && !errors.Is(err, models.ErrInvalidUUID) {
+			ctr.log.PrintError(ctx, err, nil)
+			return nil, status.Error(codes.Internal, "Failed to check the session. Please try again.")
+		}
+		if !active {
+			return nil, status.Error(codes.Unauthenticated, "Session was logged out or revoked. Please log in again.")
+		}
+	}
+
+	// Deactivated and banned users are reported like deleted ones, their tokens must not be accepted
+	role, active, err := ctr.userSrv.GetAccess(userId)
+	if err != nil && !errors.Is(err, models.ErrRecordNotFound) {
 		ctr.log.PrintError(ctx, err, nil)
 		return nil, status.Error(codes.Internal, "Failed to check user existence. Please try again.")
 	}
-	if !exist {
+	if !active {
 		return nil, status.Error(codes.NotFound, "User with this ID does not exist.")
 	}
 
 	return &auth.AuthorizeUserResponse{
 		UserId: userId,
+		Role:   role,
 	}, nil
 }
 
+// RefreshTokens rotates the refresh token, the presented token cannot be used again.
 func (ctr *AuthController) RefreshTokens(ctx context.Context, req *auth.RefreshTokensRequest) (*auth.RefreshTokensResponse, error) {
-	userId, err := ctr.tokenSrv.ExtractUserID(req.Tocken, refreshType)
+	next, refreshToken, refreshExpiresAt, err := ctr.tokenSrv.RotateRefreshToken(req.Tocken)
 	if err != nil {
 		ctr.log.PrintError(ctx, err, nil)
-		return nil, status.Error(codes.NotFound, "User related to this token was not found. Please ensure the token is valid.")
+		// The token leaked, access tokens of the session must stop working at the gateways as well
+		if errors.Is(err, models.ErrTokenReused) {
+			if err := ctr.revocationSrv.RevokeSession(next.UserID.String(), next.FamilyID.String()); err != nil {
+				ctr.log.PrintError(ctx, err, nil)
+			}
+		}
+		return nil, refreshTokenError(err)
 	}
-
-	accessToken, expiresAt, err := ctr.tokenSrv.GenerateJWTToken(userId, accessType)
-	if err != nil {
+	if err := ctr.sessionSrv.Touch(next.FamilyID, req.Ip, req.UserAgent); err != nil {
 		ctr.log.PrintError(ctx, err, nil)
-		return nil, status.Error(codes.Internal, "Failed to generate access token. Please try again.")
 	}
 
-	refreshToken, refreshExpiresAt, err := ctr.tokenSrv.GenerateJWTToken(userId, refreshType)
+	accessToken, expiresAt, err := ctr.accessToken(ctx, next.UserID.String(), next.FamilyID.String())
 	if err != nil {
-		ctr.log.PrintError(ctx, err, nil)
-		return nil, status.Error(codes.Internal, "Failed to generate refresh token. Please try again.")
+		return nil, err
 	}
 
 	return &auth.RefreshTokensResponse{
@@ -173,5 +337,537 @@ func (ctr *AuthController) ResetPassword(ctx context.Context, req *auth.ResetPas
 			ctr.log.PrintError(ctx, err, nil)
 		}
 	}()
+	// Signed in sessions must not survive a password reset
+	if err := ctr.tokenSrv.RevokeAllRefreshTokens(*userID); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Password is renewed, but signing out existing sessions failed")
+	}
+	if err := ctr.revocationSrv.RevokeUser(userID.String()); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	// The new password ends a lockout, whoever was guessing the old one does not know it
+	if err := ctr.loginGuard.Unlock(*userID); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
 	return &auth.ResetPasswordResponse{Message: "Password is renewed"}, nil
 }
+
+// Logout revokes the refresh token family of the session and tells the API gateways to reject
+// the access tokens issued for it.
+func (ctr *AuthController) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+	userId, sessionId, err := ctr.tokenSrv.RevokeRefreshToken(req.RefreshToken)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, refreshTokenError(err)
+	}
+	if err := ctr.revocationSrv.RevokeSession(userId, sessionId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	return &auth.LogoutResponse{Message: "Logged out"}, nil
+}
+
+// LogoutAll revokes every refresh token family of the user and tells the API gateways to
+// reject the access tokens issued so far.
+func (ctr *AuthController) LogoutAll(ctx context.Context, req *auth.LogoutAllRequest) (*auth.LogoutAllResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	if err := ctr.tokenSrv.RevokeAllRefreshTokens(userID); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to log out of all sessions. Please try again.")
+	}
+	if err := ctr.revocationSrv.RevokeUser(req.UserId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to revoke access tokens. Please try again.")
+	}
+	return &auth.LogoutAllResponse{Message: "Logged out of all sessions"}, nil
+}
+
+// GetJwks publishes the public keys access tokens are signed with, so they can be verified without the auth service.
+func (ctr *AuthController) GetJwks(ctx context.Context, req *auth.GetJwksRequest) (*auth.GetJwksResponse, error) {
+	jwks := ctr.tokenSrv.JWKS()
+	res := &auth.GetJwksResponse{Keys: make([]*auth.JsonWebKey, 0, len(jwks))}
+	for _, k := range jwks {
+		res.Keys = append(res.Keys, &auth.JsonWebKey{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+			Y:   k.Y,
+		})
+	}
+	return res, nil
+}
+
+// CreateAPIKey issues a personal access token, the token is only part of this response.
+func (ctr *AuthController) CreateAPIKey(ctx context.Context, req *auth.CreateAPIKeyRequest) (*auth.CreateAPIKeyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	var expiry time.Time
+	if req.ExpiresAt != nil {
+		expiry = req.ExpiresAt.AsTime()
+	}
+	token, key, err := ctr.apiKeySrv.CreateAPIKey(userID, req.Name, req.Scopes, expiry)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		if errors.Is(err, services.ErrAPIKeyValidation) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "Failed to create API key. Please try again.")
+	}
+	return &auth.CreateAPIKeyResponse{Token: token, Key: apiKeyToProto(key)}, nil
+}
+
+func (ctr *AuthController) ListAPIKeys(ctx context.Context, req *auth.ListAPIKeysRequest) (*auth.ListAPIKeysResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	keys, err := ctr.apiKeySrv.ListAPIKeys(userID)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to list API keys. Please try again.")
+	}
+	res := &auth.ListAPIKeysResponse{Keys: make([]*auth.APIKey, 0, len(keys))}
+	for _, key := range keys {
+		res.Keys = append(res.Keys, apiKeyToProto(key))
+	}
+	return res, nil
+}
+
+func (ctr *AuthController) RevokeAPIKey(ctx context.Context, req *auth.RevokeAPIKeyRequest) (*auth.RevokeAPIKeyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "API key ID is invalid.")
+	}
+	if err := ctr.apiKeySrv.RevokeAPIKey(id, userID); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		if errors.Is(err, models.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "API key does not exist.")
+		}
+		return nil, status.Error(codes.Internal, "Failed to revoke API key. Please try again.")
+	}
+	return &auth.RevokeAPIKeyResponse{Message: "API key revoked"}, nil
+}
+
+// AuthorizeAPIKey returns the owner and scopes of a personal access token.
+func (ctr *AuthController) AuthorizeAPIKey(ctx context.Context, req *auth.AuthorizeAPIKeyRequest) (*auth.AuthorizeAPIKeyResponse, error) {
+	key, err := ctr.apiKeySrv.AuthenticateAPIKey(req.Token)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			return nil, status.Error(codes.Unauthenticated, "API key is invalid, revoked or expired.")
+		}
+		return nil, status.Error(codes.Internal, "Failed to check API key. Please try again.")
+	}
+	return &auth.AuthorizeAPIKeyResponse{UserId: key.UserID.String(), Scopes: key.Scopes}, nil
+}
+
+// EnrollTOTP starts setting up an authenticator app, it is enforced once ConfirmTOTP succeeds.
+func (ctr *AuthController) EnrollTOTP(ctx context.Context, req *auth.EnrollTOTPRequest) (*auth.EnrollTOTPResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	secret, uri, err := ctr.mfaSrv.EnrollTOTP(userID)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, mfaError(err)
+	}
+	return &auth.EnrollTOTPResponse{Secret: secret, ProvisioningUri: uri}, nil
+}
+
+func (ctr *AuthController) ConfirmTOTP(ctx context.Context, req *auth.ConfirmTOTPRequest) (*auth.ConfirmTOTPResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	recoveryCodes, err := ctr.mfaSrv.ConfirmTOTP(userID, req.Code)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, mfaError(err)
+	}
+	return &auth.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (ctr *AuthController) DisableTOTP(ctx context.Context, req *auth.DisableTOTPRequest) (*auth.DisableTOTPResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	if err := ctr.mfaSrv.DisableTOTP(userID, req.Code); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, mfaError(err)
+	}
+	return &auth.DisableTOTPResponse{Message: "Two-factor authentication disabled"}, nil
+}
+
+// StartOIDCLogin returns the URL of the identity provider the user signs in at.
+func (ctr *AuthController) StartOIDCLogin(ctx context.Context, req *auth.StartOIDCLoginRequest) (*auth.StartOIDCLoginResponse, error) {
+	authURL, state, err := ctr.oidcSrv.StartLogin(ctx, req.Provider)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, oidcError(err)
+	}
+	return &auth.StartOIDCLoginResponse{AuthorizationUrl: authURL, State: state}, nil
+}
+
+// CompleteOIDCLogin handles the callback of an identity provider. It logs in the user linked to
+// the account like AuthenticateUser, including the second factor.
+func (ctr *AuthController) CompleteOIDCLogin(ctx context.Context, req *auth.CompleteOIDCLoginRequest) (*auth.AuthenticateUserResponse, error) {
+	userId, err := ctr.oidcSrv.CompleteLogin(ctx, req.Provider, req.State, req.Code)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, oidcError(err)
+	}
+	mfaEnabled, err := ctr.mfaSrv.Required(userId)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to log in. Please try again.")
+	}
+	return ctr.completeLogin(ctx, userId, mfaEnabled, req.Ip, req.UserAgent)
+}
+
+func oidcError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrUnknownProvider):
+		return status.Error(codes.NotFound, "Identity provider is not configured.")
+	case errors.Is(err, services.ErrInvalidOIDCLogin):
+		return status.Error(codes.Unauthenticated, "Single sign-on failed or expired. Please try again.")
+	case errors.Is(err, services.ErrUnverifiedEmail):
+		return status.Error(codes.PermissionDenied, "The identity provider has not verified your email.")
+	default:
+		return status.Error(codes.Internal, "Single sign-on failed. Please try again.")
+	}
+}
+
+// ListUsers returns the accounts for admins.
+func (ctr *AuthController) ListUsers(ctx context.Context, req *auth.ListUsersRequest) (*auth.ListUsersResponse, error) {
+	users, err := ctr.adminSrv.ListUsers(req.ActorId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, adminError(err)
+	}
+	res := &auth.ListUsersResponse{Users: make([]*auth.AdminUser, 0, len(users))}
+	for _, user := range users {
+		adminUser := &auth.AdminUser{
+			Id:         user.ID.String(),
+			Name:       user.Name,
+			Email:      user.Email,
+			Role:       user.Role,
+			Activated:  user.Activated,
+			MfaEnabled: user.MFAEnabled,
+			CreatedAt:  timestamppb.New(user.CreatedAt),
+		}
+		if user.BannedAt != nil {
+			adminUser.BannedAt = timestamppb.New(*user.BannedAt)
+		}
+		res.Users = append(res.Users, adminUser)
+	}
+	return res, nil
+}
+
+// SetUserStatus activates, deactivates or bans an account.
+func (ctr *AuthController) SetUserStatus(ctx context.Context, req *auth.SetUserStatusRequest) (*auth.SetUserStatusResponse, error) {
+	if err := ctr.adminSrv.SetUserStatus(req.ActorId, req.UserId, req.Status, req.Reason); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, adminError(err)
+	}
+	return &auth.SetUserStatusResponse{Message: "User status is set to " + req.Status}, nil
+}
+
+// SetUserRole grants or takes away the moderator and admin roles.
+func (ctr *AuthController) SetUserRole(ctx context.Context, req *auth.SetUserRoleRequest) (*auth.SetUserRoleResponse, error) {
+	if err := ctr.adminSrv.SetUserRole(req.ActorId, req.UserId, req.Role); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, adminError(err)
+	}
+	return &auth.SetUserRoleResponse{Message: "User role is set to " + req.Role}, nil
+}
+
+// RecordAdminAction writes an action taken in another service to the audit log.
+func (ctr *AuthController) RecordAdminAction(ctx context.Context, req *auth.RecordAdminActionRequest) (*auth.RecordAdminActionResponse, error) {
+	if req.Action == "" || req.Target == "" {
+		return nil, status.Error(codes.InvalidArgument, "Action and target are required.")
+	}
+	if err := ctr.adminSrv.RecordAction(req.ActorId, req.Action, req.Target, req.Details); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, adminError(err)
+	}
+	return &auth.RecordAdminActionResponse{}, nil
+}
+
+// ListAuditLog returns the actions of moderators and admins, newest first.
+func (ctr *AuthController) ListAuditLog(ctx context.Context, req *auth.ListAuditLogRequest) (*auth.ListAuditLogResponse, error) {
+	entries, err := ctr.adminSrv.ListAuditLog(req.ActorId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, adminError(err)
+	}
+	res := &auth.ListAuditLogResponse{Entries: make([]*auth.AuditEntry, 0, len(entries))}
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, &auth.AuditEntry{
+			Id:        entry.ID,
+			ActorId:   entry.ActorID.String(),
+			Action:    entry.Action,
+			Target:    entry.Target,
+			Details:   entry.Details,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		})
+	}
+	return res, nil
+}
+
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "You are not allowed to take this action.")
+	case errors.Is(err, services.ErrSelfModeration):
+		return status.Error(codes.FailedPrecondition, "You cannot change your own account.")
+	case errors.Is(err, services.ErrInvalidStatus):
+		return status.Error(codes.InvalidArgument, "Status must be active, deactivated or banned.")
+	case errors.Is(err, services.ErrInvalidRole):
+		return status.Error(codes.InvalidArgument, "Role must be user, moderator or admin.")
+	case errors.Is(err, models.ErrInvalidUUID):
+		return status.Error(codes.InvalidArgument, "User ID is invalid.")
+	case errors.Is(err, models.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "User with this ID does not exist.")
+	default:
+		return status.Error(codes.Internal, "Failed to complete the admin action. Please try again.")
+	}
+}
+
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidMFAChallenge):
+		return status.Error(codes.Unauthenticated, "Two-factor challenge is invalid or expired. Please log in again.")
+	case errors.Is(err, services.ErrInvalidMFACode):
+		return status.Error(codes.Unauthenticated, "Two-factor code is invalid.")
+	case errors.Is(err, models.ErrMFAEnabled):
+		return status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled.")
+	case errors.Is(err, services.ErrMFANotEnrolled), errors.Is(err, services.ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, "Two-factor authentication is not set up.")
+	case errors.Is(err, models.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "User with this ID does not exist.")
+	default:
+		return status.Error(codes.Internal, "Two-factor authentication failed. Please try again.")
+	}
+}
+
+func apiKeyToProto(key *models.APIKey) *auth.APIKey {
+	res := &auth.APIKey{
+		Id:        key.ID.String(),
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		ExpiresAt: timestamppb.New(key.Expiry),
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if key.LastUsedAt != nil {
+		res.LastUsedAt = timestamppb.New(*key.LastUsedAt)
+	}
+	return res
+}
+
+func refreshTokenError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrTokenReused):
+		return status.Error(codes.Unauthenticated, "Refresh token was already used. All sessions started with this login were revoked.")
+	case errors.Is(err, models.ErrTokenRevoked), errors.Is(err, models.ErrRecordNotFound):
+		return status.Error(codes.Unauthenticated, "Refresh token is revoked or expired. Please log in again.")
+	case errors.Is(err, services.ErrInvalidRefreshToken):
+		return status.Error(codes.Unauthenticated, "Refresh token is invalid. Please log in again.")
+	default:
+		return status.Error(codes.Internal, "Failed to process the refresh token. Please try again.")
+	}
+}
+
+func (ctr *AuthController) GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.Profile, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	user, err := ctr.accountSrv.GetProfile(userID)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, accountError(err)
+	}
+	return profileToProto(user), nil
+}
+
+func (ctr *AuthController) UpdateProfile(ctx context.Context, req *auth.UpdateProfileRequest) (*auth.Profile, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	user, err := ctr.accountSrv.UpdateProfile(userID, req.Name)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, accountError(err)
+	}
+	return profileToProto(user), nil
+}
+
+// ChangePassword sets a new password and signs out every session, like a password reset.
+func (ctr *AuthController) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	if err := ctr.accountSrv.ChangePassword(userID, req.CurrentPassword, req.NewPassword); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, accountError(err)
+	}
+	if err := ctr.tokenSrv.RevokeAllRefreshTokens(userID); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Password is changed, but signing out existing sessions failed")
+	}
+	if err := ctr.revocationSrv.RevokeUser(req.UserId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	return &auth.ChangePasswordResponse{Message: "Password is changed, please log in again"}, nil
+}
+
+// ChangeEmail mails a confirmation link to the new address, the email of the account is only
+// changed by ConfirmEmailChange.
+func (ctr *AuthController) ChangeEmail(ctx context.Context, req *auth.ChangeEmailRequest) (*auth.ChangeEmailResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	token, err := ctr.accountSrv.RequestEmailChange(userID, req.Password, req.NewEmail)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, accountError(err)
+	}
+	go func() {
+		if err := ctr.mailer.Send(req.NewEmail, "email_change.tmpl", map[string]any{
+			"emailChangeToken": token,
+		}); err != nil {
+			ctr.log.PrintError(ctx, err, nil)
+		}
+	}()
+	return &auth.ChangeEmailResponse{Message: "Email with a confirmation link was sent to the new address"}, nil
+}
+
+func (ctr *AuthController) ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error) {
+	change, err := ctr.accountSrv.ConfirmEmailChange(req.Token)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		if errors.Is(err, models.ErrRecordNotFound) {
+			return nil, status.Error(codes.InvalidArgument, "Email change token is invalid or expired.")
+		}
+		return nil, accountError(err)
+	}
+	// The old address learns about the change, in case the account was taken over
+	go func() {
+		if err := ctr.mailer.Send(change.OldEmail, "email_changed.tmpl", map[string]any{
+			"newEmail": change.NewEmail,
+		}); err != nil {
+			ctr.log.PrintError(ctx, err, nil)
+		}
+	}()
+	return &auth.ConfirmEmailChangeResponse{Message: "Email is changed to " + change.NewEmail}, nil
+}
+
+// DeleteAccount removes the user, ends every session and announces the deletion so the other
+// services purge the data of the user.
+func (ctr *AuthController) DeleteAccount(ctx context.Context, req *auth.DeleteAccountRequest) (*auth.DeleteAccountResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	if err := ctr.accountSrv.DeleteAccount(userID, req.Password); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, accountError(err)
+	}
+	if err := ctr.revocationSrv.DeleteUser(req.UserId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	return &auth.DeleteAccountResponse{Message: "Account is deleted"}, nil
+}
+
+func profileToProto(user *models.User) *auth.Profile {
+	return &auth.Profile{
+		Id:         user.ID.String(),
+		Name:       user.Name,
+		Email:      user.Email,
+		Role:       user.Role,
+		MfaEnabled: user.MFAEnabled,
+		CreatedAt:  timestamppb.New(user.CreatedAt),
+	}
+}
+
+func accountError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrIncorrectPassword):
+		return status.Error(codes.PermissionDenied, "Password is incorrect.")
+	case errors.Is(err, services.ErrInvalidAccountChange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrSameEmail):
+		return status.Error(codes.InvalidArgument, "This is already the email of your account.")
+	case errors.Is(err, models.ErrDuplicateEmail):
+		return status.Error(codes.AlreadyExists, "Email is already in use.")
+	case errors.Is(err, models.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "User with this ID does not exist.")
+	default:
+		return status.Error(codes.Internal, "Failed to update the account. Please try again.")
+	}
+}
+
+func (ctr *AuthController) ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	sessions, err := ctr.sessionSrv.List(userID)
+	if err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		return nil, status.Error(codes.Internal, "Failed to list sessions. Please try again.")
+	}
+	res := &auth.ListSessionsResponse{Sessions: make([]*auth.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, &auth.Session{
+			Id:         session.ID.String(),
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+		})
+	}
+	return res, nil
+}
+
+// RevokeSession signs out a single device, its refresh token stops working and the API gateways
+// reject its access tokens.
+func (ctr *AuthController) RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "User ID is invalid.")
+	}
+	if err := ctr.sessionSrv.Revoke(userID, req.SessionId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+		switch {
+		case errors.Is(err, models.ErrInvalidUUID):
+			return nil, status.Error(codes.InvalidArgument, "Session ID is invalid.")
+		case errors.Is(err, models.ErrRecordNotFound):
+			return nil, status.Error(codes.NotFound, "Session does not exist or already ended.")
+		default:
+			return nil, status.Error(codes.Internal, "Failed to revoke the session. Please try again.")
+		}
+	}
+	if err := ctr.revocationSrv.RevokeSession(req.UserId, req.SessionId); err != nil {
+		ctr.log.PrintError(ctx, err, nil)
+	}
+	return &auth.RevokeSessionResponse{Message: "Session revoked"}, nil
+}
@@ -0,0 +1,193 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnknownKey is returned for tokens signed by a key the manager does not know.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type key struct {
+	id      string
+	public  crypto.PublicKey
+	private crypto.PrivateKey
+}
+
+// KeyManager signs access tokens with the active key pair and verifies them with any of the
+// configured keys. Every token carries the ID of its key in the kid header, so the signing key
+// can be rotated while tokens of the previous key are still valid.
+type KeyManager struct {
+	method  jwt.SigningMethod
+	signing *key
+	keys    map[string]*key
+}
+
+// NewKeyManager loads the configured PEM key pairs. The signing key must have a private key,
+// all keys must match the signing method.
+func NewKeyManager(method jwt.SigningMethod, signingKeyID string, cfgs []config.KeyConfig) (*KeyManager, error) {
+	manager := &KeyManager{
+		method: method,
+		keys:   make(map[string]*key, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		if cfg.ID == "" {
+			return nil, errors.New("key id is required")
+		}
+		if _, ok := manager.keys[cfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %s", cfg.ID)
+		}
+		k, err := loadKey(method, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", cfg.ID, err)
+		}
+		manager.keys[cfg.ID] = k
+	}
+
+	signing, ok := manager.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not configured", signingKeyID)
+	}
+	if signing.private == nil {
+		return nil, fmt.Errorf("signing key %s has no private key", signingKeyID)
+	}
+	manager.signing = signing
+	return manager, nil
+}
+
+// Sign signs the claims with the active key and stamps its ID in the kid header.
+func (m *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(m.method, claims)
+	token.Header["kid"] = m.signing.id
+	return token.SignedString(m.signing.private)
+}
+
+// Keyfunc resolves the verification key of a token from its kid header.
+func (m *KeyManager) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	k, ok := m.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	return k.public, nil
+}
+
+// Method returns the name of the signing algorithm, e.g. RS256.
+func (m *KeyManager) Method() string {
+	return m.method.Alg()
+}
+
+// JSONWebKey is the public part of a key in the JWK format of RFC 7517.
+type JSONWebKey struct {
+	Kty string
+	Kid string
+	Use string
+	Alg string
+	N   string // RSA modulus
+	E   string // RSA exponent
+	Crv string // EC curve
+	X   string // EC point
+	Y   string
+}
+
+// JWKS returns the public keys of every configured key, tokens can be verified with any of them.
+func (m *KeyManager) JWKS() []JSONWebKey {
+	jwks := make([]JSONWebKey, 0, len(m.keys))
+	for _, k := range m.keys {
+		jwk := JSONWebKey{Kid: k.id, Use: "sig", Alg: m.method.Alg()}
+		switch public := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+		}
+		jwks = append(jwks, jwk)
+	}
+	sort.Slice(jwks, func(i, j int) bool { return jwks[i].Kid < jwks[j].Kid })
+	return jwks
+}
+
+func loadKey(method jwt.SigningMethod, cfg config.KeyConfig) (*key, error) {
+	k := &key{id: cfg.ID}
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		if cfg.PrivateKeyPath != "" {
+			pem, err := os.ReadFile(cfg.PrivateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			k.private, k.public = private, &private.PublicKey
+			return k, nil
+		}
+		pem, err := readPublicKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+		k.public, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		return k, err
+	case *jwt.SigningMethodECDSA:
+		if cfg.PrivateKeyPath != "" {
+			pem, err := os.ReadFile(cfg.PrivateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseECPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkCurve(method, &private.PublicKey); err != nil {
+				return nil, err
+			}
+			k.private, k.public = private, &private.PublicKey
+			return k, nil
+		}
+		pem, err := readPublicKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+		public, err := jwt.ParseECPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		k.public = public
+		return k, checkCurve(method, public)
+	default:
+		return nil, fmt.Errorf("signing method %s does not use key pairs", method.Alg())
+	}
+}
+
+func readPublicKey(cfg config.KeyConfig) ([]byte, error) {
+	if cfg.PublicKeyPath == "" {
+		return nil, errors.New("private_key or public_key is required")
+	}
+	return os.ReadFile(cfg.PublicKeyPath)
+}
+
+// checkCurve makes sure an EC key has the curve the signing method expects, e.g. P-256 for ES256.
+func checkCurve(method jwt.SigningMethod, public *ecdsa.PublicKey) error {
+	ecMethod := method.(*jwt.SigningMethodECDSA)
+	if public.Curve.Params().BitSize != ecMethod.CurveBits {
+		return fmt.Errorf("%s requires a %d bit curve, got %s", method.Alg(), ecMethod.CurveBits, public.Curve.Params().Name)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func rsaKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "private.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(private)),
+		writePEM(t, "public.pem", "PUBLIC KEY", public)
+}
+
+func TestKeyManager_Rotation(t *testing.T) {
+	oldPrivate, oldPublic := rsaKeyPair(t)
+	newPrivate, _ := rsaKeyPair(t)
+
+	before, err := NewKeyManager(jwt.SigningMethodRS256, "2024", []config.KeyConfig{
+		{ID: "2024", PrivateKeyPath: oldPrivate},
+	})
+	assert.NoError(t, err)
+	oldToken, err := before.Sign(jwt.MapClaims{"user_id": "user"})
+	assert.NoError(t, err)
+
+	// The new key signs, the old one is only kept to verify tokens it already signed
+	after, err := NewKeyManager(jwt.SigningMethodRS256, "2025", []config.KeyConfig{
+		{ID: "2024", PublicKeyPath: oldPublic},
+		{ID: "2025", PrivateKeyPath: newPrivate},
+	})
+	assert.NoError(t, err)
+	newToken, err := after.Sign(jwt.MapClaims{"user_id": "user"})
+	assert.NoError(t, err)
+
+	for _, tokenStr := range []string{oldToken, newToken} {
+		token, err := jwt.Parse(tokenStr, after.Keyfunc, jwt.WithValidMethods([]string{"RS256"}))
+		assert.NoError(t, err)
+		assert.True(t, token.Valid)
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025", token.Header["kid"])
+
+	// Tokens of the new key are unknown to managers that have not been rotated yet
+	_, err = jwt.Parse(newToken, before.Keyfunc)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	jwks := after.JWKS()
+	assert.Len(t, jwks, 2)
+	assert.Equal(t, "2024", jwks[0].Kid)
+	assert.Equal(t, "RSA", jwks[0].Kty)
+	assert.Equal(t, "RS256", jwks[0].Alg)
+	assert.Equal(t, "AQAB", jwks[0].E)
+	assert.NotEmpty(t, jwks[0].N)
+}
+
+func TestKeyManager_ECDSA(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writePEM(t, "ec.pem", "EC PRIVATE KEY", der)
+
+	manager, err := NewKeyManager(jwt.SigningMethodES256, "ec", []config.KeyConfig{{ID: "ec", PrivateKeyPath: path}})
+	assert.NoError(t, err)
+	tokenStr, err := manager.Sign(jwt.MapClaims{"user_id": "user"})
+	assert.NoError(t, err)
+	_, err = jwt.Parse(tokenStr, manager.Keyfunc, jwt.WithValidMethods([]string{"ES256"}))
+	assert.NoError(t, err)
+
+	jwk := manager.JWKS()[0]
+	assert.Equal(t, "EC", jwk.Kty)
+	assert.Equal(t, "P-256", jwk.Crv)
+	assert.Len(t, jwk.X, 43) // 32 bytes, base64url without padding
+
+	_, err = NewKeyManager(jwt.SigningMethodES384, "ec", []config.KeyConfig{{ID: "ec", PrivateKeyPath: path}})
+	assert.Error(t, err)
+}
+
+func TestKeyManager_InvalidConfig(t *testing.T) {
+	private, public := rsaKeyPair(t)
+
+	_, err := NewKeyManager(jwt.SigningMethodRS256, "missing", []config.KeyConfig{{ID: "key", PrivateKeyPath: private}})
+	assert.Error(t, err)
+
+	_, err = NewKeyManager(jwt.SigningMethodRS256, "key", []config.KeyConfig{{ID: "key", PublicKeyPath: public}})
+	assert.Error(t, err, "a signing key without a private key is rejected")
+
+	_, err = NewKeyManager(jwt.SigningMethodHS256, "key", []config.KeyConfig{{ID: "key", PrivateKeyPath: private}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Roles of users, every role can do what the roles before it can.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Statuses an admin can put an account in.
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+	UserStatusBanned      = "banned"
+)
+
+// HasRole reports whether role grants at least the permissions of required.
+func HasRole(role, required string) bool {
+	return roleRank(role) >= roleRank(required)
+}
+
+func roleRank(role string) int {
+	switch role {
+	case RoleAdmin:
+		return 2
+	case RoleModerator:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AuditEntry records an action of a moderator or admin.
+type AuditEntry struct {
+	ID        int64
+	ActorID   uuid.UUID
+	Action    string
+	Target    string // ID of the user or key of the paste the action was taken on
+	Details   string
+	CreatedAt time.Time
+}
+
+// AdminModel manages accounts on behalf of admins. Every change is written to the audit log in
+// the same transaction.
+type AdminModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminModel(pool *pgxpool.Pool) *AdminModel {
+	return &AdminModel{pool: pool}
+}
+
+// ListUsers returns users ordered by their sign up, newest first.
+func (m *AdminModel) ListUsers(limit, offset int) ([]*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        SELECT id, created_at, name, email, activated, totp_enabled, role, banned_at
+        FROM users
+        ORDER BY created_at DESC, id
+        LIMIT $1 OFFSET $2
+    `
+	rows, err := m.pool.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.CreatedAt, &user.Name, &user.Email, &user.Activated, &user.MFAEnabled, &user.Role, &user.BannedAt); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
+
+// SetStatus activates, deactivates or bans a user.
+func (m *AdminModel) SetStatus(entry *AuditEntry, userID uuid.UUID, status string) error {
+	var query string
+	switch status {
+	case UserStatusActive:
+		query = `UPDATE users SET activated = true, banned_at = NULL WHERE id = $1`
+	case UserStatusDeactivated:
+		query = `UPDATE users SET activated = false WHERE id = $1`
+	case UserStatusBanned:
+		query = `UPDATE users SET banned_at = COALESCE(banned_at, NOW()) WHERE id = $1`
+	default:
+		return fmt.Errorf("unknown user status: %s", status)
+	}
+	return m.updateUser(entry, query, userID)
+}
+
+// SetRole changes the role of a user.
+func (m *AdminModel) SetRole(entry *AuditEntry, userID uuid.UUID, role string) error {
+	return m.updateUser(entry, `UPDATE users SET role = $2 WHERE id = $1`, userID, role)
+}
+
+func (m *AdminModel) updateUser(entry *AuditEntry, query string, userID uuid.UUID, args ...any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, append([]any{userID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	if err := insertAuditEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// InsertAudit records an action that was taken outside of the auth service, e.g. on a paste.
+func (m *AdminModel) InsertAudit(entry *AuditEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return insertAuditEntry(ctx, m.pool, entry)
+}
+
+// ListAudit returns the audit log, newest entries first.
+func (m *AdminModel) ListAudit(limit, offset int) ([]*AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        SELECT id, actor_id, action, target, details, created_at
+        FROM admin_audit_log
+        ORDER BY id DESC
+        LIMIT $1 OFFSET $2
+    `
+	rows, err := m.pool.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	defer rows.Close()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		var entry AuditEntry
+		if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &entry.Target, &entry.Details, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// execer is implemented by the pool and by transactions.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func insertAuditEntry(ctx context.Context, db execer, entry *AuditEntry) error {
+	query := `INSERT INTO admin_audit_log (actor_id, action, target, details) VALUES ($1, $2, $3, $4)`
+	if _, err := db.Exec(ctx, query, entry.ActorID, entry.Action, entry.Target, entry.Details); err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Scopes an API key can be granted, a key is only accepted on the routes its scopes cover.
+const (
+	ScopePasteRead   = "paste:read"
+	ScopePasteWrite  = "paste:write"
+	ScopePasteDelete = "paste:delete"
+)
+
+// APIKeyScopes lists every valid scope.
+var APIKeyScopes = []string{ScopePasteRead, ScopePasteWrite, ScopePasteDelete}
+
+// APIKey is a long-lived personal access token. Only the hash of the token is stored, the
+// plaintext is shown to the user once when the key is created.
+type APIKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Hash       string
+	Scopes     []string
+	Expiry     time.Time
+	CreatedAt  time.Time
+	LastUsedAt *time.Time // Nil until the key is used for the first time
+}
+
+type APIKeyModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyModel(pool *pgxpool.Pool) *APIKeyModel {
+	return &APIKeyModel{pool: pool}
+}
+
+func (m *APIKeyModel) Insert(key *APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        INSERT INTO api_keys (id, user_id, name, hash, scopes, expiry) VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+	return m.pool.QueryRow(ctx, query, key.ID, key.UserID, key.Name, key.Hash, key.Scopes, key.Expiry).Scan(&key.CreatedAt)
+}
+
+// GetForUser returns the keys of the user, newest first.
+func (m *APIKeyModel) GetForUser(userID uuid.UUID) ([]*APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        SELECT id, user_id, name, scopes, expiry, created_at, last_used_at
+        FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC
+    `
+	rows, err := m.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		var key APIKey
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Scopes, &key.Expiry, &key.CreatedAt, &key.LastUsedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, rows.Err()
+}
+
+// Use looks up an unexpired key by the hash of its token and records that it was used. Keys of
+// deactivated and banned users are not found.
+func (m *APIKeyModel) Use(hash string) (*APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE api_keys SET last_used_at = NOW()
+        WHERE hash = $1 AND expiry > NOW()
+        AND user_id IN (SELECT id FROM users WHERE activated AND banned_at IS NULL)
+        RETURNING id, user_id, name, scopes, expiry, created_at, last_used_at
+    `
+	var key APIKey
+	err := m.pool.QueryRow(ctx, query, hash).Scan(&key.ID, &key.UserID, &key.Name, &key.Scopes, &key.Expiry, &key.CreatedAt, &key.LastUsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+	key.Hash = hash
+	return &key, nil
+}
+
+// Delete revokes a key of the user. Keys of other users are reported as not found.
+func (m *APIKeyModel) Delete(id, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	tag, err := m.pool.Exec(ctx, `DELETE FROM api_keys WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
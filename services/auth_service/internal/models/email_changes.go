@@ -0,0 +1,97 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EmailChange is a new email address that waits for its owner to confirm it.
+type EmailChange struct {
+	Hash     string // SHA-256 of the token mailed to the new address
+	UserID   uuid.UUID
+	NewEmail string
+	OldEmail string // Set by Confirm, the address is told about the change
+	Expiry   time.Time
+}
+
+type EmailChangeModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmailChangeModel(pool *pgxpool.Pool) *EmailChangeModel {
+	return &EmailChangeModel{pool: pool}
+}
+
+// Insert stores a pending change, replacing the one the user requested before.
+func (m *EmailChangeModel) Insert(change *EmailChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM email_changes WHERE user_id = $1`, change.UserID); err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	query := `INSERT INTO email_changes (hash, user_id, new_email, expiry) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.Exec(ctx, query, change.Hash, change.UserID, change.NewEmail, change.Expiry); err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	return tx.Commit(ctx)
+}
+
+// Confirm swaps the email of the user for the pending one of the token. It returns
+// ErrRecordNotFound for unknown and expired tokens and ErrDuplicateEmail when the address was
+// taken by another user in the meantime.
+func (m *EmailChangeModel) Confirm(hash string) (*EmailChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	change := EmailChange{Hash: hash}
+	query := `
+        DELETE FROM email_changes WHERE hash = $1 AND expiry > NOW()
+        RETURNING user_id, new_email, expiry
+    `
+	if err := tx.QueryRow(ctx, query, hash).Scan(&change.UserID, &change.NewEmail, &change.Expiry); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	query = `
+        UPDATE users SET email = $2
+        FROM (SELECT email FROM users WHERE id = $1) AS old
+        WHERE users.id = $1
+        RETURNING old.email
+    `
+	if err := tx.QueryRow(ctx, query, change.UserID, change.NewEmail).Scan(&change.OldEmail); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrDuplicateEmail
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Identity links an account of an external identity provider to a user.
+type Identity struct {
+	Provider string
+	Subject  string // Stable ID of the account at the provider
+	Email    string
+	Name     string
+}
+
+// LoginState is an OIDC login that was sent to the provider and waits for its callback.
+type LoginState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	Expiry       time.Time
+}
+
+type IdentityModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdentityModel(pool *pgxpool.Pool) *IdentityModel {
+	return &IdentityModel{pool: pool}
+}
+
+func (m *IdentityModel) InsertState(state *LoginState) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        INSERT INTO oidc_login_states (state, provider, nonce, code_verifier, expiry) VALUES ($1, $2, $3, $4, $5)
+    `
+	if _, err := m.pool.Exec(ctx, query, state.State, state.Provider, state.Nonce, state.CodeVerifier, state.Expiry); err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	return nil
+}
+
+// TakeState removes a pending login and returns it, so every callback can only be completed once.
+// Expired logins return ErrRecordNotFound.
+func (m *IdentityModel) TakeState(state string) (*LoginState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        DELETE FROM oidc_login_states WHERE state = $1
+        RETURNING provider, nonce, code_verifier, expiry
+    `
+	login := LoginState{State: state}
+	err := m.pool.QueryRow(ctx, query, state).Scan(&login.Provider, &login.Nonce, &login.CodeVerifier, &login.Expiry)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	if login.Expiry.Before(time.Now()) {
+		return nil, ErrRecordNotFound
+	}
+	return &login, nil
+}
+
+// LinkUser returns the user the identity belongs to. An unknown identity is linked to the user
+// with the same email, or to a new user when there is none. The email has to be verified by the
+// provider. Users signing in this way are activated, passwordHash is only used for new users and
+// should not match any password.
+func (m *IdentityModel) LinkUser(identity *Identity, passwordHash []byte) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var userID uuid.UUID
+	query := `SELECT user_id FROM user_identities WHERE provider = $1 AND subject = $2`
+	err = tx.QueryRow(ctx, query, identity.Provider, identity.Subject).Scan(&userID)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+
+	query = `
+        INSERT INTO users (name, email, password_hash, activated)
+        VALUES ($1, $2, $3, true)
+        ON CONFLICT (email) DO UPDATE SET activated = true
+        RETURNING id
+    `
+	if err := tx.QueryRow(ctx, query, identity.Name, identity.Email, passwordHash).Scan(&userID); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+
+	query = `
+        INSERT INTO user_identities (provider, subject, user_id, email) VALUES ($1, $2, $3, $4)
+    `
+	if _, err := tx.Exec(ctx, query, identity.Provider, identity.Subject, userID, identity.Email); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Kinds of login throttles, failed logins are counted per account and per client IP.
+const (
+	ThrottleAccount = "account"
+	ThrottleIP      = "ip"
+)
+
+// LoginThrottle counts the recent failed logins of an account or an IP.
+type LoginThrottle struct {
+	Kind         string
+	Subject      string // Email of the account or the IP
+	Failures     int
+	BlockedUntil time.Time
+}
+
+// LoginFailure is the state after a failed login was recorded.
+type LoginFailure struct {
+	UserID          *uuid.UUID // Nil when no user has the email
+	AccountFailures int
+	IPFailures      int
+}
+
+// LoginAttemptModel keeps the audit log of logins and the counters used to throttle them.
+type LoginAttemptModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewLoginAttemptModel(pool *pgxpool.Pool) *LoginAttemptModel {
+	return &LoginAttemptModel{pool: pool}
+}
+
+// GetBlocked returns the throttles of the account and the IP that still block logins.
+func (m *LoginAttemptModel) GetBlocked(email, ip string) ([]LoginThrottle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        SELECT kind, subject, failures, blocked_until FROM login_throttles
+        WHERE ((kind = $1 AND subject = $2) OR (kind = $3 AND subject = $4)) AND blocked_until > NOW()
+    `
+	rows, err := m.pool.Query(ctx, query, ThrottleAccount, email, ThrottleIP, ip)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	defer rows.Close()
+
+	var throttles []LoginThrottle
+	for rows.Next() {
+		var throttle LoginThrottle
+		if err := rows.Scan(&throttle.Kind, &throttle.Subject, &throttle.Failures, &throttle.BlockedUntil); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+		}
+		throttles = append(throttles, throttle)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return throttles, nil
+}
+
+// RecordFailure logs a failed login and counts it for the account and the IP. Failures before
+// since are forgotten, the count starts over.
+func (m *LoginAttemptModel) RecordFailure(email, ip string, since time.Time) (*LoginFailure, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var failure LoginFailure
+	query := `
+        INSERT INTO login_attempts (email, user_id, ip, succeeded)
+        VALUES ($1, (SELECT id FROM users WHERE email = $1), $2, false)
+        RETURNING user_id
+    `
+	if err := tx.QueryRow(ctx, query, email, ip).Scan(&failure.UserID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+
+	query = `
+        INSERT INTO login_throttles (kind, subject, failures, last_failure_at) VALUES ($1, $2, 1, NOW())
+        ON CONFLICT (kind, subject) DO UPDATE SET
+            failures = CASE WHEN login_throttles.last_failure_at < $3 THEN 1 ELSE login_throttles.failures + 1 END,
+            last_failure_at = NOW()
+        RETURNING failures
+    `
+	if err := tx.QueryRow(ctx, query, ThrottleAccount, email, since).Scan(&failure.AccountFailures); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if err := tx.QueryRow(ctx, query, ThrottleIP, ip, since).Scan(&failure.IPFailures); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &failure, nil
+}
+
+// Block rejects logins of the account or the IP until the given time.
+func (m *LoginAttemptModel) Block(kind, subject string, until time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `UPDATE login_throttles SET blocked_until = $3 WHERE kind = $1 AND subject = $2`
+	if _, err := m.pool.Exec(ctx, query, kind, subject, until); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return nil
+}
+
+// RecordSuccess logs a successful login and clears the failures of the account. The failures of
+// the IP are kept, one valid account must not cover guessing the passwords of others.
+func (m *LoginAttemptModel) RecordSuccess(userID uuid.UUID, email, ip string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO login_attempts (email, user_id, ip, succeeded) VALUES ($1, $2, $3, true)`
+	if _, err := tx.Exec(ctx, query, email, userID, ip); err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	query = `DELETE FROM login_throttles WHERE kind = $1 AND subject = $2`
+	if _, err := tx.Exec(ctx, query, ThrottleAccount, email); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Unlock clears the failures of the account of a user, e.g. after the password was reset.
+func (m *LoginAttemptModel) Unlock(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        DELETE FROM login_throttles
+        WHERE kind = $1 AND subject = (SELECT email FROM users WHERE id = $2)
+    `
+	if _, err := m.pool.Exec(ctx, query, ThrottleAccount, userID); err != nil {
+		return err
+	}
+	return nil
+}
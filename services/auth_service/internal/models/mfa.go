@@ -0,0 +1,177 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MFAModel stores the TOTP secrets, recovery codes and pending login challenges of users.
+type MFAModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFAModel(pool *pgxpool.Pool) *MFAModel {
+	return &MFAModel{pool: pool}
+}
+
+// SetPendingTOTP stores a new secret that is not enforced until EnableTOTP, and returns the
+// email of the user for the provisioning URI. It returns ErrMFAEnabled when the user already
+// has a confirmed authenticator, that one has to be disabled first.
+func (m *MFAModel) SetPendingTOTP(userID uuid.UUID, secret string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE users SET totp_secret = $2, totp_last_step = NULL
+        WHERE id = $1 AND NOT totp_enabled
+        RETURNING email
+    `
+	var email string
+	if err := m.pool.QueryRow(ctx, query, userID, secret).Scan(&email); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrMFAEnabled
+		}
+		return "", fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return email, nil
+}
+
+// GetTOTP returns the secret of the user and whether it is enforced. The secret is empty when
+// the user never started an enrollment.
+func (m *MFAModel) GetTOTP(userID uuid.UUID) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `SELECT COALESCE(totp_secret, ''), totp_enabled FROM users WHERE id = $1`
+	var secret string
+	var enabled bool
+	if err := m.pool.QueryRow(ctx, query, userID).Scan(&secret, &enabled); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, ErrRecordNotFound
+		}
+		return "", false, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return secret, enabled, nil
+}
+
+// UseTOTPStep records the time step of an accepted code. It reports false when that step or a
+// later one was already used, so an observed code cannot be replayed.
+func (m *MFAModel) UseTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE users SET totp_last_step = $2
+        WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)
+    `
+	tag, err := m.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// EnableTOTP enforces the pending secret and replaces the recovery codes of the user.
+func (m *MFAModel) EnableTOTP(userID uuid.UUID, recoveryHashes []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET totp_enabled = true WHERE id = $1`, userID); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO mfa_recovery_codes (user_id, hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// DisableTOTP removes the secret and the recovery codes of the user.
+func (m *MFAModel) DisableTOTP(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET totp_secret = NULL, totp_enabled = false, totp_last_step = NULL WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UseRecoveryCode marks an unused recovery code as used, it reports false for unknown or used codes.
+func (m *MFAModel) UseRecoveryCode(userID uuid.UUID, hash string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE mfa_recovery_codes SET used_at = NOW()
+        WHERE user_id = $1 AND hash = $2 AND used_at IS NULL
+    `
+	tag, err := m.pool.Exec(ctx, query, userID, hash)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// InsertChallenge records a login that is waiting for its second factor.
+func (m *MFAModel) InsertChallenge(jti, userID uuid.UUID, expiry time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `INSERT INTO mfa_challenges (jti, user_id, expiry) VALUES ($1, $2, $3)`
+	if _, err := m.pool.Exec(ctx, query, jti, userID, expiry); err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	return nil
+}
+
+// AttemptChallenge counts an attempt to answer a challenge and returns its user. Challenges that
+// are answered, expired or out of attempts return ErrTokenRevoked.
+func (m *MFAModel) AttemptChallenge(jti uuid.UUID, maxAttempts int) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE mfa_challenges SET attempts = attempts + 1
+        WHERE jti = $1 AND used_at IS NULL AND expiry > NOW() AND attempts < $2
+        RETURNING user_id
+    `
+	var userID uuid.UUID
+	if err := m.pool.QueryRow(ctx, query, jti, maxAttempts).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrTokenRevoked
+		}
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return userID, nil
+}
+
+// CompleteChallenge marks a challenge as answered, it cannot be used again.
+func (m *MFAModel) CompleteChallenge(jti uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if _, err := m.pool.Exec(ctx, `UPDATE mfa_challenges SET used_at = NOW() WHERE jti = $1`, jti); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return nil
+}
@@ -14,17 +14,36 @@ var (
 	ErrUpdateFailed   = errors.New("update failed")
 	ErrInsertFailed   = errors.New("insert failed")
 	ErrSelectFailed   = errors.New("select failed")
+	ErrTokenRevoked   = errors.New("token revoked")
+	ErrTokenReused    = errors.New("token reused")
+	ErrMFAEnabled     = errors.New("mfa already enabled")
 	AnonymousUser     = &User{}
 )
 
 type Model struct {
-	User  *UserModel
-	Token *TokenModel
+	User         *UserModel
+	Token        *TokenModel
+	RefreshToken *RefreshTokenModel
+	APIKey       *APIKeyModel
+	MFA          *MFAModel
+	Identity     *IdentityModel
+	LoginAttempt *LoginAttemptModel
+	Admin        *AdminModel
+	EmailChange  *EmailChangeModel
+	Session      *SessionModel
 }
 
 func New(pool *pgxpool.Pool) *Model {
 	return &Model{
-		User:  NewUserModel(pool),
-		Token: NewTokenModel(pool),
+		User:         NewUserModel(pool),
+		Token:        NewTokenModel(pool),
+		RefreshToken: NewRefreshTokenModel(pool),
+		APIKey:       NewAPIKeyModel(pool),
+		MFA:          NewMFAModel(pool),
+		Identity:     NewIdentityModel(pool),
+		LoginAttempt: NewLoginAttemptModel(pool),
+		Admin:        NewAdminModel(pool),
+		EmailChange:  NewEmailChangeModel(pool),
+		Session:      NewSessionModel(pool),
 	}
 }
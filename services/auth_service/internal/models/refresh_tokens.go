@@ -0,0 +1,139 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RefreshToken is a single issued refresh token. Every login starts a new family, each
+// refresh replaces the presented token with a new one of the same family.
+type RefreshToken struct {
+	JTI      uuid.UUID
+	FamilyID uuid.UUID
+	UserID   uuid.UUID
+	Expiry   time.Time
+}
+
+type RefreshTokenModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewRefreshTokenModel(pool *pgxpool.Pool) *RefreshTokenModel {
+	return &RefreshTokenModel{pool: pool}
+}
+
+func (m *RefreshTokenModel) Insert(token *RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        INSERT INTO refresh_tokens (jti, family_id, user_id, expiry) VALUES ($1, $2, $3, $4)
+    `
+	_, err := m.pool.Exec(ctx, query, token.JTI, token.FamilyID, token.UserID, token.Expiry)
+	return err
+}
+
+// Rotate marks the token jti as used and stores next in its family. Presenting a token that was
+// already used means it leaked, the whole family is revoked and ErrTokenReused is returned, next
+// still names the family and the user so the session can be revoked everywhere.
+// The presented token is locked for the duration of the rotation, so two concurrent refreshes
+// with the same token cannot both succeed.
+func (m *RefreshTokenModel) Rotate(jti uuid.UUID, next *RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        SELECT family_id, user_id, expiry, used_at IS NOT NULL, revoked_at IS NOT NULL
+        FROM refresh_tokens WHERE jti = $1 FOR UPDATE
+    `
+	var current RefreshToken
+	var used, revoked bool
+	err = tx.QueryRow(ctx, query, jti).Scan(&current.FamilyID, &current.UserID, &current.Expiry, &used, &revoked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	switch {
+	case revoked:
+		return ErrTokenRevoked
+	case used:
+		next.FamilyID = current.FamilyID
+		next.UserID = current.UserID
+		if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`, current.FamilyID); err != nil {
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return err
+		}
+		return ErrTokenReused
+	case current.Expiry.Before(time.Now()):
+		return ErrTokenRevoked
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE jti = $1`, jti); err != nil {
+		return err
+	}
+	next.FamilyID = current.FamilyID
+	next.UserID = current.UserID
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO refresh_tokens (jti, family_id, user_id, expiry) VALUES ($1, $2, $3, $4)`,
+		next.JTI, next.FamilyID, next.UserID, next.Expiry,
+	); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RevokeFamily revokes every token of the family the token jti belongs to and returns the
+// family ID, which is the session ID, and the user of the family.
+func (m *RefreshTokenModel) RevokeFamily(jti uuid.UUID) (uuid.UUID, uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE refresh_tokens SET revoked_at = NOW()
+        WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE jti = $1) AND revoked_at IS NULL
+        RETURNING family_id, user_id
+    `
+	rows, err := m.pool.Query(ctx, query, jti)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return uuid.Nil, uuid.Nil, err
+		}
+		return uuid.Nil, uuid.Nil, ErrRecordNotFound
+	}
+	var familyID, userID uuid.UUID
+	if err := rows.Scan(&familyID, &userID); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	rows.Close()
+	return familyID, userID, rows.Err()
+}
+
+func (m *RefreshTokenModel) RevokeAllForUser(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE refresh_tokens SET revoked_at = NOW()
+        WHERE user_id = $1 AND revoked_at IS NULL
+    `
+	_, err := m.pool.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Session is a login of a user on a device. Its ID is the family of its refresh tokens.
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+// liveFamily matches sessions whose refresh token family still has a token to refresh with.
+const liveFamily = `
+    EXISTS (
+        SELECT 1 FROM refresh_tokens
+        WHERE family_id = sessions.id AND used_at IS NULL AND revoked_at IS NULL AND expiry > NOW()
+    )
+`
+
+type SessionModel struct {
+	pool *pgxpool.Pool
+}
+
+func NewSessionModel(pool *pgxpool.Pool) *SessionModel {
+	return &SessionModel{pool: pool}
+}
+
+func (m *SessionModel) Insert(session *Session) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        INSERT INTO sessions (id, user_id, user_agent, ip) VALUES ($1, $2, $3, $4)
+        RETURNING created_at, last_seen_at
+    `
+	err := m.pool.QueryRow(ctx, query, session.ID, session.UserID, session.UserAgent, session.IP).Scan(&session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInsertFailed, err)
+	}
+	return nil
+}
+
+// Touch records that the session was refreshed, from a new address when ip is set.
+func (m *SessionModel) Touch(id uuid.UUID, ip, userAgent string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE sessions
+        SET last_seen_at = NOW(), ip = COALESCE(NULLIF($2, ''), ip), user_agent = COALESCE(NULLIF($3, ''), user_agent)
+        WHERE id = $1
+    `
+	if _, err := m.pool.Exec(ctx, query, id, ip, userAgent); err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	return nil
+}
+
+// ListActive returns the sessions of a user that were not logged out or revoked, the most
+// recently used first.
+func (m *SessionModel) ListActive(userID uuid.UUID) ([]*Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        SELECT id, user_id, user_agent, ip, created_at, last_seen_at
+        FROM sessions
+        WHERE user_id = $1 AND ` + liveFamily + `
+        ORDER BY last_seen_at DESC
+    `
+	rows, err := m.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	defer rows.Close()
+
+	var sessions []*Session
+	for rows.Next() {
+		var session Session
+		if err := rows.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.CreatedAt, &session.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return sessions, nil
+}
+
+// IsActive reports whether the session was neither logged out nor revoked.
+func (m *SessionModel) IsActive(id uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `SELECT EXISTS (SELECT 1 FROM sessions WHERE id = $1 AND ` + liveFamily + `)`
+	var active bool
+	if err := m.pool.QueryRow(ctx, query, id).Scan(&active); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return active, nil
+}
+
+// Revoke ends a session of the user by revoking its refresh tokens. It returns
+// ErrRecordNotFound when the user has no active session with the ID.
+func (m *SessionModel) Revoke(userID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	query := `
+        UPDATE refresh_tokens SET revoked_at = NOW()
+        WHERE family_id = $2 AND user_id = $1 AND revoked_at IS NULL
+    `
+	tag, err := m.pool.Exec(ctx, query, userID, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
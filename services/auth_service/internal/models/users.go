@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
@@ -21,6 +22,10 @@ type User struct {
 	Email     string    `json:"email"`
 	Password  password  `json:"-"`
 	Activated bool      `json:"activated"`
+	// MFAEnabled is set once the user confirmed a TOTP authenticator, logins then need a code as well
+	MFAEnabled bool       `json:"mfa_enabled"`
+	Role       string     `json:"role"`
+	BannedAt   *time.Time `json:"banned_at,omitempty"` // Banned users cannot log in, even when activated
 }
 
 type password struct {
@@ -84,7 +89,7 @@ func (model *UserModel) UserExists(userId *uuid.UUID) (bool, error) {
 
 func (model *UserModel) GetByEmail(email string) (*User, error) {
 	query := `
-        SELECT id, created_at, name, email, password_hash, activated
+        SELECT id, created_at, name, email, password_hash, activated, totp_enabled, role, banned_at
         FROM users 
         WHERE email = $1
     `
@@ -100,6 +105,9 @@ func (model *UserModel) GetByEmail(email string) (*User, error) {
 		&user.Email,
 		&user.Password.Hash,
 		&user.Activated,
+		&user.MFAEnabled,
+		&user.Role,
+		&user.BannedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -110,6 +118,107 @@ func (model *UserModel) GetByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
+func (model *UserModel) GetByID(userID uuid.UUID) (*User, error) {
+	query := `
+        SELECT id, created_at, name, email, password_hash, activated, totp_enabled, role, banned_at
+        FROM users
+        WHERE id = $1
+    `
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var user User
+	err := model.pool.QueryRow(ctx, query, userID).Scan(
+		&user.ID,
+		&user.CreatedAt,
+		&user.Name,
+		&user.Email,
+		&user.Password.Hash,
+		&user.Activated,
+		&user.MFAEnabled,
+		&user.Role,
+		&user.BannedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return &user, nil
+}
+
+func (model *UserModel) UpdateName(userID uuid.UUID, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	tag, err := model.pool.Exec(ctx, `UPDATE users SET name = $2 WHERE id = $1`, userID, name)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (model *UserModel) UpdatePassword(userID uuid.UUID, hash []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	tag, err := model.pool.Exec(ctx, `UPDATE users SET password_hash = $2 WHERE id = $1`, userID, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateFailed, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete removes a user. Sessions, API keys and linked identities are deleted with it, the
+// tokens of activation and password reset mails are deleted here.
+func (model *UserModel) Delete(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := model.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tokens WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// GetAccess returns the role of a user and whether the user may use the application, that is
+// activated and not banned.
+func (model *UserModel) GetAccess(userID uuid.UUID) (string, bool, error) {
+	query := `SELECT role, activated AND banned_at IS NULL FROM users WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var role string
+	var active bool
+	if err := model.pool.QueryRow(ctx, query, userID).Scan(&role, &active); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, ErrRecordNotFound
+		}
+		return "", false, fmt.Errorf("%w: %v", ErrSelectFailed, err)
+	}
+	return role, active, nil
+}
+
 func (m *UserModel) ActivateUser(hash string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
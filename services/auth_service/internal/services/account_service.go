@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/validation"
+	"github.com/google/uuid"
+)
+
+// emailChangeExpiry is how long the link mailed to a new address can be used.
+const emailChangeExpiry = 24 * time.Hour
+
+var (
+	ErrIncorrectPassword    = errors.New("incorrect password")
+	ErrSameEmail            = errors.New("email is already the address of the account")
+	ErrInvalidAccountChange = errors.New("invalid account change")
+)
+
+// AccountService lets users manage their own account. Changes of the password, the email and
+// deleting the account have to be confirmed with the current password.
+type AccountService struct {
+	users        *models.UserModel
+	emailChanges *models.EmailChangeModel
+}
+
+func NewAccountService(users *models.UserModel, emailChanges *models.EmailChangeModel) *AccountService {
+	return &AccountService{users: users, emailChanges: emailChanges}
+}
+
+func (srv *AccountService) GetProfile(userID uuid.UUID) (*models.User, error) {
+	return srv.users.GetByID(userID)
+}
+
+func (srv *AccountService) UpdateProfile(userID uuid.UUID, name string) (*models.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is not provided", ErrInvalidAccountChange)
+	}
+	if len([]rune(name)) > 100 {
+		return nil, fmt.Errorf("%w: name is longer than 100 characters", ErrInvalidAccountChange)
+	}
+	if err := srv.users.UpdateName(userID, name); err != nil {
+		return nil, err
+	}
+	return srv.users.GetByID(userID)
+}
+
+func (srv *AccountService) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
+	if _, err := srv.checkPassword(userID, currentPassword); err != nil {
+		return err
+	}
+	if err := validation.ValidatePasswordPlaintext(newPassword); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAccountChange, err)
+	}
+	var user models.User
+	if err := user.Password.Set(newPassword); err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return srv.users.UpdatePassword(userID, user.Password.Hash)
+}
+
+// RequestEmailChange stores the new address and returns the token that confirms it, which has
+// to be mailed to the new address. The email of the account stays the same until then.
+func (srv *AccountService) RequestEmailChange(userID uuid.UUID, password, newEmail string) (string, error) {
+	user, err := srv.checkPassword(userID, password)
+	if err != nil {
+		return "", err
+	}
+	if err := validation.ValidateEmail(newEmail); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidAccountChange, err)
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return "", ErrSameEmail
+	}
+	if _, err := srv.users.GetByEmail(newEmail); err == nil {
+		return "", models.ErrDuplicateEmail
+	} else if !errors.Is(err, models.ErrRecordNotFound) {
+		return "", err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	change := &models.EmailChange{
+		Hash:     hashToken(token),
+		UserID:   userID,
+		NewEmail: newEmail,
+		Expiry:   time.Now().Add(emailChangeExpiry),
+	}
+	if err := srv.emailChanges.Insert(change); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConfirmEmailChange swaps the email of the account for the address the token was mailed to.
+func (srv *AccountService) ConfirmEmailChange(token string) (*models.EmailChange, error) {
+	return srv.emailChanges.Confirm(hashToken(token))
+}
+
+// DeleteAccount removes the user with everything the auth service stores about them.
+func (srv *AccountService) DeleteAccount(userID uuid.UUID, password string) error {
+	if _, err := srv.checkPassword(userID, password); err != nil {
+		return err
+	}
+	return srv.users.Delete(userID)
+}
+
+func (srv *AccountService) checkPassword(userID uuid.UUID, password string) (*models.User, error) {
+	user, err := srv.users.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := user.Password.Match(password)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, ErrIncorrectPassword
+	}
+	return user, nil
+}
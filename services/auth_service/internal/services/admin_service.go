@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 200
+)
+
+// Actions written to the audit log.
+const (
+	AuditSetUserStatus = "user.set_status"
+	AuditSetUserRole   = "user.set_role"
+)
+
+var (
+	ErrForbidden      = errors.New("action requires a higher role")
+	ErrSelfModeration = errors.New("admins cannot change their own account")
+	ErrInvalidStatus  = errors.New("invalid user status")
+	ErrInvalidRole    = errors.New("invalid role")
+)
+
+// AdminService lets moderators and admins manage accounts. The role of the actor is checked
+// against the database on every call, a demoted admin loses access before the role claim of the
+// access token expires.
+type AdminService struct {
+	model         *models.AdminModel
+	users         *models.UserModel
+	tokenSrv      *TokenService
+	revocationSrv *RevocationService
+}
+
+func NewAdminService(model *models.AdminModel, users *models.UserModel, tokenSrv *TokenService, revocationSrv *RevocationService) *AdminService {
+	return &AdminService{model: model, users: users, tokenSrv: tokenSrv, revocationSrv: revocationSrv}
+}
+
+// Authorize returns ErrForbidden unless the actor is an active user with at least the required role.
+func (srv *AdminService) Authorize(actorId, required string) (uuid.UUID, error) {
+	actorID, err := uuid.Parse(actorId)
+	if err != nil {
+		return uuid.Nil, models.ErrInvalidUUID
+	}
+	role, active, err := srv.users.GetAccess(actorID)
+	if err != nil {
+		if errors.Is(err, models.ErrRecordNotFound) {
+			return uuid.Nil, ErrForbidden
+		}
+		return uuid.Nil, err
+	}
+	if !active || !models.HasRole(role, required) {
+		return uuid.Nil, ErrForbidden
+	}
+	return actorID, nil
+}
+
+func (srv *AdminService) ListUsers(actorId string, limit, offset int) ([]*models.User, error) {
+	if _, err := srv.Authorize(actorId, models.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return srv.model.ListUsers(pageSize(limit), max(offset, 0))
+}
+
+// SetUserStatus activates, deactivates or bans a user. Deactivated and banned users are signed
+// out of every session.
+func (srv *AdminService) SetUserStatus(actorId, userId, status, reason string) error {
+	actorID, userID, err := srv.authorizeChange(actorId, userId)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains([]string{models.UserStatusActive, models.UserStatusDeactivated, models.UserStatusBanned}, status) {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, status)
+	}
+	entry := &models.AuditEntry{ActorID: actorID, Action: AuditSetUserStatus, Target: userId, Details: auditDetails(status, reason)}
+	if err := srv.model.SetStatus(entry, userID, status); err != nil {
+		return err
+	}
+	if status == models.UserStatusActive {
+		return nil
+	}
+	if err := srv.tokenSrv.RevokeAllRefreshTokens(userID); err != nil {
+		return err
+	}
+	return srv.revocationSrv.RevokeUser(userId)
+}
+
+// SetUserRole changes the role of a user. The access tokens of the user are revoked, the next
+// one is issued with the new role when the session is refreshed.
+func (srv *AdminService) SetUserRole(actorId, userId, role string) error {
+	actorID, userID, err := srv.authorizeChange(actorId, userId)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains([]string{models.RoleUser, models.RoleModerator, models.RoleAdmin}, role) {
+		return fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+	entry := &models.AuditEntry{ActorID: actorID, Action: AuditSetUserRole, Target: userId, Details: role}
+	if err := srv.model.SetRole(entry, userID, role); err != nil {
+		return err
+	}
+	return srv.revocationSrv.RevokeUser(userId)
+}
+
+// RecordAction writes an action a moderator took in another service to the audit log.
+func (srv *AdminService) RecordAction(actorId, action, target, details string) error {
+	actorID, err := srv.Authorize(actorId, models.RoleModerator)
+	if err != nil {
+		return err
+	}
+	return srv.model.InsertAudit(&models.AuditEntry{ActorID: actorID, Action: action, Target: target, Details: details})
+}
+
+func (srv *AdminService) ListAuditLog(actorId string, limit, offset int) ([]*models.AuditEntry, error) {
+	if _, err := srv.Authorize(actorId, models.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return srv.model.ListAudit(pageSize(limit), max(offset, 0))
+}
+
+// authorizeChange checks that an admin changes the account of another user, so the last admin
+// cannot lock themselves out.
+func (srv *AdminService) authorizeChange(actorId, userId string) (uuid.UUID, uuid.UUID, error) {
+	actorID, err := srv.Authorize(actorId, models.RoleAdmin)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, models.ErrInvalidUUID
+	}
+	if userID == actorID {
+		return uuid.Nil, uuid.Nil, ErrSelfModeration
+	}
+	return actorID, userID, nil
+}
+
+func auditDetails(value, reason string) string {
+	if reason == "" {
+		return value
+	}
+	return value + ": " + reason
+}
+
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return defaultAdminPageSize
+	}
+	return min(limit, maxAdminPageSize)
+}
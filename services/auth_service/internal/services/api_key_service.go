@@ -0,0 +1,94 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/validation"
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyPrefix marks personal access tokens, so leaked ones are easy to find by secret scanners.
+	apiKeyPrefix = "tnp_"
+	// defaultAPIKeyLifetime is used when a key is created without an expiry.
+	defaultAPIKeyLifetime = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidAPIKey is returned for API keys that are unknown, revoked or expired.
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrAPIKeyValidation is returned when a key is created with a bad name, scopes or expiry.
+	ErrAPIKeyValidation = errors.New("API key validation failed")
+)
+
+type APIKeyService struct {
+	model *models.APIKeyModel
+}
+
+func NewAPIKeyService(model *models.APIKeyModel) *APIKeyService {
+	return &APIKeyService{model: model}
+}
+
+// CreateAPIKey issues a new key and returns its token. The token cannot be recovered later,
+// only its hash is stored.
+func (srv *APIKeyService) CreateAPIKey(userID uuid.UUID, name string, scopes []string, expiry time.Time) (string, *models.APIKey, error) {
+	if expiry.IsZero() {
+		expiry = time.Now().Add(defaultAPIKeyLifetime)
+	}
+	key := &models.APIKey{
+		ID:     uuid.New(),
+		UserID: userID,
+		Name:   name,
+		Scopes: slices.Compact(slices.Sorted(slices.Values(scopes))),
+		Expiry: expiry,
+	}
+	if err := validation.ValidateAPIKey(key); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrAPIKeyValidation, err)
+	}
+
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	token := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(randomBytes)
+	key.Hash = hashToken(token)
+
+	if err := srv.model.Insert(key); err != nil {
+		return "", nil, err
+	}
+	return token, key, nil
+}
+
+func (srv *APIKeyService) ListAPIKeys(userID uuid.UUID) ([]*models.APIKey, error) {
+	return srv.model.GetForUser(userID)
+}
+
+func (srv *APIKeyService) RevokeAPIKey(id, userID uuid.UUID) error {
+	return srv.model.Delete(id, userID)
+}
+
+// AuthenticateAPIKey returns the key a token belongs to and records that it was used.
+func (srv *APIKeyService) AuthenticateAPIKey(token string) (*models.APIKey, error) {
+	key, err := srv.model.Use(hashToken(token))
+	if err != nil {
+		if errors.Is(err, models.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// hashToken returns the SHA-256 of a token handed out to users, only the hash is stored.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
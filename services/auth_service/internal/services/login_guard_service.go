@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLoginThrottled = errors.New("too many failed login attempts")
+	ErrAccountLocked  = errors.New("account is temporarily locked")
+)
+
+// LoginGuard protects password logins against guessing. Every failure of an account or an IP
+// after the free attempts doubles the time until the next login is accepted, and reaching the
+// lockout threshold blocks it for the lockout duration.
+type LoginGuard struct {
+	model *models.LoginAttemptModel
+	cfg   config.LoginProtectionConfig
+}
+
+func NewLoginGuard(cfg *config.LoginProtectionConfig, model *models.LoginAttemptModel) *LoginGuard {
+	return &LoginGuard{model: model, cfg: *cfg}
+}
+
+// Check returns ErrAccountLocked or ErrLoginThrottled with the time left when a login of the
+// account from the IP is not accepted yet.
+func (g *LoginGuard) Check(email, ip string) (time.Duration, error) {
+	throttles, err := g.model.GetBlocked(email, ip)
+	if err != nil {
+		return 0, err
+	}
+	var wait time.Duration
+	var locked bool
+	for _, throttle := range throttles {
+		wait = max(wait, time.Until(throttle.BlockedUntil))
+		if throttle.Kind == models.ThrottleAccount && throttle.Failures >= g.cfg.LockoutThreshold {
+			locked = true
+		}
+	}
+	switch {
+	case locked:
+		return wait, ErrAccountLocked
+	case wait > 0:
+		return wait, ErrLoginThrottled
+	default:
+		return 0, nil
+	}
+}
+
+// Failed records a failed login. It returns when the account is locked until, if this failure
+// locked an existing account, so its owner can be told.
+func (g *LoginGuard) Failed(email, ip string) (time.Time, error) {
+	failure, err := g.model.RecordFailure(email, ip, time.Now().Add(-g.cfg.Window))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now()
+	// Logins that did not come through the gateway have no IP to throttle
+	if ip == "" {
+		failure.IPFailures = 0
+	}
+	if delay := backoff(failure.IPFailures, g.cfg.IPFreeAttempts, g.cfg.IPLockoutThreshold, g.cfg); delay > 0 {
+		if err := g.model.Block(models.ThrottleIP, ip, now.Add(delay)); err != nil {
+			return time.Time{}, err
+		}
+	}
+	delay := backoff(failure.AccountFailures, g.cfg.FreeAttempts, g.cfg.LockoutThreshold, g.cfg)
+	if delay == 0 {
+		return time.Time{}, nil
+	}
+	lockedUntil := now.Add(delay)
+	if err := g.model.Block(models.ThrottleAccount, email, lockedUntil); err != nil {
+		return time.Time{}, err
+	}
+	if failure.UserID == nil || failure.AccountFailures != g.cfg.LockoutThreshold {
+		return time.Time{}, nil
+	}
+	return lockedUntil, nil
+}
+
+// Succeeded records a successful login and clears the failures of the account.
+func (g *LoginGuard) Succeeded(userId, email, ip string) error {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return models.ErrInvalidUUID
+	}
+	return g.model.RecordSuccess(userID, email, ip)
+}
+
+// Unlock lets a user log in again right away, e.g. after resetting the password.
+func (g *LoginGuard) Unlock(userID uuid.UUID) error {
+	return g.model.Unlock(userID)
+}
+
+// backoff returns how long logins are blocked after the given number of failures.
+func backoff(failures, free, lockout int, cfg config.LoginProtectionConfig) time.Duration {
+	if failures >= lockout {
+		return cfg.LockoutDuration
+	}
+	if failures <= free {
+		return 0
+	}
+	// Large exponents overflow, they would be capped anyway
+	delay := cfg.BaseDelay << min(failures-free-1, 30)
+	if delay <= 0 || delay > cfg.MaxDelay {
+		return cfg.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	cfg := config.LoginProtectionConfig{
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutDuration: time.Hour,
+	}
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 1, expected: 0},
+		{failures: 3, expected: 0},
+		{failures: 4, expected: time.Second},
+		{failures: 5, expected: 2 * time.Second},
+		{failures: 7, expected: 8 * time.Second},
+		{failures: 9, expected: 32 * time.Second},
+		{failures: 10, expected: time.Hour},
+		{failures: 12, expected: time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, backoff(tt.failures, 3, 10, cfg), "failures: %d", tt.failures)
+	}
+	// Counts far past the free attempts must not overflow into a short delay
+	assert.Equal(t, time.Minute, backoff(500, 3, 1000, cfg))
+}
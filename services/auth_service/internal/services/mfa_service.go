@@ -0,0 +1,231 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpIssuer = "TextNest"
+	// totpPeriod is the lifetime of a code in seconds, codes of the neighbouring periods are
+	// accepted as well to allow for clock drift.
+	totpPeriod = 30
+	// recoveryCodeCount is the number of one-time codes issued when an authenticator is confirmed.
+	recoveryCodeCount = 10
+	// maxChallengeAttempts limits the codes that can be tried with a single login.
+	maxChallengeAttempts = 5
+)
+
+var (
+	ErrInvalidMFACode      = errors.New("invalid MFA code")
+	ErrMFANotEnrolled      = errors.New("MFA enrollment was not started")
+	ErrMFANotEnabled       = errors.New("MFA is not enabled")
+	ErrInvalidMFAChallenge = errors.New("invalid MFA challenge")
+)
+
+// MFAService manages TOTP authenticators and the second step of logins that require one.
+type MFAService struct {
+	model    *models.MFAModel
+	tokenSrv *TokenService
+}
+
+func NewMFAService(model *models.MFAModel, tokenSrv *TokenService) *MFAService {
+	return &MFAService{model: model, tokenSrv: tokenSrv}
+}
+
+// EnrollTOTP generates a new secret for the user and returns it with its otpauth:// provisioning
+// URI, which authenticator apps read from a QR code. The secret is only enforced once a code
+// generated from it is confirmed with ConfirmTOTP.
+func (srv *MFAService) EnrollTOTP(userID uuid.UUID) (string, string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	encodedSecret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+	email, err := srv.model.SetPendingTOTP(userID, encodedSecret)
+	if err != nil {
+		return "", "", err
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: email,
+		Period:      totpPeriod,
+		Secret:      secret,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build provisioning URI: %w", err)
+	}
+	return encodedSecret, key.URL(), nil
+}
+
+// ConfirmTOTP enables the pending secret when code matches it and returns the recovery codes.
+// They are shown once, only their hashes are stored.
+func (srv *MFAService) ConfirmTOTP(userID uuid.UUID, code string) ([]string, error) {
+	secret, enabled, err := srv.model.GetTOTP(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, models.ErrMFAEnabled
+	}
+	if secret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+	if err := srv.verifyTOTP(userID, secret, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	if err := srv.model.EnableTOTP(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP removes the authenticator of the user, it has to be confirmed with a current
+// code or a recovery code.
+func (srv *MFAService) DisableTOTP(userID uuid.UUID, code string) error {
+	if err := srv.VerifyCode(userID, code); err != nil {
+		return err
+	}
+	return srv.model.DisableTOTP(userID)
+}
+
+// VerifyCode accepts a TOTP code of the enabled authenticator or an unused recovery code.
+func (srv *MFAService) VerifyCode(userID uuid.UUID, code string) error {
+	secret, enabled, err := srv.model.GetTOTP(userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrMFANotEnabled
+	}
+	code = strings.TrimSpace(code)
+	if len(code) == int(otp.DigitsSix) {
+		return srv.verifyTOTP(userID, secret, code)
+	}
+	used, err := srv.model.UseRecoveryCode(userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// Required reports whether logins of the user need a second factor.
+func (srv *MFAService) Required(userId string) (bool, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return false, models.ErrInvalidUUID
+	}
+	_, enabled, err := srv.model.GetTOTP(userID)
+	return enabled, err
+}
+
+// StartChallenge is called after the password of a user with MFA was checked. It returns the
+// short-lived token the second step of the login is answered with.
+func (srv *MFAService) StartChallenge(userId string) (string, time.Time, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return "", time.Time{}, models.ErrInvalidUUID
+	}
+	jti := uuid.New()
+	token, expiresAt, err := srv.tokenSrv.IssueMFAChallenge(userId, jti)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := srv.model.InsertChallenge(jti, userID, expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// CompleteChallenge checks the code for a challenge and returns the user the login belongs to.
+// A challenge can be answered once and only tried a few times.
+func (srv *MFAService) CompleteChallenge(token, code string) (string, error) {
+	jti, err := srv.tokenSrv.ParseMFAChallenge(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidMFAChallenge, err)
+	}
+	userID, err := srv.model.AttemptChallenge(jti, maxChallengeAttempts)
+	if err != nil {
+		if errors.Is(err, models.ErrTokenRevoked) {
+			return "", fmt.Errorf("%w: challenge is answered, expired or out of attempts", ErrInvalidMFAChallenge)
+		}
+		return "", err
+	}
+	if err := srv.VerifyCode(userID, code); err != nil {
+		return "", err
+	}
+	if err := srv.model.CompleteChallenge(jti); err != nil {
+		return "", err
+	}
+	return userID.String(), nil
+}
+
+// verifyTOTP accepts codes of the current and the neighbouring periods. Every period can only
+// be used once, later codes invalidate earlier ones.
+func (srv *MFAService) verifyTOTP(userID uuid.UUID, secret, code string) error {
+	now := time.Now()
+	for skew := -1; skew <= 1; skew++ {
+		at := now.Add(time.Duration(skew*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate TOTP code: %w", err)
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+		fresh, err := srv.model.UseTOTPStep(userID, at.Unix()/totpPeriod)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+	return ErrInvalidMFACode
+}
+
+// generateRecoveryCode returns a code like "k3j9d-2mxq7".
+func generateRecoveryCode() (string, error) {
+	randomBytes := make([]byte, 7)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(randomBytes))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func hashRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(code)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// oidcLoginExpiry is how long a user has to sign in at the provider.
+const oidcLoginExpiry = 10 * time.Minute
+
+var (
+	ErrUnknownProvider   = errors.New("unknown identity provider")
+	ErrInvalidOIDCLogin  = errors.New("invalid OIDC login")
+	ErrUnverifiedEmail   = errors.New("email is not verified by the identity provider")
+	errMissingIDToken    = errors.New("token response has no id_token")
+	errNonceMismatch     = errors.New("id_token nonce does not match the login")
+	errMissingOIDCClaims = errors.New("id_token has no subject or email")
+)
+
+// oidcProvider is discovered on first use, so the auth service starts while a provider is down.
+type oidcProvider struct {
+	cfg      config.OIDCProviderConfig
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// oidcClaims are the ID token claims TextNest uses.
+type oidcClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// OIDCService signs users in with external identity providers, using the authorization code flow
+// with PKCE. Accounts are linked to users by their verified email.
+type OIDCService struct {
+	providers map[string]*oidcProvider
+	model     *models.IdentityModel
+}
+
+func NewOIDCService(cfgs []config.OIDCProviderConfig, model *models.IdentityModel) *OIDCService {
+	providers := make(map[string]*oidcProvider, len(cfgs))
+	for _, cfg := range cfgs {
+		providers[cfg.Name] = &oidcProvider{cfg: cfg}
+	}
+	return &OIDCService{providers: providers, model: model}
+}
+
+// StartLogin returns the URL to send the user to and the state the callback will carry.
+func (srv *OIDCService) StartLogin(ctx context.Context, providerName string) (string, string, error) {
+	oauthCfg, _, err := srv.provider(ctx, providerName)
+	if err != nil {
+		return "", "", err
+	}
+	state, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	login := &models.LoginState{
+		State:        state,
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Expiry:       time.Now().Add(oidcLoginExpiry),
+	}
+	if err := srv.model.InsertState(login); err != nil {
+		return "", "", err
+	}
+	url := oauthCfg.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(login.CodeVerifier))
+	return url, state, nil
+}
+
+// CompleteLogin exchanges the code of a callback and returns the user the account belongs to.
+func (srv *OIDCService) CompleteLogin(ctx context.Context, providerName, state, code string) (string, error) {
+	login, err := srv.model.TakeState(state)
+	if err != nil {
+		if errors.Is(err, models.ErrRecordNotFound) {
+			return "", fmt.Errorf("%w: unknown or expired state", ErrInvalidOIDCLogin)
+		}
+		return "", err
+	}
+	if login.Provider != providerName {
+		return "", fmt.Errorf("%w: state belongs to another provider", ErrInvalidOIDCLogin)
+	}
+	oauthCfg, verifier, err := srv.provider(ctx, providerName)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := exchangeCode(ctx, oauthCfg, verifier, code, login)
+	if err != nil {
+		return "", err
+	}
+	if !claims.EmailVerified {
+		return "", ErrUnverifiedEmail
+	}
+
+	// The password hash only fills the column, nobody knows the password it was made from
+	var unusable models.User
+	password, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	if err := unusable.Password.Set(password); err != nil {
+		return "", err
+	}
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+	userID, err := srv.model.LinkUser(&models.Identity{
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+		Name:     name,
+	}, unusable.Password.Hash)
+	if err != nil {
+		return "", err
+	}
+	return userID.String(), nil
+}
+
+func (srv *OIDCService) provider(ctx context.Context, name string) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p, ok := srv.providers[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	discovered, err := oidc.NewProvider(ctx, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover identity provider %s: %w", name, err)
+	}
+	scopes := []string{oidc.ScopeOpenID, "email", "profile"}
+	for _, scope := range p.cfg.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     discovered.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = discovered.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth, p.verifier, nil
+}
+
+// exchangeCode redeems an authorization code with its PKCE verifier and checks the ID token
+// against the login it was started with.
+func exchangeCode(ctx context.Context, oauthCfg *oauth2.Config, verifier *oidc.IDTokenVerifier, code string, login *models.LoginState) (*oidcClaims, error) {
+	token, err := oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(login.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: code exchange failed: %v", ErrInvalidOIDCLogin, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOIDCLogin, errMissingIDToken)
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOIDCLogin, err)
+	}
+	if idToken.Nonce != login.Nonce {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOIDCLogin, errNonceMismatch)
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOIDCLogin, err)
+	}
+	if claims.Subject == "" || claims.Email == "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOIDCLogin, errMissingOIDCClaims)
+	}
+	return &claims, nil
+}
+
+func randomToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
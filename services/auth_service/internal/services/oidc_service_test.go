@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOIDCProvider is an identity provider that issues an ID token for a single authorization code.
+type mockOIDCProvider struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	code          string
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &mockOIDCProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.server.URL,
+			"authorization_endpoint":                p.server.URL + "/authorize",
+			"token_endpoint":                        p.server.URL + "/token",
+			"jwks_uri":                              p.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != p.code || base64.RawURLEncoding.EncodeToString(verifier[:]) != p.codeChallenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// authorize prepares the code the provider returns for a login started with verifier and nonce.
+func (p *mockOIDCProvider) authorize(verifier, nonce string, claims jwt.MapClaims) {
+	challenge := sha256.Sum256([]byte(verifier))
+	p.code = "code-" + nonce
+	p.codeChallenge = base64.RawURLEncoding.EncodeToString(challenge[:])
+	p.claims = jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   "textnest",
+		"sub":   "user-1",
+		"email": "user@example.com",
+		"nonce": nonce,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+	}
+	for claim, value := range claims {
+		p.claims[claim] = value
+	}
+}
+
+func TestExchangeCode(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+	srv := NewOIDCService([]config.OIDCProviderConfig{{
+		Name:        "mock",
+		Issuer:      provider.server.URL,
+		ClientID:    "textnest",
+		RedirectURL: "http://localhost/v1/users/sso/mock/callback",
+	}}, nil)
+	ctx := context.Background()
+	oauthCfg, verifier, err := srv.provider(ctx, "mock")
+	require.NoError(t, err)
+
+	login := &models.LoginState{Provider: "mock", Nonce: "nonce", CodeVerifier: "verifier-of-at-least-43-characters-aaaaaaaaa"}
+
+	t.Run("valid login", func(t *testing.T) {
+		provider.authorize(login.CodeVerifier, login.Nonce, jwt.MapClaims{"email_verified": true, "name": "User"})
+		claims, err := exchangeCode(ctx, oauthCfg, verifier, provider.code, login)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, "user@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "User", claims.Name)
+	})
+
+	t.Run("unverified email is reported", func(t *testing.T) {
+		provider.authorize(login.CodeVerifier, login.Nonce, nil)
+		claims, err := exchangeCode(ctx, oauthCfg, verifier, provider.code, login)
+		require.NoError(t, err)
+		assert.False(t, claims.EmailVerified)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		provider.authorize("another-verifier-of-at-least-43-characters-aa", login.Nonce, nil)
+		_, err := exchangeCode(ctx, oauthCfg, verifier, provider.code, login)
+		assert.ErrorIs(t, err, ErrInvalidOIDCLogin)
+	})
+
+	t.Run("replayed nonce", func(t *testing.T) {
+		provider.authorize(login.CodeVerifier, "another-nonce", nil)
+		_, err := exchangeCode(ctx, oauthCfg, verifier, provider.code, login)
+		assert.ErrorIs(t, err, ErrInvalidOIDCLogin)
+	})
+
+	t.Run("token for another client", func(t *testing.T) {
+		provider.authorize(login.CodeVerifier, login.Nonce, jwt.MapClaims{"aud": "another-client"})
+		_, err := exchangeCode(ctx, oauthCfg, verifier, provider.code, login)
+		assert.ErrorIs(t, err, ErrInvalidOIDCLogin)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, _, err := srv.provider(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrUnknownProvider)
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
+)
+
+// UserRevokedTopic carries the users whose access tokens must no longer be accepted.
+const UserRevokedTopic = "user-revoked"
+
+// UserRevoked is the message published to UserRevokedTopic. Access tokens of the user issued
+// before RevokedAt are rejected by the API gateways, only those of the session when SessionID is set.
+type UserRevoked struct {
+	UserID    string    `json:"user_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// RevocationService tells the API gateways that the sessions of a user ended. Access tokens are
+// verified by the gateways themselves and would otherwise stay valid until they expire.
+type RevocationService struct {
+	producer *kafka.KafkaProducer // nil when Kafka is not configured
+}
+
+func NewRevocationService(producer *kafka.KafkaProducer) *RevocationService {
+	return &RevocationService{producer: producer}
+}
+
+func (srv *RevocationService) RevokeUser(userID string) error {
+	return srv.publish(UserRevoked{UserID: userID, RevokedAt: time.Now()})
+}
+
+// RevokeSession ends a single session of a user, the other sessions keep working.
+func (srv *RevocationService) RevokeSession(userID, sessionID string) error {
+	return srv.publish(UserRevoked{UserID: userID, SessionID: sessionID, RevokedAt: time.Now()})
+}
+
+func (srv *RevocationService) publish(revocation UserRevoked) error {
+	if srv.producer == nil {
+		return nil
+	}
+	message, err := json.Marshal(revocation)
+	if err != nil {
+		return fmt.Errorf("failed to encode revocation: %w", err)
+	}
+	if err := srv.producer.ProduceMessages(string(message), UserRevokedTopic); err != nil {
+		return fmt.Errorf("failed to publish revocation: %w", err)
+	}
+	return nil
+}
+
+// UserDeletedTopic carries the users who deleted their account, the other services purge their data.
+const UserDeletedTopic = "user-deleted"
+
+// UserDeleted is the message published to UserDeletedTopic.
+type UserDeleted struct {
+	UserID    string    `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// DeleteUser ends the sessions of a deleted user and announces the deletion.
+func (srv *RevocationService) DeleteUser(userID string) error {
+	if err := srv.RevokeUser(userID); err != nil {
+		return err
+	}
+	if srv.producer == nil {
+		return nil
+	}
+	message, err := json.Marshal(UserDeleted{UserID: userID, DeletedAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to encode user deletion: %w", err)
+	}
+	if err := srv.producer.ProduceMessages(string(message), UserDeletedTopic); err != nil {
+		return fmt.Errorf("failed to publish user deletion: %w", err)
+	}
+	return nil
+}
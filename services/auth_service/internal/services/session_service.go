@@ -0,0 +1,61 @@
+package services
+
+import (
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/google/uuid"
+)
+
+// SessionService tracks the logins of users. A session lives as long as its refresh token
+// family, logging out, reusing a refresh token or revoking the session ends it.
+type SessionService struct {
+	model *models.SessionModel
+}
+
+func NewSessionService(model *models.SessionModel) *SessionService {
+	return &SessionService{model: model}
+}
+
+// Start records a new login of the user and returns the session ID its tokens are issued for.
+func (srv *SessionService) Start(userId, ip, userAgent string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return uuid.Nil, models.ErrInvalidUUID
+	}
+	session := &models.Session{
+		ID:        uuid.New(),
+		UserID:    userID,
+		UserAgent: userAgent,
+		IP:        ip,
+	}
+	if err := srv.model.Insert(session); err != nil {
+		return uuid.Nil, err
+	}
+	return session.ID, nil
+}
+
+// Touch records that the session was refreshed.
+func (srv *SessionService) Touch(sessionID uuid.UUID, ip, userAgent string) error {
+	return srv.model.Touch(sessionID, ip, userAgent)
+}
+
+func (srv *SessionService) List(userID uuid.UUID) ([]*models.Session, error) {
+	return srv.model.ListActive(userID)
+}
+
+// IsActive reports whether an access token issued for the session may still be used.
+func (srv *SessionService) IsActive(sessionId string) (bool, error) {
+	sessionID, err := uuid.Parse(sessionId)
+	if err != nil {
+		return false, models.ErrInvalidUUID
+	}
+	return srv.model.IsActive(sessionID)
+}
+
+// Revoke ends a session of the user, its refresh token stops working.
+func (srv *SessionService) Revoke(userID uuid.UUID, sessionId string) error {
+	sessionID, err := uuid.Parse(sessionId)
+	if err != nil {
+		return models.ErrInvalidUUID
+	}
+	return srv.model.Revoke(userID, sessionID)
+}
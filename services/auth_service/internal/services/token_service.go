@@ -4,40 +4,52 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/NesterovYehor/textnest/services/auth_service/config"
+	"github.com/NesterovYehor/textnest/services/auth_service/internal/keys"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/validation"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// ErrInvalidRefreshToken is returned for refresh tokens that are malformed, expired or badly signed.
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
 type TokenService struct {
-	model     *models.TokenModel
-	jwtConfig *config.JwtConfig
+	model        *models.TokenModel
+	refreshModel *models.RefreshTokenModel
+	jwtConfig    *config.JwtConfig
+	keys         *keys.KeyManager // Signs access tokens with asymmetric signing methods, nil for HMAC
 }
 
-func NewTokenService(jwtCfg *config.JwtConfig, model *models.TokenModel) *TokenService {
+func NewTokenService(jwtCfg *config.JwtConfig, model *models.TokenModel, refreshModel *models.RefreshTokenModel, keyManager *keys.KeyManager) *TokenService {
 	return &TokenService{
-		jwtConfig: jwtCfg,
-		model:     model,
+		jwtConfig:    jwtCfg,
+		model:        model,
+		refreshModel: refreshModel,
+		keys:         keyManager,
+	}
+}
+
+// JWKS returns the public keys access tokens can be verified with, none for HMAC signing.
+func (srv *TokenService) JWKS() []keys.JSONWebKey {
+	if srv.keys == nil {
+		return nil
 	}
+	return srv.keys.JWKS()
 }
 
 func (srv *TokenService) ExtractUserID(token string, expectedType string) (string, error) {
 	if expectedType != "access" && expectedType != "refresh" {
 		return "", fmt.Errorf("invalid token type: %s", expectedType)
 	}
-	secret := ""
-	if expectedType == "access" {
-		secret = srv.jwtConfig.AccessSecret
-	} else {
-		secret = srv.jwtConfig.RefreshSecret
-	}
+	keyFunc, methods := srv.verificationKey(expectedType)
 	// Validate the token
-	parsedToken, err := validation.ValidateJwtToken(token, secret, expectedType)
+	parsedToken, err := validation.ValidateJwtToken(token, keyFunc, methods, expectedType)
 	if err != nil {
 		return "", fmt.Errorf("invalid token: %v", err)
 	}
@@ -58,10 +70,158 @@ func (srv *TokenService) ExtractUserID(token string, expectedType string) (strin
 	return userID, nil
 }
 
+// GenerateJWTToken issues a stateless token. Refresh tokens have to be tracked and are issued
+// with IssueRefreshToken instead.
 func (srv *TokenService) GenerateJWTToken(
 	userId string,
-	tokenType string, // "access" or "refresh"
+	tokenType string, // "access" or "activate"
 ) (string, time.Time, error) {
+	if tokenType == "refresh" {
+		return "", time.Time{}, fmt.Errorf("refresh tokens must be issued with IssueRefreshToken")
+	}
+	return srv.signJWT(userId, tokenType, nil)
+}
+
+// GenerateAccessToken issues an access token carrying the role of the user, which the API
+// gateways authorize moderation routes with, and the session it was issued for.
+func (srv *TokenService) GenerateAccessToken(userId, role, sessionId string) (string, time.Time, error) {
+	return srv.signJWT(userId, "access", jwt.MapClaims{"role": role, "sid": sessionId})
+}
+
+// ParseAccessToken returns the user and the session of an access token. The session is empty
+// for tokens issued before sessions were tracked.
+func (srv *TokenService) ParseAccessToken(token string) (string, string, error) {
+	keyFunc, methods := srv.verificationKey("access")
+	parsedToken, err := validation.ValidateJwtToken(token, keyFunc, methods, "access")
+	if err != nil {
+		return "", "", fmt.Errorf("invalid token: %v", err)
+	}
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", "", fmt.Errorf("unable to parse token claims")
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return "", "", fmt.Errorf("user_id not found or invalid in token claims")
+	}
+	sessionID, _ := claims["sid"].(string)
+	return userID, sessionID, nil
+}
+
+// IssueRefreshToken starts the token family of a fresh login and returns its first refresh
+// token. The family is the session the login is tracked as.
+func (srv *TokenService) IssueRefreshToken(userId string, sessionID uuid.UUID) (string, time.Time, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return "", time.Time{}, models.ErrInvalidUUID
+	}
+	token := &models.RefreshToken{
+		JTI:      uuid.New(),
+		FamilyID: sessionID,
+		UserID:   userID,
+		Expiry:   time.Now().Add(srv.jwtConfig.RefreshExpiry),
+	}
+	tokenStr, expiresAt, err := srv.signJWT(userId, "refresh", jwt.MapClaims{"jti": token.JTI.String()})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := srv.refreshModel.Insert(token); err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenStr, expiresAt, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one of the same family and returns the
+// stored record of the new token, which names the user and the session. A token can only be
+// exchanged once, presenting it again revokes the family and returns models.ErrTokenReused
+// together with the record, so the caller knows which session ended.
+func (srv *TokenService) RotateRefreshToken(token string) (*models.RefreshToken, string, time.Time, error) {
+	userId, jti, err := srv.parseRefreshToken(token)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	next := &models.RefreshToken{
+		JTI:    uuid.New(),
+		Expiry: time.Now().Add(srv.jwtConfig.RefreshExpiry),
+	}
+	tokenStr, expiresAt, err := srv.signJWT(userId, "refresh", jwt.MapClaims{"jti": next.JTI.String()})
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	if err := srv.refreshModel.Rotate(jti, next); err != nil {
+		if errors.Is(err, models.ErrTokenReused) {
+			return next, "", time.Time{}, err
+		}
+		return nil, "", time.Time{}, err
+	}
+	return next, tokenStr, expiresAt, nil
+}
+
+// RevokeRefreshToken ends the login session the refresh token belongs to and returns the IDs
+// of its user and session.
+func (srv *TokenService) RevokeRefreshToken(token string) (string, string, error) {
+	_, jti, err := srv.parseRefreshToken(token)
+	if err != nil {
+		return "", "", err
+	}
+	sessionID, userID, err := srv.refreshModel.RevokeFamily(jti)
+	if err != nil {
+		return "", "", err
+	}
+	return userID.String(), sessionID.String(), nil
+}
+
+// RevokeAllRefreshTokens ends every login session of the user.
+func (srv *TokenService) RevokeAllRefreshTokens(userID uuid.UUID) error {
+	return srv.refreshModel.RevokeAllForUser(userID)
+}
+
+// IssueMFAChallenge signs the challenge token of a login that still needs its second factor.
+func (srv *TokenService) IssueMFAChallenge(userId string, jti uuid.UUID) (string, time.Time, error) {
+	return srv.signJWT(userId, "mfa", jwt.MapClaims{"jti": jti.String()})
+}
+
+// ParseMFAChallenge validates a challenge token and returns its jti.
+func (srv *TokenService) ParseMFAChallenge(token string) (uuid.UUID, error) {
+	keyFunc, methods := srv.verificationKey("mfa")
+	parsedToken, err := validation.ValidateJwtToken(token, keyFunc, methods, "mfa")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unable to parse token claims")
+	}
+	rawJTI, _ := claims["jti"].(string)
+	return uuid.Parse(rawJTI)
+}
+
+// parseRefreshToken validates a refresh token and returns its user ID and jti.
+func (srv *TokenService) parseRefreshToken(token string) (string, uuid.UUID, error) {
+	keyFunc, methods := srv.verificationKey("refresh")
+	parsedToken, err := validation.ValidateJwtToken(token, keyFunc, methods, "refresh")
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidRefreshToken, err)
+	}
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", uuid.Nil, fmt.Errorf("%w: unable to parse token claims", ErrInvalidRefreshToken)
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return "", uuid.Nil, fmt.Errorf("%w: user_id not found or invalid in token claims", ErrInvalidRefreshToken)
+	}
+	// Refresh tokens issued before they were tracked carry no jti and are no longer accepted
+	rawJTI, _ := claims["jti"].(string)
+	jti, err := uuid.Parse(rawJTI)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("%w: jti not found or invalid in token claims", ErrInvalidRefreshToken)
+	}
+	return userID, jti, nil
+}
+
+func (srv *TokenService) signJWT(userId, tokenType string, extra jwt.MapClaims) (string, time.Time, error) {
 	var expiry time.Duration
 	var secret []byte
 	switch tokenType {
@@ -74,15 +234,29 @@ func (srv *TokenService) GenerateJWTToken(
 	case "activate":
 		expiry = srv.jwtConfig.ActivateExpiry
 		secret = []byte(srv.jwtConfig.ActivateSecret)
+	case "mfa":
+		expiry = srv.jwtConfig.MFAExpiry
+		secret = []byte(srv.jwtConfig.MFASecret)
 	default:
 		return "", time.Time{}, fmt.Errorf("invalid token type: %s", tokenType)
 	}
 	claims := jwt.MapClaims{
 		"user_id": userId,
 		"type":    tokenType,
+		"iat":     time.Now().Unix(),
 		"exp":     time.Now().Add(expiry).Unix(),
 	}
-	token := jwt.NewWithClaims(srv.jwtConfig.SigningMethod, claims)
+	for name, value := range extra {
+		claims[name] = value
+	}
+	if tokenType == "access" && srv.keys != nil {
+		tokenStr, err := srv.keys.Sign(claims)
+		if err != nil {
+			return "", time.Now(), err
+		}
+		return tokenStr, time.Now().Add(expiry), nil
+	}
+	token := jwt.NewWithClaims(srv.hmacMethod(), claims)
 	tokenStr, err := token.SignedString(secret)
 	if err != nil {
 		return "", time.Now(), err
@@ -90,6 +264,36 @@ func (srv *TokenService) GenerateJWTToken(
 	return tokenStr, time.Now().Add(expiry), nil
 }
 
+// verificationKey returns the key lookup and the accepted algorithms for a token type.
+// Access tokens are verified with the key pairs when they are configured, every other
+// token never leaves the auth service and stays signed with its shared secret.
+func (srv *TokenService) verificationKey(tokenType string) (jwt.Keyfunc, []string) {
+	if tokenType == "access" && srv.keys != nil {
+		return srv.keys.Keyfunc, []string{srv.keys.Method()}
+	}
+	var secret string
+	switch tokenType {
+	case "access":
+		secret = srv.jwtConfig.AccessSecret
+	case "refresh":
+		secret = srv.jwtConfig.RefreshSecret
+	case "mfa":
+		secret = srv.jwtConfig.MFASecret
+	default:
+		secret = srv.jwtConfig.ActivateSecret
+	}
+	return func(*jwt.Token) (any, error) { return []byte(secret), nil }, []string{srv.hmacMethod().Alg()}
+}
+
+// hmacMethod is the configured signing method when it is HMAC, and HS256 for the tokens
+// signed with shared secrets when access tokens use key pairs.
+func (srv *TokenService) hmacMethod() jwt.SigningMethod {
+	if method, ok := srv.jwtConfig.SigningMethod.(*jwt.SigningMethodHMAC); ok {
+		return method
+	}
+	return jwt.SigningMethodHS256
+}
+
 func (srv *TokenService) GenerateSecureToken(userID *uuid.UUID) (string, error) {
 	randomBytes := make([]byte, 16)
 	if _, err := rand.Read(randomBytes); err != nil {
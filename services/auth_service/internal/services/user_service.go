@@ -40,25 +40,29 @@ func (srv *UserService) CreateNewUser(name, email, password string) (*uuid.UUID,
 	return userID, nil
 }
 
-func (srv *UserService) AuthenticateUserByEmail(email, passwordPlaintext string) (string, error) {
+// AuthenticateUserByEmail checks the password of a user and reports whether the login needs a second factor.
+func (srv *UserService) AuthenticateUserByEmail(email, passwordPlaintext string) (string, bool, error) {
 	user, err := srv.model.GetByEmail(email)
 	if err != nil {
-		return "", srv.handleErr(err)
+		return "", false, srv.handleErr(err)
 	}
 
 	matches, err := user.Password.Match(passwordPlaintext)
 	if err != nil {
-		return "", srv.handleErr(err)
+		return "", false, srv.handleErr(err)
 	}
 	if !user.Activated {
-		return "", errors.New("User is not activated")
+		return "", false, errors.New("User is not activated")
+	}
+	if user.BannedAt != nil {
+		return "", false, errors.New("User is banned")
 	}
 
 	if !matches {
-		return "", errors.New("invalid credentials")
+		return "", false, errors.New("invalid credentials")
 	}
 
-	return user.ID.String(), nil
+	return user.ID.String(), user.MFAEnabled, nil
 }
 
 func (srv *UserService) ValidateUserByEmail(email string) (*uuid.UUID, error) {
@@ -89,6 +93,19 @@ func (srv *UserService) UserExists(userId string) (bool, error) {
 	return res, nil
 }
 
+// GetAccess returns the role of a user and whether the user is activated and not banned.
+func (srv *UserService) GetAccess(userId string) (string, bool, error) {
+	id, err := uuid.Parse(userId)
+	if err != nil {
+		return "", false, models.ErrInvalidUUID
+	}
+	role, active, err := srv.model.GetAccess(id)
+	if err != nil {
+		return "", false, srv.handleErr(err)
+	}
+	return role, active, nil
+}
+
 func (srv *UserService) ActivateUser(hash string) error {
 	if err := srv.model.ActivateUser(hash); err != nil {
 		return srv.handleErr(err)
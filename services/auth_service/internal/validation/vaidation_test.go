@@ -2,6 +2,7 @@ package validation_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/validation"
@@ -82,3 +83,35 @@ func TestValidateUser(t *testing.T) {
 	err = validation.ValidateUser(user)
 	assert.NoError(t, err, "User with valid hash but no plaintext password should not return an error")
 }
+
+func TestValidateAPIKey(t *testing.T) {
+	// Valid key
+	key := &models.APIKey{
+		Name:   "CI",
+		Scopes: []string{models.ScopePasteRead, models.ScopePasteWrite},
+		Expiry: time.Now().Add(24 * time.Hour),
+	}
+	err := validation.ValidateAPIKey(key)
+	assert.NoError(t, err, "Valid key should not return an error")
+
+	// Key with unknown scope
+	key.Scopes = []string{"paste:admin"}
+	err = validation.ValidateAPIKey(key)
+	assert.EqualError(t, err, `unknown scope "paste:admin"`, "Key with unknown scope should return the correct error message")
+
+	// Key without scopes
+	key.Scopes = nil
+	err = validation.ValidateAPIKey(key)
+	assert.EqualError(t, err, "scopes are not provided", "Key without scopes should return the correct error message")
+
+	// Key expiring in more than a year
+	key.Scopes = []string{models.ScopePasteDelete}
+	key.Expiry = time.Now().AddDate(2, 0, 0)
+	err = validation.ValidateAPIKey(key)
+	assert.EqualError(t, err, "expiry is more than a year away", "Key with a distant expiry should return the correct error message")
+
+	// Key that already expired
+	key.Expiry = time.Now().Add(-time.Minute)
+	err = validation.ValidateAPIKey(key)
+	assert.EqualError(t, err, "expiry is in the past", "Expired key should return the correct error message")
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/validator"
@@ -60,10 +61,10 @@ func ValidateUser(user *models.User) error {
 	return nil
 }
 
-func ValidateJwtToken(tokenString, secret, expectedType string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+// ValidateJwtToken parses a token signed with one of methods, keyFunc returns the key to verify it with.
+// Tokens with any other algorithm are rejected, so an HMAC token can never be verified with a public key.
+func ValidateJwtToken(tokenString string, keyFunc jwt.Keyfunc, methods []string, expectedType string) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, keyFunc, jwt.WithValidMethods(methods))
 	if err != nil {
 		return nil, err
 	}
@@ -92,3 +93,28 @@ func ValidatePasswordResetToken(token *models.Token) error {
 	}
 	return nil
 }
+
+// ValidateAPIKey validates a new API key. Keys have to expire within a year.
+func ValidateAPIKey(key *models.APIKey) error {
+	if key.Name == "" {
+		return errors.New("name is not provided")
+	}
+	if len([]rune(key.Name)) > 100 {
+		return errors.New("name is longer than 100 characters")
+	}
+	if len(key.Scopes) == 0 {
+		return errors.New("scopes are not provided")
+	}
+	for _, scope := range key.Scopes {
+		if !slices.Contains(models.APIKeyScopes, scope) {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	if key.Expiry.Before(time.Now()) {
+		return errors.New("expiry is in the past")
+	}
+	if key.Expiry.After(time.Now().AddDate(1, 0, 0)) {
+		return errors.New("expiry is more than a year away")
+	}
+	return nil
+}
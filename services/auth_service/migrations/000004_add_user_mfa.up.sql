@@ -0,0 +1,19 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret TEXT,
+    ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    used_at TIMESTAMP(0) WITH TIME ZONE,
+    PRIMARY KEY (user_id, hash)
+);
+
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    jti UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP(0) WITH TIME ZONE
+);
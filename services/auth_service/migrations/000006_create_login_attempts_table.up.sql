@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    email citext NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ip TEXT NOT NULL,
+    succeeded BOOLEAN NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_email_idx ON login_attempts (email, created_at);
+CREATE INDEX IF NOT EXISTS login_attempts_ip_idx ON login_attempts (ip, created_at);
+
+CREATE TABLE IF NOT EXISTS login_throttles (
+    kind TEXT NOT NULL,
+    subject citext NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    blocked_until TIMESTAMP(0) WITH TIME ZONE,
+    PRIMARY KEY (kind, subject)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS admin_audit_log;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS banned_at,
+    DROP COLUMN IF EXISTS role;
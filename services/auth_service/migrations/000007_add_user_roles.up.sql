@@ -0,0 +1,16 @@
+-- Roles are granted by admins, the first admin is promoted by hand:
+-- UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin')),
+    ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP(0) WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id UUID NOT NULL REFERENCES users(id),
+    action TEXT NOT NULL,
+    target TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_created_at_idx ON admin_audit_log (created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE admin_audit_log
+    ADD CONSTRAINT admin_audit_log_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) NOT VALID;
+
+DROP TABLE IF EXISTS email_changes;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS email_changes (
+    hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email citext NOT NULL,
+    expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS email_changes_user_id_idx ON email_changes (user_id);
+
+-- The audit log outlives the accounts of moderators and admins who delete themselves
+ALTER TABLE admin_audit_log DROP CONSTRAINT IF EXISTS admin_audit_log_actor_id_fkey;
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/NesterovYehor/textnest/services/auth_service/internal/models"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,3 +94,433 @@ func TestInsertToken(t *testing.T) {
 	token.UserID = *userID
 	assert.NoError(t, tokensModel.Insert(&token))
 }
+
+func TestRotateRefreshToken(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false
+        );
+        CREATE TABLE IF NOT EXISTS refresh_tokens (
+            jti UUID PRIMARY KEY,
+            family_id UUID NOT NULL,
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+            created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            used_at TIMESTAMP(0) WITH TIME ZONE,
+            revoked_at TIMESTAMP(0) WITH TIME ZONE
+        );`
+	conn, cleanup := PreparePostgres(ctx, "refresh_tokens", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	refreshModel := models.NewRefreshTokenModel(conn)
+	owner := models.User{Name: "refresh", Email: "refresh@email"}
+	assert.NoError(t, owner.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&owner)
+	assert.NoError(t, err)
+
+	first := &models.RefreshToken{JTI: uuid.New(), FamilyID: uuid.New(), UserID: *userID, Expiry: time.Now().Add(time.Hour)}
+	assert.NoError(t, refreshModel.Insert(first))
+
+	// Rotating moves the family to the new token
+	second := &models.RefreshToken{JTI: uuid.New(), Expiry: time.Now().Add(time.Hour)}
+	assert.NoError(t, refreshModel.Rotate(first.JTI, second))
+	assert.Equal(t, first.FamilyID, second.FamilyID)
+	assert.Equal(t, *userID, second.UserID)
+
+	// Replaying the used token revokes the whole family, including the token rotated from it
+	replay := &models.RefreshToken{JTI: uuid.New(), Expiry: time.Now().Add(time.Hour)}
+	assert.ErrorIs(t, refreshModel.Rotate(first.JTI, replay), models.ErrTokenReused)
+	assert.Equal(t, first.FamilyID, replay.FamilyID)
+	assert.Equal(t, *userID, replay.UserID)
+	third := &models.RefreshToken{JTI: uuid.New(), Expiry: time.Now().Add(time.Hour)}
+	assert.ErrorIs(t, refreshModel.Rotate(second.JTI, third), models.ErrTokenRevoked)
+
+	// Other logins of the user are revoked by RevokeAllForUser only
+	other := &models.RefreshToken{JTI: uuid.New(), FamilyID: uuid.New(), UserID: *userID, Expiry: time.Now().Add(time.Hour)}
+	assert.NoError(t, refreshModel.Insert(other))
+	assert.NoError(t, refreshModel.RevokeAllForUser(*userID))
+	assert.ErrorIs(t, refreshModel.Rotate(other.JTI, third), models.ErrTokenRevoked)
+
+	assert.ErrorIs(t, refreshModel.Rotate(uuid.New(), third), models.ErrRecordNotFound)
+
+	// Logging out names the session that ended
+	loggedIn := &models.RefreshToken{JTI: uuid.New(), FamilyID: uuid.New(), UserID: *userID, Expiry: time.Now().Add(time.Hour)}
+	assert.NoError(t, refreshModel.Insert(loggedIn))
+	familyID, ownerID, err := refreshModel.RevokeFamily(loggedIn.JTI)
+	assert.NoError(t, err)
+	assert.Equal(t, loggedIn.FamilyID, familyID)
+	assert.Equal(t, *userID, ownerID)
+	_, _, err = refreshModel.RevokeFamily(loggedIn.JTI)
+	assert.ErrorIs(t, err, models.ErrRecordNotFound)
+}
+
+func TestMFAChallengeAndRecoveryCodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false,
+            totp_secret TEXT,
+            totp_enabled BOOLEAN NOT NULL DEFAULT false,
+            totp_last_step BIGINT
+        );
+        CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            hash TEXT NOT NULL,
+            used_at TIMESTAMP(0) WITH TIME ZONE,
+            PRIMARY KEY (user_id, hash)
+        );
+        CREATE TABLE IF NOT EXISTS mfa_challenges (
+            jti UUID PRIMARY KEY,
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            attempts INT NOT NULL DEFAULT 0,
+            expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+            used_at TIMESTAMP(0) WITH TIME ZONE
+        );`
+	conn, cleanup := PreparePostgres(ctx, "mfa_challenges", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	mfaModel := models.NewMFAModel(conn)
+	owner := models.User{Name: "mfa", Email: "mfa@email"}
+	assert.NoError(t, owner.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&owner)
+	assert.NoError(t, err)
+
+	// A pending secret can be replaced until it is enabled
+	email, err := mfaModel.SetPendingTOTP(*userID, "SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "mfa@email", email)
+	assert.NoError(t, mfaModel.EnableTOTP(*userID, []string{"code-hash"}))
+	_, err = mfaModel.SetPendingTOTP(*userID, "OTHER")
+	assert.ErrorIs(t, err, models.ErrMFAEnabled)
+
+	// Time steps and recovery codes can only be used once
+	fresh, err := mfaModel.UseTOTPStep(*userID, 100)
+	assert.NoError(t, err)
+	assert.True(t, fresh)
+	fresh, err = mfaModel.UseTOTPStep(*userID, 100)
+	assert.NoError(t, err)
+	assert.False(t, fresh)
+	used, err := mfaModel.UseRecoveryCode(*userID, "code-hash")
+	assert.NoError(t, err)
+	assert.True(t, used)
+	used, err = mfaModel.UseRecoveryCode(*userID, "code-hash")
+	assert.NoError(t, err)
+	assert.False(t, used)
+
+	// A challenge accepts a limited number of attempts and cannot be answered twice
+	jti := uuid.New()
+	assert.NoError(t, mfaModel.InsertChallenge(jti, *userID, time.Now().Add(time.Minute)))
+	for range 2 {
+		challengeUser, err := mfaModel.AttemptChallenge(jti, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, *userID, challengeUser)
+	}
+	_, err = mfaModel.AttemptChallenge(jti, 2)
+	assert.ErrorIs(t, err, models.ErrTokenRevoked)
+
+	answered := uuid.New()
+	assert.NoError(t, mfaModel.InsertChallenge(answered, *userID, time.Now().Add(time.Minute)))
+	assert.NoError(t, mfaModel.CompleteChallenge(answered))
+	_, err = mfaModel.AttemptChallenge(answered, 5)
+	assert.ErrorIs(t, err, models.ErrTokenRevoked)
+}
+
+func TestLoginThrottles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false
+        );
+        CREATE TABLE IF NOT EXISTS login_attempts (
+            id BIGSERIAL PRIMARY KEY,
+            email citext NOT NULL,
+            user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+            ip TEXT NOT NULL,
+            succeeded BOOLEAN NOT NULL,
+            created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+        );
+        CREATE TABLE IF NOT EXISTS login_throttles (
+            kind TEXT NOT NULL,
+            subject citext NOT NULL,
+            failures INT NOT NULL DEFAULT 0,
+            last_failure_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            blocked_until TIMESTAMP(0) WITH TIME ZONE,
+            PRIMARY KEY (kind, subject)
+        );`
+	conn, cleanup := PreparePostgres(ctx, "login_throttles", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	attemptModel := models.NewLoginAttemptModel(conn)
+	owner := models.User{Name: "locked", Email: "locked@email"}
+	assert.NoError(t, owner.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&owner)
+	assert.NoError(t, err)
+
+	// Failures are counted per account, regardless of the case of the email, and per IP
+	since := time.Now().Add(-time.Hour)
+	failure, err := attemptModel.RecordFailure("locked@email", "10.0.0.1", since)
+	assert.NoError(t, err)
+	assert.Equal(t, *userID, *failure.UserID)
+	failure, err = attemptModel.RecordFailure("LOCKED@email", "10.0.0.2", since)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, failure.AccountFailures)
+	assert.Equal(t, 1, failure.IPFailures)
+
+	unknown, err := attemptModel.RecordFailure("nobody@email", "10.0.0.2", since)
+	assert.NoError(t, err)
+	assert.Nil(t, unknown.UserID)
+	assert.Equal(t, 2, unknown.IPFailures)
+
+	// Failures before since are forgotten
+	failure, err = attemptModel.RecordFailure("locked@email", "10.0.0.1", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failure.AccountFailures)
+
+	assert.NoError(t, attemptModel.Block(models.ThrottleAccount, "locked@email", time.Now().Add(time.Hour)))
+	assert.NoError(t, attemptModel.Block(models.ThrottleIP, "10.0.0.2", time.Now().Add(-time.Minute)))
+	blocked, err := attemptModel.GetBlocked("locked@email", "10.0.0.2")
+	assert.NoError(t, err)
+	assert.Len(t, blocked, 1)
+	assert.Equal(t, models.ThrottleAccount, blocked[0].Kind)
+
+	// A password reset unlocks the account
+	assert.NoError(t, attemptModel.Unlock(*userID))
+	blocked, err = attemptModel.GetBlocked("locked@email", "10.0.0.2")
+	assert.NoError(t, err)
+	assert.Empty(t, blocked)
+}
+
+func TestAdminAuditLog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false,
+            totp_enabled BOOLEAN NOT NULL DEFAULT false,
+            role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin')),
+            banned_at TIMESTAMP(0) WITH TIME ZONE
+        );
+        CREATE TABLE IF NOT EXISTS admin_audit_log (
+            id BIGSERIAL PRIMARY KEY,
+            actor_id UUID NOT NULL REFERENCES users(id),
+            action TEXT NOT NULL,
+            target TEXT NOT NULL,
+            details TEXT NOT NULL DEFAULT '',
+            created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+        );`
+	conn, cleanup := PreparePostgres(ctx, "admin_audit_log", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	adminModel := models.NewAdminModel(conn)
+	admin := models.User{Name: "admin", Email: "admin@email"}
+	assert.NoError(t, admin.Password.Set("Test-password"))
+	adminID, err := usersModel.Insert(&admin)
+	assert.NoError(t, err)
+	user := models.User{Name: "user", Email: "user@email"}
+	assert.NoError(t, user.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&user)
+	assert.NoError(t, err)
+
+	entry := &models.AuditEntry{ActorID: *adminID, Action: "user.set_status", Target: userID.String(), Details: "banned"}
+	assert.NoError(t, adminModel.SetStatus(entry, *userID, models.UserStatusBanned))
+	role, active, err := usersModel.GetAccess(*userID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleUser, role)
+	assert.False(t, active)
+
+	entry = &models.AuditEntry{ActorID: *adminID, Action: "user.set_role", Target: userID.String(), Details: models.RoleModerator}
+	assert.NoError(t, adminModel.SetRole(entry, *userID, models.RoleModerator))
+	role, _, err = usersModel.GetAccess(*userID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleModerator, role)
+
+	// Unknown users are not changed and leave no audit entry behind
+	entry = &models.AuditEntry{ActorID: *adminID, Action: "user.set_role", Target: "missing"}
+	assert.ErrorIs(t, adminModel.SetRole(entry, uuid.New(), models.RoleAdmin), models.ErrRecordNotFound)
+
+	entries, err := adminModel.ListAudit(10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "user.set_role", entries[0].Action)
+	assert.Equal(t, "banned", entries[1].Details)
+}
+
+func TestEmailChangeAndDelete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false,
+            totp_enabled BOOLEAN NOT NULL DEFAULT false,
+            role TEXT NOT NULL DEFAULT 'user',
+            banned_at TIMESTAMP(0) WITH TIME ZONE
+        );
+        CREATE TABLE IF NOT EXISTS tokens (
+            hash TEXT PRIMARY KEY,
+            user_id UUID NOT NULL REFERENCES users(id),
+            expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL
+        );
+        CREATE TABLE IF NOT EXISTS email_changes (
+            hash TEXT PRIMARY KEY,
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            new_email citext NOT NULL,
+            expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL
+        );`
+	conn, cleanup := PreparePostgres(ctx, "email_changes", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	changeModel := models.NewEmailChangeModel(conn)
+	owner := models.User{Name: "owner", Email: "old@email"}
+	assert.NoError(t, owner.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&owner)
+	assert.NoError(t, err)
+	other := models.User{Name: "other", Email: "other@email"}
+	assert.NoError(t, other.Password.Set("Test-password"))
+	_, err = usersModel.Insert(&other)
+	assert.NoError(t, err)
+
+	// A new request replaces the pending one
+	expiry := time.Now().Add(time.Hour)
+	assert.NoError(t, changeModel.Insert(&models.EmailChange{Hash: "first", UserID: *userID, NewEmail: "first@email", Expiry: expiry}))
+	assert.NoError(t, changeModel.Insert(&models.EmailChange{Hash: "second", UserID: *userID, NewEmail: "new@email", Expiry: expiry}))
+	_, err = changeModel.Confirm("first")
+	assert.ErrorIs(t, err, models.ErrRecordNotFound)
+
+	change, err := changeModel.Confirm("second")
+	assert.NoError(t, err)
+	assert.Equal(t, "old@email", change.OldEmail)
+	user, err := usersModel.GetByID(*userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@email", user.Email)
+
+	// Addresses taken in the meantime are not swapped
+	assert.NoError(t, changeModel.Insert(&models.EmailChange{Hash: "taken", UserID: *userID, NewEmail: "other@email", Expiry: expiry}))
+	_, err = changeModel.Confirm("taken")
+	assert.ErrorIs(t, err, models.ErrDuplicateEmail)
+
+	_, err = conn.Exec(ctx, `INSERT INTO tokens (hash, user_id, expiry) VALUES ('activation', $1, $2)`, *userID, expiry)
+	assert.NoError(t, err)
+	assert.NoError(t, usersModel.Delete(*userID))
+	_, err = usersModel.GetByID(*userID)
+	assert.ErrorIs(t, err, models.ErrRecordNotFound)
+	assert.ErrorIs(t, usersModel.Delete(*userID), models.ErrRecordNotFound)
+}
+
+func TestSessions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tableSchema := `
+        CREATE EXTENSION IF NOT EXISTS citext;
+        CREATE TABLE IF NOT EXISTS users (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+            created_at timestamp(0) with time zone NOT NULL DEFAULT NOW (),
+            name text NOT NULL,
+            email citext UNIQUE NOT NULL,
+            password_hash bytea NOT NULL,
+            activated bool NOT NULL DEFAULT false
+        );
+        CREATE TABLE IF NOT EXISTS refresh_tokens (
+            jti UUID PRIMARY KEY,
+            family_id UUID NOT NULL,
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            expiry TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+            created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            used_at TIMESTAMP(0) WITH TIME ZONE,
+            revoked_at TIMESTAMP(0) WITH TIME ZONE
+        );
+        CREATE TABLE IF NOT EXISTS sessions (
+            id UUID PRIMARY KEY,
+            user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+            user_agent TEXT NOT NULL DEFAULT '',
+            ip TEXT NOT NULL DEFAULT '',
+            created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            last_seen_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+        );`
+	conn, cleanup := PreparePostgres(ctx, "sessions", tableSchema, t)
+	defer cleanup()
+
+	usersModel := models.NewUserModel(conn)
+	refreshModel := models.NewRefreshTokenModel(conn)
+	sessionModel := models.NewSessionModel(conn)
+	owner := models.User{Name: "sessions", Email: "sessions@email"}
+	assert.NoError(t, owner.Password.Set("Test-password"))
+	userID, err := usersModel.Insert(&owner)
+	assert.NoError(t, err)
+	other := models.User{Name: "other", Email: "sessions-other@email"}
+	assert.NoError(t, other.Password.Set("Test-password"))
+	otherID, err := usersModel.Insert(&other)
+	assert.NoError(t, err)
+
+	laptop := &models.Session{ID: uuid.New(), UserID: *userID, UserAgent: "laptop", IP: "10.0.0.1"}
+	phone := &models.Session{ID: uuid.New(), UserID: *userID, UserAgent: "phone", IP: "10.0.0.2"}
+	for _, session := range []*models.Session{laptop, phone} {
+		assert.NoError(t, sessionModel.Insert(session))
+		assert.NoError(t, refreshModel.Insert(&models.RefreshToken{JTI: uuid.New(), FamilyID: session.ID, UserID: *userID, Expiry: time.Now().Add(time.Hour)}))
+	}
+
+	// Refreshing keeps the session and records the new address
+	assert.NoError(t, sessionModel.Touch(phone.ID, "10.0.0.3", ""))
+	sessions, err := sessionModel.ListActive(*userID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	for _, session := range sessions {
+		if session.ID == phone.ID {
+			assert.Equal(t, "10.0.0.3", session.IP)
+			assert.Equal(t, "phone", session.UserAgent)
+		}
+	}
+
+	// Sessions of other users cannot be revoked
+	assert.ErrorIs(t, sessionModel.Revoke(*otherID, laptop.ID), models.ErrRecordNotFound)
+
+	assert.NoError(t, sessionModel.Revoke(*userID, laptop.ID))
+	active, err := sessionModel.IsActive(laptop.ID)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	active, err = sessionModel.IsActive(phone.ID)
+	assert.NoError(t, err)
+	assert.True(t, active)
+	sessions, err = sessionModel.ListActive(*userID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.ErrorIs(t, sessionModel.Revoke(*userID, laptop.ID), models.ErrRecordNotFound)
+}
@@ -7,11 +7,13 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/IBM/sarama"
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/config"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/handlers"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/repository"
@@ -27,6 +29,7 @@ type App struct {
 	KafkaProducer       *kafka.KafkaProducer
 	Scheduler           *scheduler.Checker
 	ExpiredPasteHandler *handlers.ExpiredPasteHandler
+	UserDeletedHandler  *handlers.UserDeletedHandler
 
 	Config *config.Config
 }
@@ -76,19 +79,22 @@ func NewApp(ctx context.Context) (*App, func(), error) {
 			return
 		}
 		metadataRepo := repository.NewMetadataRepo(db)
-		storageRepo, err := repository.NewStorageRepo(cfg.S3Region, cfg.BucketName)
+		store, err := storage.New(cfg.Storage)
 		if err != nil {
 			logger.PrintFatal(ctx, err, nil)
 			return
 		}
+		storageRepo := repository.NewStorageRepo(store)
 
 		pasteService := services.NewPasteService(metadataRepo, storageRepo)
 		expiredPasteHandler := handlers.NewExpiredPasteHandler(pasteService)
+		userDeletedHandler := handlers.NewUserDeletedHandler(pasteService)
 
 		expirationService := services.NewExpirationService(
 			metadataRepo,
 			storageRepo,
 			kafkaProducer,
+			cfg.PendingUploadTTL,
 		)
 
 		scheduler := scheduler.NewChecker(expirationService, logger)
@@ -99,6 +105,7 @@ func NewApp(ctx context.Context) (*App, func(), error) {
 			KafkaProducer:       kafkaProducer,
 			Scheduler:           scheduler,
 			ExpiredPasteHandler: expiredPasteHandler,
+			UserDeletedHandler:  userDeletedHandler,
 			Config:              cfg,
 		}
 
@@ -126,17 +133,31 @@ func openDB(dsn string) (*sql.DB, error) {
 	return db, nil
 }
 
+// userDeletedTopic carries the users who deleted their account, published by the auth service.
+const userDeletedTopic = "user-deleted"
+
 func (app *App) RunKafkaConsumer(cfg *config.Config, ctx context.Context) error {
+	// Configs written before accounts could be deleted do not list the topic
+	kafkaCfg := *cfg.Kafka
+	kafkaCfg.Topics = slices.Clone(cfg.Kafka.Topics)
+	if !slices.Contains(kafkaCfg.Topics, userDeletedTopic) {
+		kafkaCfg.Topics = append(kafkaCfg.Topics, userDeletedTopic)
+	}
+
 	// Map Kafka topics to handlers
 	handlers := map[string]kafka.MessageHandler{
 		"expired-paste-topic": func(msg *sarama.ConsumerMessage) error {
 			// Using the handler for the expired paste topic
 			return app.ExpiredPasteHandler.Handle(ctx, msg)
 		},
+		// Published by the auth service when a user deletes their account
+		userDeletedTopic: func(msg *sarama.ConsumerMessage) error {
+			return app.UserDeletedHandler.Handle(ctx, msg)
+		},
 	}
 
 	// Initialize Kafka consumer
-	consumer, err := kafka.NewKafkaConsumer(cfg.Kafka, handlers, ctx)
+	consumer, err := kafka.NewKafkaConsumer(&kafkaCfg, handlers, ctx)
 	if err != nil {
 		app.Logger.PrintError(ctx, fmt.Errorf("Failed to create a new Kafka consumer: %w", err), nil)
 		return err
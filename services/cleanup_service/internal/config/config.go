@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,8 +17,17 @@ type Config struct {
 	Kafka              *kafka.KafkaConfig `yaml:"kafka"`
 	DBUrl              string             `yaml:"db_url"`
 	S3Region           string             `yaml:"region"`
+	Storage            *storage.Config    `yaml:"storage"`
+	// PendingUploadTTL is how long a paste or revision uploaded through a presigned URL may stay pending
+	PendingUploadTTL time.Duration `yaml:"pending_upload_ttl"`
 }
 
+// DefaultPendingUploadTTL is used when the configuration sets no pending_upload_ttl.
+const DefaultPendingUploadTTL = time.Hour
+
+// minPendingUploadTTL is how long presigned upload URLs are valid, pending pastes must outlive them.
+const minPendingUploadTTL = 15 * time.Minute
+
 // LoadConfig initializes the configuration by loading variables from the .env file and environment.
 func LoadConfig(ctx context.Context) (*Config, error) {
 	// Read CONFIG_PATH from environment
@@ -47,8 +57,18 @@ func LoadConfig(ctx context.Context) (*Config, error) {
 	if cfg.Kafka == nil || len(cfg.Kafka.Topics) == 0 || len(cfg.Kafka.Brokers) == 0 {
 		return nil, fmt.Errorf("kafka configuration is incomplete")
 	}
-	if cfg.BucketName == "" || cfg.S3Region == "" {
-		return nil, fmt.Errorf("S3 configuration is incomplete")
+	if cfg.PendingUploadTTL == 0 {
+		cfg.PendingUploadTTL = DefaultPendingUploadTTL
+	}
+	if cfg.PendingUploadTTL < minPendingUploadTTL {
+		return nil, fmt.Errorf("pending upload TTL must be at least %v, got: %v", minPendingUploadTTL, cfg.PendingUploadTTL)
+	}
+	// Configs without a storage section keep using S3 with the top-level bucket settings
+	if cfg.Storage == nil {
+		cfg.Storage = &storage.Config{Driver: storage.DriverS3, Bucket: cfg.BucketName, Region: cfg.S3Region}
+	}
+	if err := cfg.Storage.Validate(); err != nil {
+		return nil, fmt.Errorf("storage configuration is invalid: %w", err)
 	}
 
 	return &cfg, nil
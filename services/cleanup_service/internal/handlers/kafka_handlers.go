@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/IBM/sarama"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/services"
@@ -26,3 +28,23 @@ func (h *ExpiredPasteHandler) Handle(ctx context.Context, msg *sarama.ConsumerMe
 	return nil
 }
 
+// UserDeletedHandler purges the pastes of users who deleted their account
+type UserDeletedHandler struct {
+	pasteService *services.PasteService
+}
+
+// NewUserDeletedHandler initializes a handler for the user deleted topic of the auth service
+func NewUserDeletedHandler(srv *services.PasteService) *UserDeletedHandler {
+	return &UserDeletedHandler{pasteService: srv}
+}
+
+// Handle processes the Kafka message to delete all pastes of the user
+func (h *UserDeletedHandler) Handle(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	var deleted struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(msg.Value, &deleted); err != nil {
+		return fmt.Errorf("failed to decode user deletion: %w", err)
+	}
+	return h.pasteService.DeleteUserPastes(ctx, deleted.UserID)
+}
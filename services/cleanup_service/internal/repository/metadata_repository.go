@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/pkg/validator"
+	"github.com/lib/pq"
 )
 
 type MetadataRepo struct {
@@ -43,6 +45,47 @@ func (repo *MetadataRepo) DeleteAndReturnExpiredKeys() ([]string, error) {
 	return keys, err
 }
 
+// DeleteAndReturnPendingKeys removes pastes created before createdBefore whose upload was never
+// completed and returns their keys.
+func (repo *MetadataRepo) DeleteAndReturnPendingKeys(createdBefore time.Time) ([]string, error) {
+	query := `DELETE FROM metadata WHERE status = 'pending' AND created_at <= $1 RETURNING key`
+	var keys []string
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := repo.DB.QueryContext(ctx, query, createdBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// DeleteAndReturnPendingVersionKeys removes revisions requested before createdBefore whose upload
+// was never completed and returns the storage object keys they were to be uploaded to.
+func (repo *MetadataRepo) DeleteAndReturnPendingVersionKeys(createdBefore time.Time) ([]string, error) {
+	query := `DELETE FROM paste_versions WHERE status = 'pending' AND created_at <= $1 RETURNING key, version, object_key`
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := repo.DB.QueryContext(ctx, query, createdBefore)
+	if err != nil {
+		return nil, err
+	}
+	return scanVersionObjectKeys(rows)
+}
+
 func (repo *MetadataRepo) DeletePasteByKey(key string) error {
 	query := `  DELETE FROM metadata WHERE key = $1`
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*30)
@@ -55,6 +98,100 @@ func (repo *MetadataRepo) DeletePasteByKey(key string) error {
 	return nil
 }
 
+// DeleteAndReturnKeysByUserID removes the metadata of every paste of a user and returns their keys.
+func (repo *MetadataRepo) DeleteAndReturnKeysByUserID(userID string) ([]string, error) {
+	query := `DELETE FROM metadata WHERE user_id = $1 RETURNING key`
+	var keys []string
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := repo.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// DeleteCollectionsByUserID removes the collections of a user.
+func (repo *MetadataRepo) DeleteCollectionsByUserID(userID string) error {
+	query := `DELETE FROM collections WHERE user_id = $1`
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	_, err := repo.DB.ExecContext(ctx, query, userID)
+	return err
+}
+
+// DeleteVersionsByKeys removes the revision history of the given pastes and
+// returns the storage object keys of the deleted revisions.
+func (repo *MetadataRepo) DeleteVersionsByKeys(keys []string) ([]string, error) {
+	query := `DELETE FROM paste_versions WHERE key = ANY($1) RETURNING key, version, object_key`
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := repo.DB.QueryContext(ctx, query, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	return scanVersionObjectKeys(rows)
+}
+
+// scanVersionObjectKeys collects the object keys of deleted revisions together with the keys
+// their presigned uploads were written to, which are left behind when an upload is not completed.
+func scanVersionObjectKeys(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var objectKeys []string
+	for rows.Next() {
+		var key, objectKey string
+		var version int32
+		if err := rows.Scan(&key, &version, &objectKey); err != nil {
+			return nil, err
+		}
+		objectKeys = append(objectKeys, objectKey, storage.UploadObjectKey(key, version))
+	}
+
+	return objectKeys, rows.Err()
+}
+
+// DeleteFilesByKeys removes the file manifests of the given bundles and
+// returns the storage object keys of the deleted files.
+func (repo *MetadataRepo) DeleteFilesByKeys(keys []string) ([]string, error) {
+	query := `DELETE FROM paste_files WHERE key = ANY($1) RETURNING object_key`
+	var objectKeys []string
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := repo.DB.QueryContext(ctx, query, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var objectKey string
+		if err := rows.Scan(&objectKey); err != nil {
+			return nil, err
+		}
+		objectKeys = append(objectKeys, objectKey)
+	}
+
+	return objectKeys, rows.Err()
+}
+
 func (repo *MetadataRepo) IsKeyValid(v *validator.Validator, key string) {
 	v.Check(len([]rune(key)) != 8, "key", "Provided key must be 8 chars lenth")
 }
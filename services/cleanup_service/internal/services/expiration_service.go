@@ -5,26 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/repository"
 )
 
 type ExpirationService struct {
-	metadataRepo  *repository.MetadataRepo
-	storageRepo   *repository.StorageRepo
-	kafkaProducer *kafka.KafkaProducer
+	metadataRepo     *repository.MetadataRepo
+	storageRepo      *repository.StorageRepo
+	kafkaProducer    *kafka.KafkaProducer
+	pendingUploadTTL time.Duration // How long a paste or revision may wait for its upload to be completed
 }
 
 func NewExpirationService(
 	metadataRepo *repository.MetadataRepo,
 	storageRepo *repository.StorageRepo,
 	kafkaProducer *kafka.KafkaProducer,
+	pendingUploadTTL time.Duration,
 ) *ExpirationService {
 	return &ExpirationService{
-		metadataRepo:  metadataRepo,
-		storageRepo:   storageRepo,
-		kafkaProducer: kafkaProducer,
+		metadataRepo:     metadataRepo,
+		storageRepo:      storageRepo,
+		kafkaProducer:    kafkaProducer,
+		pendingUploadTTL: pendingUploadTTL,
 	}
 }
 
@@ -34,14 +38,40 @@ func (s *ExpirationService) ProcessExpirations(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error retrieving expired pastes: %v", err)
 	}
+	// Pastes whose upload was never completed are reaped like expired ones, releasing their keys
+	pendingKeys, err := s.metadataRepo.DeleteAndReturnPendingKeys(time.Now().Add(-s.pendingUploadTTL))
+	if err != nil {
+		return fmt.Errorf("error retrieving abandoned uploads: %v", err)
+	}
+	expiredKeys = append(expiredKeys, pendingKeys...)
 	log.Println(expiredKeys)
 
+	// Abandoned revisions of live pastes were never served, only a partial upload may be left
+	pendingVersionKeys, err := s.metadataRepo.DeleteAndReturnPendingVersionKeys(time.Now().Add(-s.pendingUploadTTL))
+	if err != nil {
+		return fmt.Errorf("error retrieving abandoned revisions: %v", err)
+	}
+	if len(pendingVersionKeys) > 0 {
+		if err := s.storageRepo.DeleteExpiredPastes(pendingVersionKeys); err != nil {
+			return fmt.Errorf("error deleting abandoned revisions from storage: %v", err)
+		}
+	}
+
 	if len(expiredKeys) == 0 {
 		return nil // No expired keys to process
 	}
 
-	// Step 2: Delete expired keys from storage
-	if err := s.storageRepo.DeleteExpiredPastes(expiredKeys); err != nil {
+	// Step 2: Delete expired keys, all of their revisions and the files of bundles from storage
+	versionKeys, err := s.metadataRepo.DeleteVersionsByKeys(expiredKeys)
+	if err != nil {
+		return fmt.Errorf("error deleting paste versions: %v", err)
+	}
+	fileKeys, err := s.metadataRepo.DeleteFilesByKeys(expiredKeys)
+	if err != nil {
+		return fmt.Errorf("error deleting paste files: %v", err)
+	}
+	objectKeys := append(append(append([]string{}, expiredKeys...), versionKeys...), fileKeys...)
+	if err := s.storageRepo.DeleteExpiredPastes(objectKeys); err != nil {
 		return fmt.Errorf("error deleting expired pastes from storage: %v", err)
 	}
 
@@ -28,9 +28,56 @@ func (service *PasteService) DeletePasteByKey(ctx context.Context, key string) e
 		return fmt.Errorf("failed to delete metadata: %w", err)
 	}
 
-	if err := service.storageRepo.DeletePasteContentByKey(key); err != nil {
+	versionKeys, err := service.metadataRepo.DeleteVersionsByKeys([]string{key})
+	if err != nil {
+		return fmt.Errorf("failed to delete paste versions: %w", err)
+	}
+
+	fileKeys, err := service.metadataRepo.DeleteFilesByKeys([]string{key})
+	if err != nil {
+		return fmt.Errorf("failed to delete paste files: %w", err)
+	}
+
+	objectKeys := append(append([]string{key}, versionKeys...), fileKeys...)
+	if err := service.storageRepo.DeleteExpiredPastes(objectKeys); err != nil {
 		return fmt.Errorf("failed to delete paste from storage: %w", err)
 	}
 
 	return nil
 }
+
+// DeleteUserPastes removes every paste of a deleted user with all of its revisions and files,
+// and the collections they sorted them into.
+func (service *PasteService) DeleteUserPastes(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("user ID is empty")
+	}
+
+	keys, err := service.metadataRepo.DeleteAndReturnKeysByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete metadata: %w", err)
+	}
+	if err := service.metadataRepo.DeleteCollectionsByUserID(userID); err != nil {
+		return fmt.Errorf("failed to delete collections: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	versionKeys, err := service.metadataRepo.DeleteVersionsByKeys(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete paste versions: %w", err)
+	}
+
+	fileKeys, err := service.metadataRepo.DeleteFilesByKeys(keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete paste files: %w", err)
+	}
+
+	objectKeys := append(append(append([]string{}, keys...), versionKeys...), fileKeys...)
+	if err := service.storageRepo.DeleteExpiredPastes(objectKeys); err != nil {
+		return fmt.Errorf("failed to delete pastes from storage: %w", err)
+	}
+
+	return nil
+}
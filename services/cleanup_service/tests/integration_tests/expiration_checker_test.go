@@ -11,6 +11,7 @@ import (
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	"github.com/NesterovYehor/TextNest/pkg/test/container"
+	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/config"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/repository"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/scheduler"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/services"
@@ -34,10 +35,9 @@ func TestExpirationChecker(t *testing.T) {
 	db, cleanUpDB := testutils.SetupTestDatabase(t, ctx)
 	defer cleanUpDB()
 
-	// Set up S3 bucket
-	cleanUpS3, err := testutils.SetUpTestS3(ctx)
+	// Set up storage
+	store, err := testutils.SetUpTestStorage()
 	assert.NoError(t, err)
-	defer cleanUpS3()
 
 	// Kafka setup
 	kafkaContainerSetUp, err := container.StartKafka(ctx)
@@ -52,16 +52,14 @@ func TestExpirationChecker(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Create repositories and services
-	factory := repository.NewRepositoryFactory(db)
-	metadataRepo := factory.CreateMetadataRepository()
-	storageRepo, err := factory.CreateStorageRepository()
-	assert.NoError(t, err)
+	metadataRepo := repository.NewMetadataRepo(db)
+	storageRepo := repository.NewStorageRepo(store)
 
 	// Expiration service
 	srv := services.NewExpirationService(
 		metadataRepo, storageRepo,
 		kafkaProd,
-		testutils.S3TestData.Bucket,
+		config.DefaultPendingUploadTTL,
 	)
 
 	// Run expiration processing
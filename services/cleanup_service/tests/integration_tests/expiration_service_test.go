@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/pkg/test/container"
+	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/config"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/repository"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/services"
 	testutils "github.com/NesterovYehor/TextNest/services/cleanup_service/tests/unit_tests"
@@ -20,10 +22,9 @@ func TestProcessExpirations(t *testing.T) {
 	db, cleanUpDB := testutils.SetupTestDatabase(t, ctx)
 	defer cleanUpDB()
 
-	// Set up S3 bucket
-	cleanUpS3, err := testutils.SetUpTestS3(ctx)
+	// Set up storage
+	store, err := testutils.SetUpTestStorage()
 	assert.NoError(t, err)
-	defer cleanUpS3()
 
 	// Kafka options
 	topicName := "example-topic"
@@ -39,19 +40,21 @@ func TestProcessExpirations(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Create repositories and services
-	factory := repository.NewRepositoryFactory(db)
-	metadataRepo := factory.CreateMetadataRepository()
-	storageRepo, err := factory.CreateStorageRepository()
-	assert.NoError(t, err)
+	metadataRepo := repository.NewMetadataRepo(db)
+	storageRepo := repository.NewStorageRepo(store)
 
 	// Expiration service
 	srv := services.NewExpirationService(
 		metadataRepo, storageRepo,
 		kafkaProd,
-		testutils.S3TestData.Bucket,
+		config.DefaultPendingUploadTTL,
 	)
 
 	// Execute expiration processing
 	err = srv.ProcessExpirations(ctx)
 	assert.NoError(t, err)
+
+	// The content of the expired paste must be gone as well
+	_, err = store.StatPaste(ctx, testutils.StorageTestData.Key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
 }
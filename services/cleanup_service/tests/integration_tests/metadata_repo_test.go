@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/services/cleanup_service/internal/repository"
 	testutils "github.com/NesterovYehor/TextNest/services/cleanup_service/tests/unit_tests"
 	"github.com/stretchr/testify/assert"
@@ -18,8 +19,7 @@ func TestDeleteAndReturnExpiredKeys(t *testing.T) {
 	defer cleanup()
 
 	// Create the repository and call DeleteAndReturnExpiredKeys
-	factory := repository.NewRepositoryFactory(db)
-	repo := factory.CreateMetadataRepository()
+	repo := repository.NewMetadataRepo(db)
 	expiredKeys, err := repo.DeleteAndReturnExpiredKeys()
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"test_key"}, expiredKeys, "Expected expired keys to match the inserted key")
@@ -29,6 +29,27 @@ func TestDeleteAndReturnExpiredKeys(t *testing.T) {
 	assert.False(t, exists, "Expected the expired key to be deleted from the database")
 }
 
+func TestDeleteAndReturnPendingKeys(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	db, cleanup := testutils.SetupTestDatabase(t, ctx)
+	defer cleanup()
+
+	query := `INSERT INTO metadata (key, created_at, expiration_date, status) VALUES ($1, $2, $3, 'pending')`
+	_, err := db.ExecContext(ctx, query, "abandoned", time.Now().Add(-2*time.Hour), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	_, err = db.ExecContext(ctx, query, "uploading", time.Now(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	// Only uploads pending for longer than the TTL are reaped, active pastes are left alone
+	repo := repository.NewMetadataRepo(db)
+	keys, err := repo.DeleteAndReturnPendingKeys(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abandoned"}, keys)
+	assert.True(t, testutils.VerifyRowExists(t, db, "uploading"))
+}
+
 func TestDeletePasteByKey(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
@@ -36,8 +57,7 @@ func TestDeletePasteByKey(t *testing.T) {
 	defer cleanup()
 
 	// Create the repository and call DeletePasteByKey
-	factory := repository.NewRepositoryFactory(db)
-	repo := factory.CreateMetadataRepository()
+	repo := repository.NewMetadataRepo(db)
 	err := repo.DeletePasteByKey("test_key")
 	assert.NoError(t, err)
 
@@ -45,3 +65,26 @@ func TestDeletePasteByKey(t *testing.T) {
 	exists := testutils.VerifyRowExists(t, db, "test_key")
 	assert.False(t, exists, "Expected the key to be deleted from the database")
 }
+
+func TestDeleteFilesByKeys(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	db, cleanup := testutils.SetupTestDatabase(t, ctx)
+	defer cleanup()
+
+	query := `INSERT INTO paste_files(key, position, name, size, object_key) VALUES ($1, $2, $3, $4, $5)`
+	for position, name := range []string{"Dockerfile", "compose.yaml"} {
+		_, err := db.ExecContext(ctx, query, "test_key", position, name, 10, storage.FileObjectKey("test_key", int32(position)))
+		assert.NoError(t, err)
+	}
+
+	repo := repository.NewMetadataRepo(db)
+	objectKeys, err := repo.DeleteFilesByKeys([]string{"test_key"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{storage.FileObjectKey("test_key", 0), storage.FileObjectKey("test_key", 1)}, objectKeys)
+
+	// The manifest is gone, a second call finds nothing to delete
+	objectKeys, err = repo.DeleteFilesByKeys([]string{"test_key"})
+	assert.NoError(t, err)
+	assert.Empty(t, objectKeys)
+}
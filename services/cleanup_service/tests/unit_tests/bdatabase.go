@@ -23,7 +23,27 @@ func SetupTestDatabase(t assert.TestingT, ctx context.Context) (*sql.DB, func())
         CREATE TABLE IF NOT EXISTS metadata (
             key VARCHAR NOT NULL UNIQUE,
             created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-            expiration_date TIMESTAMP WITH TIME ZONE NOT NULL
+            expiration_date TIMESTAMP WITH TIME ZONE NOT NULL,
+            status TEXT NOT NULL DEFAULT 'active'
+        );
+        CREATE TABLE IF NOT EXISTS paste_versions (
+            key VARCHAR NOT NULL,
+            version INTEGER NOT NULL,
+            object_key TEXT NOT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+            status TEXT NOT NULL DEFAULT 'active',
+            PRIMARY KEY (key, version)
+        );
+        CREATE TABLE IF NOT EXISTS paste_files (
+            key VARCHAR NOT NULL,
+            position INTEGER NOT NULL,
+            name TEXT NOT NULL,
+            language TEXT DEFAULT NULL,
+            size BIGINT NOT NULL,
+            object_key TEXT NOT NULL,
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+            PRIMARY KEY (key, position),
+            UNIQUE (key, name)
         );
     `
 
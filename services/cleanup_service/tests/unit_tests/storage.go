@@ -0,0 +1,14 @@
+package testutils
+
+import (
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+)
+
+// SetUpTestStorage returns an in-memory storage seeded with the content of the test paste.
+func SetUpTestStorage() (*storage.MemoryStorage, error) {
+	store := storage.NewMemoryStorage()
+	if err := store.UploadPaste(StorageTestData.Key, []byte(StorageTestData.Content)); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
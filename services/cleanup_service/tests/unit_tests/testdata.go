@@ -27,14 +27,10 @@ func GetTestEnv() error {
 	return nil
 }
 
-var S3TestData = struct {
-	Bucket  string
-	Region  string
+var StorageTestData = struct {
 	Key     string
 	Content string
 }{
-	Bucket:  "test-textnest-bucket",
-	Region:  "us-east-1",
 	Key:     "test_key", // Same key as in metadata
 	Content: "Test data for expired paste content",
 }
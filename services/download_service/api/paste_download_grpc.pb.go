@@ -21,6 +21,12 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PasteDownload_DownloadByKey_FullMethodName    = "/pastedownload.PasteDownload/DownloadByKey"
 	PasteDownload_DownloadByUserId_FullMethodName = "/pastedownload.PasteDownload/DownloadByUserId"
+	PasteDownload_ListVersions_FullMethodName     = "/pastedownload.PasteDownload/ListVersions"
+	PasteDownload_GetVersion_FullMethodName       = "/pastedownload.PasteDownload/GetVersion"
+	PasteDownload_SearchPastes_FullMethodName     = "/pastedownload.PasteDownload/SearchPastes"
+	PasteDownload_RenderPaste_FullMethodName      = "/pastedownload.PasteDownload/RenderPaste"
+	PasteDownload_StreamContent_FullMethodName    = "/pastedownload.PasteDownload/StreamContent"
+	PasteDownload_DownloadBundle_FullMethodName   = "/pastedownload.PasteDownload/DownloadBundle"
 )
 
 // PasteDownloadClient is the client API for PasteDownload service.
@@ -33,6 +39,19 @@ type PasteDownloadClient interface {
 	DownloadByKey(ctx context.Context, in *DownloadByKeyRequest, opts ...grpc.CallOption) (*DownloadByKeyResponse, error)
 	// DownloadByUserId retrieves a slice of objects based on userId, with pagination.
 	DownloadByUserId(ctx context.Context, in *DownloadByUserIdRequest, opts ...grpc.CallOption) (*DownloadByUserIdResponse, error)
+	// ListVersions returns every stored revision of a paste, oldest first.
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	// GetVersion retrieves a single revision of a paste together with its content.
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// SearchPastes runs a full-text search over the titles, tags and content of a user's pastes.
+	SearchPastes(ctx context.Context, in *SearchPastesRequest, opts ...grpc.CallOption) (*SearchPastesResponse, error)
+	// RenderPaste returns the content of a paste with syntax highlighting applied.
+	RenderPaste(ctx context.Context, in *RenderPasteRequest, opts ...grpc.CallOption) (*RenderPasteResponse, error)
+	// StreamContent streams the raw bytes of the latest revision of a paste. The first message
+	// describes the content, every following one carries a chunk of it.
+	StreamContent(ctx context.Context, in *StreamContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamContentResponse], error)
+	// DownloadBundle streams all files of a bundle packed into a single archive.
+	DownloadBundle(ctx context.Context, in *DownloadBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadBundleResponse], error)
 }
 
 type pasteDownloadClient struct {
@@ -63,6 +82,84 @@ func (c *pasteDownloadClient) DownloadByUserId(ctx context.Context, in *Download
 	return out, nil
 }
 
+func (c *pasteDownloadClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, PasteDownload_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteDownloadClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, PasteDownload_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteDownloadClient) SearchPastes(ctx context.Context, in *SearchPastesRequest, opts ...grpc.CallOption) (*SearchPastesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchPastesResponse)
+	err := c.cc.Invoke(ctx, PasteDownload_SearchPastes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteDownloadClient) RenderPaste(ctx context.Context, in *RenderPasteRequest, opts ...grpc.CallOption) (*RenderPasteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderPasteResponse)
+	err := c.cc.Invoke(ctx, PasteDownload_RenderPaste_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pasteDownloadClient) StreamContent(ctx context.Context, in *StreamContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamContentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PasteDownload_ServiceDesc.Streams[0], PasteDownload_StreamContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamContentRequest, StreamContentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteDownload_StreamContentClient = grpc.ServerStreamingClient[StreamContentResponse]
+
+func (c *pasteDownloadClient) DownloadBundle(ctx context.Context, in *DownloadBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadBundleResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PasteDownload_ServiceDesc.Streams[1], PasteDownload_DownloadBundle_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadBundleRequest, DownloadBundleResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteDownload_DownloadBundleClient = grpc.ServerStreamingClient[DownloadBundleResponse]
+
 // PasteDownloadServer is the server API for PasteDownload service.
 // All implementations must embed UnimplementedPasteDownloadServer
 // for forward compatibility.
@@ -73,6 +170,19 @@ type PasteDownloadServer interface {
 	DownloadByKey(context.Context, *DownloadByKeyRequest) (*DownloadByKeyResponse, error)
 	// DownloadByUserId retrieves a slice of objects based on userId, with pagination.
 	DownloadByUserId(context.Context, *DownloadByUserIdRequest) (*DownloadByUserIdResponse, error)
+	// ListVersions returns every stored revision of a paste, oldest first.
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	// GetVersion retrieves a single revision of a paste together with its content.
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	// SearchPastes runs a full-text search over the titles, tags and content of a user's pastes.
+	SearchPastes(context.Context, *SearchPastesRequest) (*SearchPastesResponse, error)
+	// RenderPaste returns the content of a paste with syntax highlighting applied.
+	RenderPaste(context.Context, *RenderPasteRequest) (*RenderPasteResponse, error)
+	// StreamContent streams the raw bytes of the latest revision of a paste. The first message
+	// describes the content, every following one carries a chunk of it.
+	StreamContent(*StreamContentRequest, grpc.ServerStreamingServer[StreamContentResponse]) error
+	// DownloadBundle streams all files of a bundle packed into a single archive.
+	DownloadBundle(*DownloadBundleRequest, grpc.ServerStreamingServer[DownloadBundleResponse]) error
 	mustEmbedUnimplementedPasteDownloadServer()
 }
 
@@ -89,6 +199,24 @@ func (UnimplementedPasteDownloadServer) DownloadByKey(context.Context, *Download
 func (UnimplementedPasteDownloadServer) DownloadByUserId(context.Context, *DownloadByUserIdRequest) (*DownloadByUserIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DownloadByUserId not implemented")
 }
+func (UnimplementedPasteDownloadServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedPasteDownloadServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedPasteDownloadServer) SearchPastes(context.Context, *SearchPastesRequest) (*SearchPastesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPastes not implemented")
+}
+func (UnimplementedPasteDownloadServer) RenderPaste(context.Context, *RenderPasteRequest) (*RenderPasteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderPaste not implemented")
+}
+func (UnimplementedPasteDownloadServer) StreamContent(*StreamContentRequest, grpc.ServerStreamingServer[StreamContentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamContent not implemented")
+}
+func (UnimplementedPasteDownloadServer) DownloadBundle(*DownloadBundleRequest, grpc.ServerStreamingServer[DownloadBundleResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadBundle not implemented")
+}
 func (UnimplementedPasteDownloadServer) mustEmbedUnimplementedPasteDownloadServer() {}
 func (UnimplementedPasteDownloadServer) testEmbeddedByValue()                       {}
 
@@ -146,6 +274,100 @@ func _PasteDownload_DownloadByUserId_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PasteDownload_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteDownloadServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteDownload_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteDownloadServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteDownload_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteDownloadServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteDownload_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteDownloadServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteDownload_SearchPastes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPastesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteDownloadServer).SearchPastes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteDownload_SearchPastes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteDownloadServer).SearchPastes(ctx, req.(*SearchPastesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteDownload_RenderPaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderPasteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteDownloadServer).RenderPaste(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteDownload_RenderPaste_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteDownloadServer).RenderPaste(ctx, req.(*RenderPasteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteDownload_StreamContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PasteDownloadServer).StreamContent(m, &grpc.GenericServerStream[StreamContentRequest, StreamContentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteDownload_StreamContentServer = grpc.ServerStreamingServer[StreamContentResponse]
+
+func _PasteDownload_DownloadBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PasteDownloadServer).DownloadBundle(m, &grpc.GenericServerStream[DownloadBundleRequest, DownloadBundleResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteDownload_DownloadBundleServer = grpc.ServerStreamingServer[DownloadBundleResponse]
+
 // PasteDownload_ServiceDesc is the grpc.ServiceDesc for PasteDownload service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,7 +383,34 @@ var PasteDownload_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DownloadByUserId",
 			Handler:    _PasteDownload_DownloadByUserId_Handler,
 		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _PasteDownload_ListVersions_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _PasteDownload_GetVersion_Handler,
+		},
+		{
+			MethodName: "SearchPastes",
+			Handler:    _PasteDownload_SearchPastes_Handler,
+		},
+		{
+			MethodName: "RenderPaste",
+			Handler:    _PasteDownload_RenderPaste_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamContent",
+			Handler:       _PasteDownload_StreamContent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadBundle",
+			Handler:       _PasteDownload_DownloadBundle_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "paste_download/paste_download.proto",
 }
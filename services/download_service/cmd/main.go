@@ -50,6 +50,8 @@ func main() {
 	}
 	pb.RegisterPasteDownloadServer(grpcSrv.Grpc, coord)
 
+	go coord.RunCacheInvalidation(ctx)
+
 	if err := grpcSrv.RunGrpcServer(ctx); err != nil {
 		log.PrintFatal(ctx, err, nil)
 		return
@@ -7,9 +7,8 @@ import (
 
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
 	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
-    "google.golang.org/protobuf/proto"
 	"github.com/redis/go-redis/v9"
-	"github.com/sony/gobreaker"
+	"google.golang.org/protobuf/proto"
 )
 
 type redisCache struct {
@@ -20,8 +19,7 @@ type redisCache struct {
 
 // NewRedisCache initializes a new Redis cache instance
 func NewRedisCache(redisAddr string) (Cache, error) {
-	cbSettings := gobreaker.Settings{
-		Name:        "MetadataRepo",
+	cbConfig := middleware.CircuitBreakerConfig{
 		MaxRequests: 5,
 		Interval:    5 * time.Second,
 		Timeout:     30 * time.Second,
@@ -38,7 +36,7 @@ func NewRedisCache(redisAddr string) (Cache, error) {
 	return &redisCache{
 		client:     rdb,
 		expiration: time.Hour * 24,
-		breaker:    middleware.NewCircuitBreakerMiddleware(cbSettings),
+		breaker:    middleware.NewCircuitBreakerMiddleware(cbConfig, "MetadataRepo"),
 	}, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,6 +20,7 @@ type Config struct {
 	S3Region          string            `yaml:"region"`
 	DBURL             string            `yaml:"db"`
 	RedisMetadataAddr string            `yaml:"metadata_cache_addr"`
+	Storage           *storage.Config   `yaml:"storage"`
 
 	ExpirationInterval time.Duration `yaml:"expiration_interval"`
 }
@@ -51,8 +53,12 @@ func LoadConfig(log *jsonlog.Logger, ctx context.Context) (*Config, error) {
 	if len(cfg.Kafka.Topics) == 0 || len(cfg.Kafka.Brokers) == 0 {
 		log.PrintFatal(ctx, fmt.Errorf("kafka configuration is incomplete"), nil)
 	}
-	if cfg.BucketName == "" || cfg.S3Region == "" {
-		log.PrintFatal(ctx, fmt.Errorf("S3 configuration is incomplete"), nil)
+	// Configs without a storage section keep using S3 with the top-level bucket settings
+	if cfg.Storage == nil {
+		cfg.Storage = &storage.Config{Driver: storage.DriverS3, Bucket: cfg.BucketName, Region: cfg.S3Region}
+	}
+	if err := cfg.Storage.Validate(); err != nil {
+		log.PrintFatal(ctx, fmt.Errorf("storage configuration is invalid: %w", err), nil)
 	}
 	if cfg.RedisMetadataAddr == "" {
 		log.PrintFatal(ctx, fmt.Errorf("redis cahce configuration is incomplete"), nil)
@@ -1,22 +1,37 @@
 package coordinators
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"io"
 	"sync"
+	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	log "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/cache"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/config"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type DownloadCoordinator struct {
 	fetchMetadataService *services.FetchMetadataService
 	fetchContentService  *services.FetchContentService
+	versionService       *services.VersionService
+	accessService        *services.AccessService
+	searchService        *services.SearchService
+	renderService        *services.RenderService
+	bundleService        *services.BundleService
 	cfg                  *config.Config
 	logger               *log.Logger
 	pb.UnsafePasteDownloadServer
@@ -32,13 +47,15 @@ func NewDownloadCoordinator(ctx context.Context, cfg *config.Config, log *log.Lo
 		return nil, err
 	}
 	metadataRepo := repository.NewMetadataRepo(db)
-	contentRepo, err := repository.NewContentRepository(cfg.BucketName, cfg.S3Region)
+	store, err := storage.New(cfg.Storage)
 	if err != nil {
-		log.PrintFatal(ctx, err, nil)
+		return nil, err
 	}
+	contentRepo := repository.NewContentRepository(store)
+	versionRepo := repository.NewVersionRepo(db)
 
 	fetchMetadataService := services.NewFetchMetadataService(metadataRepo, cache, kafkaProducer)
-	fetchContentService, err := services.NewFetchContentService(contentRepo, log)
+	fetchContentService, err := services.NewFetchContentService(contentRepo, versionRepo, repository.NewFileRepo(db), log)
 	if err != nil {
 		log.PrintFatal(ctx, err, nil)
 	}
@@ -46,11 +63,42 @@ func NewDownloadCoordinator(ctx context.Context, cfg *config.Config, log *log.Lo
 	return &DownloadCoordinator{
 		fetchMetadataService: fetchMetadataService,
 		fetchContentService:  fetchContentService,
+		versionService:       services.NewVersionService(versionRepo, contentRepo, log),
+		accessService:        services.NewAccessService(metadataRepo, cache, kafkaProducer),
+		searchService:        services.NewSearchService(repository.NewSearchRepo(db), log),
+		renderService:        services.NewRenderService(),
+		bundleService:        services.NewBundleService(contentRepo),
 		cfg:                  cfg,
 		logger:               log,
 	}, nil
 }
 
+// RunCacheInvalidation drops the cached metadata of pastes edited through the upload service until
+// ctx is done. The cache is shared, so all instances consume in the configured group.
+func (coord *DownloadCoordinator) RunCacheInvalidation(ctx context.Context) error {
+	kafkaCfg := coord.cfg.Kafka
+	kafkaCfg.Topics = []string{services.PasteUpdatedTopic}
+
+	handlers := map[string]kafka.MessageHandler{
+		services.PasteUpdatedTopic: func(msg *sarama.ConsumerMessage) error {
+			return coord.fetchMetadataService.HandlePasteUpdated(ctx, msg)
+		},
+	}
+
+	consumer, err := kafka.NewKafkaConsumer(&kafkaCfg, handlers, ctx)
+	if err != nil {
+		coord.logger.PrintError(ctx, fmt.Errorf("Failed to create a new Kafka consumer: %w", err), nil)
+		return err
+	}
+
+	if err := consumer.Start(); err != nil {
+		coord.logger.PrintError(ctx, fmt.Errorf("Kafka consumer stopped with error: %w", err), nil)
+		consumer.Close()
+		return err
+	}
+	return nil
+}
+
 func (coord *DownloadCoordinator) DownloadByKey(ctx context.Context, req *pb.DownloadByKeyRequest) (*pb.DownloadByKeyResponse, error) {
 	var wg sync.WaitGroup
 	errors := make(chan error, 2) // Buffered channel to avoid deadlock
@@ -91,15 +139,341 @@ func (coord *DownloadCoordinator) DownloadByKey(ctx context.Context, req *pb.Dow
 		}
 	}
 
+	// The URL is only presigned above, it is not handed out before the access checks pass.
+	// The password is checked before the view counter so a wrong guess never burns a view.
+	if err := coord.accessService.CheckVisibility(ctx, ress.Metadata, req.UserId); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+	if err := coord.accessService.CheckPassword(ctx, ress.Metadata, req.Password); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+	// Loaded before the view is taken, a failure here must not burn the paste
+	if ress.Metadata.Encrypted {
+		header, err := coord.fetchMetadataService.FetchEncryptionHeader(ctx, req.Key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to load encryption header: %v", err)
+		}
+		ress.Encryption = header
+	}
+	// Bundles have no single object to download, the manifest lists the files instead
+	if ress.Metadata.FileCount > 0 {
+		files, err := coord.fetchContentService.ListFiles(ctx, req.Key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list files: %v", err)
+		}
+		ress.DownlaodUrl = ""
+		for _, f := range files {
+			ress.Files = append(ress.Files, f.File)
+		}
+	}
+
+	if err := coord.accessService.ConsumeView(ctx, ress.Metadata); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+
 	return ress, nil
 }
 
 func (coord *DownloadCoordinator) DownloadByUserId(ctx context.Context, req *pb.DownloadByUserIdRequest) (*pb.DownloadByUserIdResponse, error) {
-	metadata, err := coord.fetchMetadataService.FetchMetadataByUserId(ctx, req.UserId, int(req.Limit), int(req.Offset))
+	if pb.PasteSort_name[int32(req.Sort)] == "" {
+		return nil, status.Error(codes.InvalidArgument, "unknown sort order")
+	}
+	if req.Limit < 1 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be positive")
+	}
+	filter := repository.UserPastesFilter{Tag: req.Tag, CollectionID: req.CollectionId, Sort: req.Sort}
+	page, err := coord.fetchMetadataService.FetchMetadataByUserId(ctx, req.UserId, filter, req.Cursor, int(req.Limit), int(req.Offset))
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &pb.DownloadByUserIdResponse{
-		Objects: metadata,
+		Objects:    page.Pastes,
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
 	}, nil
 }
+
+func (coord *DownloadCoordinator) ListVersions(ctx context.Context, req *pb.ListVersionsRequest) (*pb.ListVersionsResponse, error) {
+	if err := coord.authorizeHistory(ctx, req.Key, req.UserId, req.Password); err != nil {
+		return nil, err
+	}
+
+	versions, err := coord.versionService.ListVersions(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list versions: %v", err)
+	}
+	return &pb.ListVersionsResponse{Versions: versions}, nil
+}
+
+func (coord *DownloadCoordinator) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	if err := coord.authorizeHistory(ctx, req.Key, req.UserId, req.Password); err != nil {
+		return nil, err
+	}
+
+	version, content, err := coord.versionService.GetVersion(ctx, req.Key, req.Version)
+	if errors.Is(err, repository.ErrVersionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "paste %s has no version %d", req.Key, req.Version)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get version: %v", err)
+	}
+	return &pb.GetVersionResponse{Version: version, Content: content}, nil
+}
+
+func (coord *DownloadCoordinator) SearchPastes(ctx context.Context, req *pb.SearchPastesRequest) (*pb.SearchPastesResponse, error) {
+	results, err := coord.searchService.SearchPastes(ctx, req.UserId, req.Query, int(req.Limit), int(req.Offset))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to search pastes: %v", err)
+	}
+	return &pb.SearchPastesResponse{Results: results}, nil
+}
+
+// RenderPaste highlights the latest revision of a paste, or one file of a bundle. It runs the same
+// access checks as DownloadByKey and takes a view of view limited pastes, the rendered output is the content.
+func (coord *DownloadCoordinator) RenderPaste(ctx context.Context, req *pb.RenderPasteRequest) (*pb.RenderPasteResponse, error) {
+	metadata, err := coord.fetchMetadataService.FetchMetadataByKey(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "paste %s is not available: %v", req.Key, err)
+	}
+	if err := coord.accessService.CheckVisibility(ctx, metadata, req.UserId); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+	if err := coord.accessService.CheckPassword(ctx, metadata, req.Password); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+	if metadata.Encrypted {
+		return nil, status.Errorf(codes.FailedPrecondition, "paste %s is encrypted on the client and cannot be rendered", req.Key)
+	}
+	if err := checkFileSelection(metadata, req.File); err != nil {
+		return nil, err
+	}
+
+	language := metadata.Language
+	if req.File != "" {
+		file, err := coord.fetchContentService.GetFile(ctx, req.Key, req.File)
+		if err != nil {
+			return nil, fileError(req.Key, req.File, err)
+		}
+		language = file.File.Language
+	}
+	content, err := coord.fetchContentService.GetContent(ctx, req.Key, req.File)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read paste: %v", err)
+	}
+	res, err := coord.renderService.Render(content, language, req.Format)
+	switch {
+	case errors.Is(err, services.ErrUnknownFormat):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrTooLargeToRender), errors.Is(err, services.ErrBinaryContent):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to render paste: %v", err)
+	}
+
+	if err := coord.accessService.ConsumeView(ctx, metadata); err != nil {
+		return nil, accessError(req.Key, err)
+	}
+	return res, nil
+}
+
+// streamChunkSize is the size of the content chunks sent by StreamContent.
+const streamChunkSize = 32 << 10
+
+// StreamContent streams the latest revision of a paste, or one file of a bundle, after the access checks of DownloadByKey.
+// Conditional requests that match are answered before a view is taken. Ranges are ignored when
+// If-Range does not match and for view limited pastes, every request takes a view and must therefore return the whole content.
+func (coord *DownloadCoordinator) StreamContent(req *pb.StreamContentRequest, stream pb.PasteDownload_StreamContentServer) error {
+	ctx := stream.Context()
+
+	metadata, err := coord.fetchMetadataService.FetchMetadataByKey(ctx, req.Key)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "paste %s is not available: %v", req.Key, err)
+	}
+	if err := coord.accessService.CheckVisibility(ctx, metadata, req.UserId); err != nil {
+		return accessError(req.Key, err)
+	}
+	if err := coord.accessService.CheckPassword(ctx, metadata, req.Password); err != nil {
+		return accessError(req.Key, err)
+	}
+	if err := checkFileSelection(metadata, req.File); err != nil {
+		return err
+	}
+
+	objectKey, object, err := coord.fetchContentService.StatContent(ctx, req.Key, req.File)
+	if errors.Is(err, repository.ErrFileNotFound) {
+		return fileError(req.Key, req.File, err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to stat paste: %v", err)
+	}
+	info := &pb.ContentInfo{
+		Size:         object.Size,
+		ContentType:  object.ContentType,
+		Etag:         object.ETag,
+		LastModified: timestamppb.New(object.LastModified),
+	}
+
+	var ifModifiedSince time.Time
+	if req.IfModifiedSince != nil {
+		ifModifiedSince = req.IfModifiedSince.AsTime()
+	}
+	if services.NotModified(object, req.IfNoneMatch, ifModifiedSince) {
+		info.NotModified = true
+		return stream.Send(&pb.StreamContentResponse{Data: &pb.StreamContentResponse_Info{Info: info}})
+	}
+
+	var ifRangeDate time.Time
+	if req.IfRangeDate != nil {
+		ifRangeDate = req.IfRangeDate.AsTime()
+	}
+	hasRange := req.HasRange && !metadata.ViewLimited && services.RangeApplies(object, req.IfRangeEtag, ifRangeDate)
+	var rangeErr error
+	info.Offset, info.Length, rangeErr = services.ResolveRange(hasRange, req.Offset, req.Length, object.Size)
+	info.Partial = hasRange && rangeErr == nil
+	// The size travels with the info, so the caller can report it when the range is rejected
+	if err := stream.Send(&pb.StreamContentResponse{Data: &pb.StreamContentResponse_Info{Info: info}}); err != nil {
+		return err
+	}
+	if rangeErr != nil {
+		return status.Errorf(codes.OutOfRange, "range is outside of the %d bytes of paste %s", object.Size, req.Key)
+	}
+
+	if err := coord.accessService.ConsumeView(ctx, metadata); err != nil {
+		return accessError(req.Key, err)
+	}
+
+	body, err := coord.fetchContentService.OpenContent(ctx, objectKey, info.Offset, info.Length)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read paste: %v", err)
+	}
+	defer body.Close()
+
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.StreamContentResponse{Data: &pb.StreamContentResponse_Chunk{Chunk: buf[:n]}}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read paste: %v", err)
+		}
+	}
+}
+
+// DownloadBundle streams every file of a bundle as a single archive. The archive is one read
+// of the paste and takes one view of view limited pastes.
+func (coord *DownloadCoordinator) DownloadBundle(req *pb.DownloadBundleRequest, stream pb.PasteDownload_DownloadBundleServer) error {
+	ctx := stream.Context()
+
+	metadata, err := coord.fetchMetadataService.FetchMetadataByKey(ctx, req.Key)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "paste %s is not available: %v", req.Key, err)
+	}
+	if err := coord.accessService.CheckVisibility(ctx, metadata, req.UserId); err != nil {
+		return accessError(req.Key, err)
+	}
+	if err := coord.accessService.CheckPassword(ctx, metadata, req.Password); err != nil {
+		return accessError(req.Key, err)
+	}
+	if metadata.FileCount == 0 {
+		return status.Errorf(codes.FailedPrecondition, "paste %s is not a bundle", req.Key)
+	}
+	if _, ok := pb.ArchiveFormat_name[int32(req.Format)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown archive format %v", req.Format)
+	}
+
+	files, err := coord.fetchContentService.ListFiles(ctx, req.Key)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list files: %v", err)
+	}
+	if err := coord.accessService.ConsumeView(ctx, metadata); err != nil {
+		return accessError(req.Key, err)
+	}
+
+	w := bufio.NewWriterSize(&bundleStreamWriter{stream: stream}, streamChunkSize)
+	if err := coord.bundleService.WriteArchive(ctx, w, files, metadata.CreatedAt.AsTime(), req.Format); err != nil {
+		return status.Errorf(codes.Internal, "failed to build archive: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send archive: %v", err)
+	}
+	return nil
+}
+
+// bundleStreamWriter sends everything written to it as archive chunks.
+type bundleStreamWriter struct {
+	stream pb.PasteDownload_DownloadBundleServer
+}
+
+func (w *bundleStreamWriter) Write(p []byte) (int, error) {
+	// The message is serialized by Send, p can be reused once it returns
+	if err := w.stream.Send(&pb.DownloadBundleResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// checkFileSelection makes sure a file is named for bundles and only for bundles.
+func checkFileSelection(metadata *pb.Metadata, file string) error {
+	if metadata.FileCount > 0 && file == "" {
+		return status.Errorf(codes.FailedPrecondition, "paste %s is a bundle, a file must be selected", metadata.Key)
+	}
+	if metadata.FileCount == 0 && file != "" {
+		return status.Errorf(codes.NotFound, "paste %s has no file %s", metadata.Key, file)
+	}
+	return nil
+}
+
+func fileError(key, file string, err error) error {
+	if errors.Is(err, repository.ErrFileNotFound) {
+		return status.Errorf(codes.NotFound, "paste %s has no file %s", key, file)
+	}
+	return status.Errorf(codes.Internal, "failed to load file %s: %v", file, err)
+}
+
+// authorizeHistory guards the version endpoints. Resolving the metadata rejects unknown
+// and expired pastes, view limited pastes expose no history since reading it would bypass
+// the view counter.
+func (coord *DownloadCoordinator) authorizeHistory(ctx context.Context, key, userId, password string) error {
+	metadata, err := coord.fetchMetadataService.FetchMetadataByKey(ctx, key)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "paste %s is not available: %v", key, err)
+	}
+	if err := coord.accessService.CheckVisibility(ctx, metadata, userId); err != nil {
+		return accessError(key, err)
+	}
+	if err := coord.accessService.CheckPassword(ctx, metadata, password); err != nil {
+		return accessError(key, err)
+	}
+	if metadata.ViewLimited {
+		return status.Errorf(codes.FailedPrecondition, "paste %s is view limited and has no public history", key)
+	}
+	return nil
+}
+
+func accessError(key string, err error) error {
+	switch {
+	case errors.Is(err, services.ErrAccessDenied):
+		// Hidden pastes are reported as missing so a guessed key does not reveal that the paste exists
+		return status.Errorf(codes.NotFound, "paste %s not found", key)
+	case errors.Is(err, services.ErrPasswordRequired):
+		return status.Errorf(codes.Unauthenticated, "paste %s requires a password", key)
+	case errors.Is(err, services.ErrInvalidPassword):
+		return status.Errorf(codes.PermissionDenied, "invalid password for paste %s", key)
+	case errors.Is(err, repository.ErrNoViewsLeft):
+		return status.Errorf(codes.NotFound, "paste %s is no longer available", key)
+	default:
+		return status.Errorf(codes.Internal, "failed to authorize access to paste %s: %v", key, err)
+	}
+}
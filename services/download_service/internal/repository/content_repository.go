@@ -2,51 +2,76 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"time"
 
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/sony/gobreaker"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 )
 
 type ContentRepo struct {
-	S3     *s3.PresignClient
-	beaker *middleware.CircuitBreakerMiddleware
-	bucket string
+	storage storage.Storage
+	beaker  *middleware.CircuitBreakerMiddleware
 }
 
-func NewContentRepository(bucket, region string) (*ContentRepo, error) {
-	// Load AWS configuration with specified region
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
-	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
-	}
-	cbSettings := gobreaker.Settings{
-		Name:        "MetadataRepo",
+func NewContentRepository(store storage.Storage) *ContentRepo {
+	cbConfig := middleware.CircuitBreakerConfig{
 		MaxRequests: 5,                // Max requests allowed in half-open state
 		Interval:    10 * time.Second, // Time window for tracking errors
 		Timeout:     30 * time.Second, // Time to reset the circuit after tripping
 	}
 
-	presignClient := s3.NewPresignClient(s3.NewFromConfig(cfg))
-
 	return &ContentRepo{
-		S3:     presignClient,
-		beaker: middleware.NewCircuitBreakerMiddleware(cbSettings),
-		bucket: bucket,
-	}, nil
+		storage: store,
+		beaker:  middleware.NewCircuitBreakerMiddleware(cbConfig, "ContentRepo"),
+	}
 }
 
 func (repo *ContentRepo) GenerateDownloadURL(key string, ctx context.Context) (string, error) {
-	req, err := repo.S3.PresignGetObject(ctx, &s3.GetObjectInput{
-		Bucket: &repo.bucket,
-		Key:    &key,
-	}, s3.WithPresignExpires(time.Minute*10))
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.PresignGetURL(ctx, key, time.Minute*10)
+	}
+
+	url, err := repo.beaker.Execute(ctx, operation)
 	if err != nil {
 		return "", err
 	}
-	return req.URL, nil
+	return url.(string), nil
+}
+
+func (repo *ContentRepo) GetContent(objectKey string, ctx context.Context) ([]byte, error) {
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.GetPaste(objectKey)
+	}
+
+	content, err := repo.beaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return content.([]byte), nil
 }
 
+func (repo *ContentRepo) StatContent(objectKey string, ctx context.Context) (*storage.ObjectInfo, error) {
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.StatPaste(ctx, objectKey)
+	}
+
+	info, err := repo.beaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return info.(*storage.ObjectInfo), nil
+}
+
+// GetContentRange opens a reader over length bytes of the object starting at offset, the caller closes it.
+func (repo *ContentRepo) GetContentRange(objectKey string, offset, length int64, ctx context.Context) (io.ReadCloser, error) {
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.GetPasteRange(ctx, objectKey, offset, length)
+	}
+
+	body, err := repo.beaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return body.(io.ReadCloser), nil
+}
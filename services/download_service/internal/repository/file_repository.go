@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+)
+
+// ErrFileNotFound is returned when a bundle has no file with the requested name.
+var ErrFileNotFound = errors.New("file not found")
+
+// FileObject is a file of a bundle together with the storage key of its content.
+type FileObject struct {
+	File      *pb.PasteFile
+	ObjectKey string
+}
+
+type FileRepo struct {
+	DB      *sql.DB
+	breaker *middleware.CircuitBreakerMiddleware
+}
+
+// NewFileRepo creates a new instance of FileRepo
+func NewFileRepo(db *sql.DB) *FileRepo {
+	cbConfig := middleware.CircuitBreakerConfig{
+		MaxRequests: 5,                // Max requests allowed in half-open state
+		Interval:    10 * time.Second, // Time window for tracking errors
+		Timeout:     30 * time.Second, // Time to reset the circuit after tripping
+	}
+	return &FileRepo{
+		DB:      db,
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "FileRepo"),
+	}
+}
+
+// ListFiles returns the files of a bundle in the order they were uploaded.
+func (repo *FileRepo) ListFiles(ctx context.Context, key string) ([]FileObject, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT name, COALESCE(language, ''), size, object_key FROM paste_files WHERE key = $1 ORDER BY position`
+		rows, err := repo.DB.QueryContext(ctx, query, key)
+		if err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		defer rows.Close()
+
+		var files []FileObject
+		for rows.Next() {
+			var f pb.PasteFile
+			var objectKey string
+			if err := rows.Scan(&f.Name, &f.Language, &f.Size, &objectKey); err != nil {
+				return nil, fmt.Errorf("scan failed: %w", err)
+			}
+			files = append(files, FileObject{File: &f, ObjectKey: objectKey})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+		return files, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.([]FileObject), nil
+}
+
+// GetFile returns a single file of a bundle by its name.
+func (repo *FileRepo) GetFile(ctx context.Context, key, name string) (FileObject, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT name, COALESCE(language, ''), size, object_key FROM paste_files WHERE key = $1 AND name = $2`
+		var f pb.PasteFile
+		var objectKey string
+		err := repo.DB.QueryRowContext(ctx, query, key, name).Scan(&f.Name, &f.Language, &f.Size, &objectKey)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrFileNotFound
+			}
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return FileObject{File: &f, ObjectKey: objectKey}, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return FileObject{}, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.(FileObject), nil
+}
@@ -3,16 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
 	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
-	"github.com/sony/gobreaker"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ErrNoViewsLeft is returned when a view limited paste has already been read the maximum number of times.
+var ErrNoViewsLeft = errors.New("no views left")
+
 type MetadataRepo struct {
 	DB      *sql.DB
 	breaker *middleware.CircuitBreakerMiddleware
@@ -20,30 +27,47 @@ type MetadataRepo struct {
 
 // NewMetadataRepo creates a new instance of MetadataRepository
 func NewMetadataRepo(db *sql.DB) *MetadataRepo {
-	cbSettings := gobreaker.Settings{
-		Name:        "MetadataRepo",
+	cbConfig := middleware.CircuitBreakerConfig{
 		MaxRequests: 5,                // Max requests allowed in half-open state
 		Interval:    10 * time.Second, // Time window for tracking errors
 		Timeout:     30 * time.Second, // Time to reset the circuit after tripping
 	}
 	return &MetadataRepo{
 		DB:      db,
-		breaker: middleware.NewCircuitBreakerMiddleware(cbSettings),
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "MetadataRepo"),
 	}
 }
 
 func (repo *MetadataRepo) DownloadPasteMetadata(ctx context.Context, key string) (*pb.Metadata, error) {
 	operation := func(ctx context.Context) (any, error) {
-		query := `SELECT key, title, created_at, expiration_date FROM metadata WHERE key = $1`
+		query := `
+        SELECT key, title, created_at, expiration_date, password_hash IS NOT NULL, max_views IS NOT NULL, COALESCE(max_views, 0),
+               visibility, COALESCE(user_id, ''), encryption_header IS NOT NULL, COALESCE(language, ''),
+               (SELECT COUNT(*) FROM paste_files WHERE paste_files.key = metadata.key), metadata_version,
+               COALESCE(collection_id, 0), ARRAY(SELECT tag FROM paste_tags WHERE paste_tags.key = metadata.key ORDER BY tag)
+        FROM metadata WHERE key = $1 AND status = 'active'
+        `
 		var paste pb.Metadata
 		var createdAt time.Time
 		var expiredDate time.Time
+		var visibility string
 
 		err := repo.DB.QueryRowContext(ctx, query, key).Scan(
 			&paste.Key,
 			&paste.Title,
 			&createdAt,
 			&expiredDate,
+			&paste.PasswordProtected,
+			&paste.ViewLimited,
+			&paste.RemainingViews,
+			&visibility,
+			&paste.UserId,
+			&paste.Encrypted,
+			&paste.Language,
+			&paste.FileCount,
+			&paste.MetadataVersion,
+			&paste.CollectionId,
+			pq.Array(&paste.Tags),
 		)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -53,6 +77,7 @@ func (repo *MetadataRepo) DownloadPasteMetadata(ctx context.Context, key string)
 		}
 		paste.CreatedAt = timestamppb.New(createdAt)
 		paste.ExpiredDate = timestamppb.New(expiredDate)
+		paste.Visibility = parseVisibility(visibility)
 		return &paste, nil
 	}
 
@@ -68,31 +93,161 @@ func (repo *MetadataRepo) DownloadPasteMetadata(ctx context.Context, key string)
 	return paste, nil
 }
 
-func (repo *MetadataRepo) DownloadMetadataByUserId(ctx context.Context, userId string, limit, offset int) ([]*pb.Metadata, error) {
+// ErrInvalidCursor is returned for a pagination cursor that was not issued for the requested listing.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// UserPastesFilter narrows down and orders the pastes listed for their owner.
+type UserPastesFilter struct {
+	Tag          string // Only pastes with this tag, empty for all
+	CollectionID int64  // Only pastes in this collection, 0 for all
+	Sort         pb.PasteSort
+}
+
+// UserPastesPage is one page of the pastes of a user.
+type UserPastesPage struct {
+	Pastes     []*pb.Metadata
+	TotalCount int32  // Number of pastes matching the filter on all pages
+	NextCursor string // Empty on the last page
+}
+
+// userPastesOrder describes how a sort order pages through the pastes. value is the sort
+// column as text, which is what the cursor stores, and after selects the pastes following the
+// cursor. The key breaks ties so pages neither overlap nor skip pastes.
+type userPastesOrder struct {
+	value   string
+	after   string
+	orderBy string
+}
+
+var userPastesOrders = map[pb.PasteSort]userPastesOrder{
+	pb.PasteSort_PASTE_SORT_CREATED_AT: {
+		value:   "created_at::text",
+		after:   "(created_at, key) < ($6::timestamptz, $7)",
+		orderBy: "created_at DESC, key DESC",
+	},
+	pb.PasteSort_PASTE_SORT_EXPIRATION: {
+		value:   "expiration_date::text",
+		after:   "(expiration_date, key) > ($6::timestamptz, $7)",
+		orderBy: "expiration_date, key",
+	},
+	pb.PasteSort_PASTE_SORT_TITLE: {
+		value:   "LOWER(COALESCE(title, ''))",
+		after:   "(LOWER(COALESCE(title, '')), key) > ($6, $7)",
+		orderBy: "LOWER(COALESCE(title, '')), key",
+	},
+}
+
+// userPastesCursor points at the last paste of a page. It is handed to clients as opaque
+// base64 encoded JSON.
+type userPastesCursor struct {
+	Sort  pb.PasteSort `json:"s"`
+	Value string       `json:"v"`
+	Key   string       `json:"k"`
+}
+
+func encodeUserPastesCursor(cursor userPastesCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeUserPastesCursor(raw string, sort pb.PasteSort) (*userPastesCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor userPastesCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Key == "" {
+		return nil, ErrInvalidCursor
+	}
+	// A cursor only makes sense for the order it was issued for
+	if cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// DownloadMetadataByUserId lists a page of the active pastes of a user. With a cursor the page
+// starts after the paste the cursor points at and offset is ignored, without one the first
+// offset pastes are skipped.
+func (repo *MetadataRepo) DownloadMetadataByUserId(ctx context.Context, userId string, filter UserPastesFilter, cursor string, limit, offset int) (*UserPastesPage, error) {
+	order, ok := userPastesOrders[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort order %v", filter.Sort)
+	}
+	var after *userPastesCursor
+	if cursor != "" {
+		var err error
+		if after, err = decodeUserPastesCursor(cursor, filter.Sort); err != nil {
+			return nil, err
+		}
+		offset = 0
+	}
+
 	operation := func(ctx context.Context) (any, error) {
-		query := `SELECT key, title, created_at, expiration_date FROM metadata WHERE user_id = $1 LIMIT $2 OFFSET $3`
-		rows, err := repo.DB.QueryContext(ctx, query, userId, limit, offset)
+		where := `
+        WHERE user_id = $1 AND status = 'active'
+          AND ($2 = '' OR EXISTS (SELECT 1 FROM paste_tags WHERE paste_tags.key = metadata.key AND paste_tags.tag = $2))
+          AND ($3 = 0 OR collection_id = $3)
+        `
+		page := &UserPastesPage{}
+		if err := repo.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM metadata`+where, userId, filter.Tag, filter.CollectionID).Scan(&page.TotalCount); err != nil {
+			return nil, fmt.Errorf("count failed: %w", err)
+		}
+
+		// One paste more than requested tells whether there is a next page
+		args := []any{userId, filter.Tag, filter.CollectionID, limit + 1, offset}
+		if after != nil {
+			where += " AND " + order.after
+			args = append(args, after.Value, after.Key)
+		}
+		query := `
+        SELECT key, COALESCE(title, ''), created_at, expiration_date, password_hash IS NOT NULL, max_views IS NOT NULL, COALESCE(max_views, 0),
+               visibility, COALESCE(user_id, ''), encryption_header IS NOT NULL, COALESCE(language, ''),
+               (SELECT COUNT(*) FROM paste_files WHERE paste_files.key = metadata.key), metadata_version,
+               COALESCE(collection_id, 0), ARRAY(SELECT tag FROM paste_tags WHERE paste_tags.key = metadata.key ORDER BY tag),
+               ` + order.value + `
+        FROM metadata` + where + `
+        ORDER BY ` + order.orderBy + `
+        LIMIT $4 OFFSET $5
+        `
+		rows, err := repo.DB.QueryContext(ctx, query, args...)
 		if err != nil {
 			return nil, fmt.Errorf("query failed: %w", err)
 		}
 		defer rows.Close()
 
-		var metadata []*pb.Metadata
+		var last userPastesCursor
 		for rows.Next() {
 			var expiredDate time.Time
 			var createdAt time.Time
+			var visibility string
+			var sortValue string
 			var m pb.Metadata
-			if err := rows.Scan(&m.Key, &m.Title, &createdAt, &expiredDate); err != nil { // FIX: Added `&` before m.Title
+			if err := rows.Scan(&m.Key, &m.Title, &createdAt, &expiredDate, &m.PasswordProtected, &m.ViewLimited, &m.RemainingViews, &visibility, &m.UserId, &m.Encrypted, &m.Language, &m.FileCount, &m.MetadataVersion, &m.CollectionId, pq.Array(&m.Tags), &sortValue); err != nil {
 				return nil, fmt.Errorf("scan failed: %w", err)
 			}
+			if len(page.Pastes) == limit {
+				// The extra paste only signals the next page, which starts after the last one returned
+				cursor, err := encodeUserPastesCursor(last)
+				if err != nil {
+					return nil, err
+				}
+				page.NextCursor = cursor
+				break
+			}
 			m.CreatedAt = timestamppb.New(createdAt)
 			m.ExpiredDate = timestamppb.New(expiredDate)
-			metadata = append(metadata, &m)
+			m.Visibility = parseVisibility(visibility)
+			page.Pastes = append(page.Pastes, &m)
+			last = userPastesCursor{Sort: filter.Sort, Value: sortValue, Key: m.Key}
 		}
 		if err := rows.Err(); err != nil {
 			return nil, fmt.Errorf("rows error: %w", err)
 		}
-		return metadata, nil
+		return page, nil
 	}
 
 	result, err := repo.breaker.Execute(ctx, operation)
@@ -100,9 +255,107 @@ func (repo *MetadataRepo) DownloadMetadataByUserId(ctx context.Context, userId s
 		return nil, fmt.Errorf("circuit breaker error: %w", err)
 	}
 
-	metadata, ok := result.([]*pb.Metadata)
+	page, ok := result.(*UserPastesPage)
 	if !ok {
 		return nil, errors.New("unexpected result type")
 	}
-	return metadata, nil
+	return page, nil
+}
+
+func (repo *MetadataRepo) GetPasswordHash(ctx context.Context, key string) ([]byte, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT password_hash FROM metadata WHERE key = $1`
+		var hash []byte
+		if err := repo.DB.QueryRowContext(ctx, query, key).Scan(&hash); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("no paste found with key %s: %w", key, err)
+			}
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return hash, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.([]byte), nil
+}
+
+// ConsumeView atomically takes one view from a view limited paste and returns how many are left.
+// The decrement and the check happen in a single statement, so concurrent readers can never
+// exceed max_views. Taking the last view expires the paste, the cleanup service deletes its
+// metadata and content with the next expired pastes.
+func (repo *MetadataRepo) ConsumeView(ctx context.Context, key string) (int32, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `
+        UPDATE metadata SET
+            max_views = max_views - 1,
+            expiration_date = CASE WHEN max_views = 1 THEN NOW() ELSE expiration_date END
+        WHERE key = $1 AND max_views > 0
+        RETURNING max_views
+        `
+		var remaining int32
+		if err := repo.DB.QueryRowContext(ctx, query, key).Scan(&remaining); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrNoViewsLeft
+			}
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return remaining, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return 0, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.(int32), nil
+}
+
+func (repo *MetadataRepo) GetEncryptionHeader(ctx context.Context, key string) (*pb.EncryptionHeader, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT encryption_header FROM metadata WHERE key = $1 AND encryption_header IS NOT NULL`
+		var raw string
+		if err := repo.DB.QueryRowContext(ctx, query, key).Scan(&raw); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("no encrypted paste found with key %s: %w", key, err)
+			}
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+
+		var header pb.EncryptionHeader
+		if err := protojson.Unmarshal([]byte(raw), &header); err != nil {
+			return nil, fmt.Errorf("failed to decode encryption header: %w", err)
+		}
+		return &header, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.(*pb.EncryptionHeader), nil
+}
+
+// IsSharedWith reports whether the owner of a shared paste granted the user read access.
+func (repo *MetadataRepo) IsSharedWith(ctx context.Context, key, userId string) (bool, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT EXISTS(SELECT 1 FROM paste_acl WHERE key = $1 AND user_id = $2)`
+		var shared bool
+		if err := repo.DB.QueryRowContext(ctx, query, key, userId).Scan(&shared); err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return shared, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return false, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.(bool), nil
+}
+
+// parseVisibility maps the value stored in the metadata table to the visibility enum, e.g. "private" to VISIBILITY_PRIVATE.
+func parseVisibility(visibility string) pb.Visibility {
+	return pb.Visibility(pb.Visibility_value["VISIBILITY_"+strings.ToUpper(visibility)])
 }
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SearchRepo struct {
+	DB      *sql.DB
+	breaker *middleware.CircuitBreakerMiddleware
+}
+
+// NewSearchRepo creates a new instance of SearchRepo
+func NewSearchRepo(db *sql.DB) *SearchRepo {
+	cbConfig := middleware.CircuitBreakerConfig{
+		MaxRequests: 5,                // Max requests allowed in half-open state
+		Interval:    10 * time.Second, // Time window for tracking errors
+		Timeout:     30 * time.Second, // Time to reset the circuit after tripping
+	}
+	return &SearchRepo{
+		DB:      db,
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "SearchRepo"),
+	}
+}
+
+// SearchPastes ranks the user's live pastes against a web search style query. The snippet comes
+// from the indexed content, or the title when no content has been indexed.
+func (repo *SearchRepo) SearchPastes(ctx context.Context, userId, query string, limit, offset int) ([]*pb.SearchResult, error) {
+	operation := func(ctx context.Context) (any, error) {
+		sqlQuery := `
+        SELECT m.key, COALESCE(m.title, ''), m.created_at, m.expiration_date, m.visibility,
+               ts_rank(s.document, q) AS rank,
+               ts_headline('simple', COALESCE(NULLIF(s.content, ''), m.title, ''), q,
+                   'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')
+        FROM paste_search s
+        JOIN metadata m ON m.key = s.key,
+             websearch_to_tsquery('simple', $2) q
+        WHERE m.user_id = $1 AND m.status = 'active' AND m.expiration_date > NOW() AND s.document @@ q
+        ORDER BY rank DESC, m.created_at DESC
+        LIMIT $3 OFFSET $4
+        `
+		rows, err := repo.DB.QueryContext(ctx, sqlQuery, userId, query, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		defer rows.Close()
+
+		var results []*pb.SearchResult
+		for rows.Next() {
+			var createdAt time.Time
+			var expiredDate time.Time
+			var visibility string
+			m := &pb.Metadata{UserId: userId}
+			res := &pb.SearchResult{Metadata: m}
+			if err := rows.Scan(&m.Key, &m.Title, &createdAt, &expiredDate, &visibility, &res.Rank, &res.Snippet); err != nil {
+				return nil, fmt.Errorf("scan failed: %w", err)
+			}
+			m.CreatedAt = timestamppb.New(createdAt)
+			m.ExpiredDate = timestamppb.New(expiredDate)
+			m.Visibility = parseVisibility(visibility)
+			results = append(results, res)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+		return results, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.([]*pb.SearchResult), nil
+}
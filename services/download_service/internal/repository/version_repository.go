@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrVersionNotFound is returned when the requested revision of a paste does not exist.
+var ErrVersionNotFound = errors.New("version not found")
+
+type VersionRepo struct {
+	DB      *sql.DB
+	breaker *middleware.CircuitBreakerMiddleware
+}
+
+// NewVersionRepo creates a new instance of VersionRepo
+func NewVersionRepo(db *sql.DB) *VersionRepo {
+	cbConfig := middleware.CircuitBreakerConfig{
+		MaxRequests: 5,                // Max requests allowed in half-open state
+		Interval:    10 * time.Second, // Time window for tracking errors
+		Timeout:     30 * time.Second, // Time to reset the circuit after tripping
+	}
+	return &VersionRepo{
+		DB:      db,
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "VersionRepo"),
+	}
+}
+
+// ListVersions returns the revisions of the paste whose upload was completed, oldest first.
+func (repo *VersionRepo) ListVersions(ctx context.Context, key string) ([]*pb.PasteVersion, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT key, version, created_at FROM paste_versions WHERE key = $1 AND status = 'active' ORDER BY version`
+		rows, err := repo.DB.QueryContext(ctx, query, key)
+		if err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		defer rows.Close()
+
+		var versions []*pb.PasteVersion
+		for rows.Next() {
+			var createdAt time.Time
+			var v pb.PasteVersion
+			if err := rows.Scan(&v.Key, &v.Version, &createdAt); err != nil {
+				return nil, fmt.Errorf("scan failed: %w", err)
+			}
+			v.CreatedAt = timestamppb.New(createdAt)
+			versions = append(versions, &v)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+		return versions, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.([]*pb.PasteVersion), nil
+}
+
+// GetVersion returns the revision together with the storage key of its content.
+func (repo *VersionRepo) GetVersion(ctx context.Context, key string, version int32) (*pb.PasteVersion, string, error) {
+	type result struct {
+		version   *pb.PasteVersion
+		objectKey string
+	}
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT key, version, object_key, created_at FROM paste_versions WHERE key = $1 AND version = $2 AND status = 'active'`
+		var res result
+		var v pb.PasteVersion
+		var createdAt time.Time
+		err := repo.DB.QueryRowContext(ctx, query, key, version).Scan(&v.Key, &v.Version, &res.objectKey, &createdAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrVersionNotFound
+			}
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		v.CreatedAt = timestamppb.New(createdAt)
+		res.version = &v
+		return res, nil
+	}
+
+	out, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, "", fmt.Errorf("circuit breaker error: %w", err)
+	}
+	res := out.(result)
+	return res.version, res.objectKey, nil
+}
+
+// LatestObjectKey returns the storage key of the newest revision of the paste whose upload was
+// completed. Pastes uploaded before versioning was introduced are stored under their own key.
+func (repo *VersionRepo) LatestObjectKey(ctx context.Context, key string) (string, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `SELECT object_key FROM paste_versions WHERE key = $1 AND status = 'active' ORDER BY version DESC LIMIT 1`
+		var objectKey string
+		err := repo.DB.QueryRowContext(ctx, query, key).Scan(&objectKey)
+		if errors.Is(err, sql.ErrNoRows) {
+			return key, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		return objectKey, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return "", fmt.Errorf("circuit breaker error: %w", err)
+	}
+	return result.(string), nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/cache"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordRequired = errors.New("paste is password protected")
+	ErrInvalidPassword  = errors.New("invalid paste password")
+	ErrAccessDenied     = errors.New("paste is not visible to the caller")
+)
+
+// AccessService enforces the visibility, the access password and the view limit of a paste.
+type AccessService struct {
+	repo          *repository.MetadataRepo
+	cache         cache.Cache
+	kafkaProducer *kafka.KafkaProducer
+}
+
+func NewAccessService(repo *repository.MetadataRepo, cache cache.Cache, kafkaProducer *kafka.KafkaProducer) *AccessService {
+	return &AccessService{
+		repo:          repo,
+		cache:         cache,
+		kafkaProducer: kafkaProducer,
+	}
+}
+
+// CheckVisibility verifies that the caller may read the paste. userId is empty for anonymous callers.
+func (svc *AccessService) CheckVisibility(ctx context.Context, metadata *pb.Metadata, userId string) error {
+	switch metadata.Visibility {
+	case pb.Visibility_VISIBILITY_PUBLIC, pb.Visibility_VISIBILITY_UNLISTED:
+		return nil
+	}
+
+	if userId == "" {
+		return ErrAccessDenied
+	}
+	if userId == metadata.UserId {
+		return nil
+	}
+	if metadata.Visibility != pb.Visibility_VISIBILITY_SHARED {
+		return ErrAccessDenied
+	}
+
+	shared, err := svc.repo.IsSharedWith(ctx, metadata.Key, userId)
+	if err != nil {
+		return fmt.Errorf("failed to check paste readers: %w", err)
+	}
+	if !shared {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// CheckPassword verifies the password of a protected paste, unprotected pastes are always accessible.
+func (svc *AccessService) CheckPassword(ctx context.Context, metadata *pb.Metadata, password string) error {
+	if !metadata.PasswordProtected {
+		return nil
+	}
+	if password == "" {
+		return ErrPasswordRequired
+	}
+
+	hash, err := svc.repo.GetPasswordHash(ctx, metadata.Key)
+	if err != nil {
+		return fmt.Errorf("failed to load password hash: %w", err)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrInvalidPassword
+		}
+		return err
+	}
+	return nil
+}
+
+// ConsumeView counts a read of a view limited paste. The read that uses up the
+// last view expires the paste and schedules it for deletion right away, the cleanup
+// scheduler reaps it otherwise.
+func (svc *AccessService) ConsumeView(ctx context.Context, metadata *pb.Metadata) error {
+	if !metadata.ViewLimited {
+		return nil
+	}
+
+	remaining, err := svc.repo.ConsumeView(ctx, metadata.Key)
+	if err != nil {
+		return err
+	}
+	metadata.RemainingViews = remaining
+
+	if remaining == 0 {
+		if err := svc.cache.Delete(ctx, metadata.Key); err != nil {
+			return fmt.Errorf("failed to delete burned paste from cache: %w", err)
+		}
+		go retryKafkaMessage(svc.kafkaProducer, metadata.Key, 3)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+)
+
+// ErrUnknownArchiveFormat is returned for archive formats the service cannot produce.
+var ErrUnknownArchiveFormat = errors.New("unknown archive format")
+
+type BundleService struct {
+	contentRepo *repository.ContentRepo
+}
+
+func NewBundleService(contentRepo *repository.ContentRepo) *BundleService {
+	return &BundleService{contentRepo: contentRepo}
+}
+
+// WriteArchive packs the files of a bundle into w. Files are copied from storage one at a time,
+// the archive is never held in memory as a whole.
+func (svc *BundleService) WriteArchive(ctx context.Context, w io.Writer, files []repository.FileObject, modified time.Time, format pb.ArchiveFormat) error {
+	switch format {
+	case pb.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ:
+		return svc.writeTarGz(ctx, w, files, modified)
+	case pb.ArchiveFormat_ARCHIVE_FORMAT_ZIP:
+		return svc.writeZip(ctx, w, files, modified)
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownArchiveFormat, format)
+	}
+}
+
+func (svc *BundleService) writeTarGz(ctx context.Context, w io.Writer, files []repository.FileObject, modified time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    f.File.Name,
+			Mode:    0o644,
+			Size:    f.File.Size,
+			ModTime: modified,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", f.File.Name, err)
+		}
+		if err := svc.copyFile(ctx, tw, f); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return gz.Close()
+}
+
+func (svc *BundleService) writeZip(ctx context.Context, w io.Writer, files []repository.FileObject, modified time.Time) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		entry, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.File.Name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", f.File.Name, err)
+		}
+		if err := svc.copyFile(ctx, entry, f); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+func (svc *BundleService) copyFile(ctx context.Context, w io.Writer, f repository.FileObject) error {
+	body, err := svc.contentRepo.GetContentRange(f.ObjectKey, 0, 0, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", f.File.Name, err)
+	}
+	defer body.Close()
+
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("failed to copy file %s: %w", f.File.Name, err)
+	}
+	return nil
+}
@@ -2,26 +2,42 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
 )
 
 type FetchContentService struct {
-	repo   *repository.ContentRepo
-	logger *jsonlog.Logger
+	repo        *repository.ContentRepo
+	versionRepo *repository.VersionRepo
+	fileRepo    *repository.FileRepo
+	logger      *jsonlog.Logger
 }
 
-func NewFetchContentService(repo *repository.ContentRepo, log *jsonlog.Logger) (*FetchContentService, error) {
+func NewFetchContentService(repo *repository.ContentRepo, versionRepo *repository.VersionRepo, fileRepo *repository.FileRepo, log *jsonlog.Logger) (*FetchContentService, error) {
 	return &FetchContentService{
-		repo:   repo,
-		logger: log,
+		repo:        repo,
+		versionRepo: versionRepo,
+		fileRepo:    fileRepo,
+		logger:      log,
 	}, nil
 }
 
+// GetContentUrl returns a download URL for the latest revision of the paste.
 func (svc *FetchContentService) GetContentUrl(ctx context.Context, key string) (string, error) {
-	url, err := svc.repo.GenerateDownloadURL(key, ctx)
+	objectKey, err := svc.versionRepo.LatestObjectKey(ctx, key)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Resolving latest version failed: %w", err), map[string]string{"key": key})
+		return "", fmt.Errorf("could not resolve latest version: %w", err)
+	}
+
+	url, err := svc.repo.GenerateDownloadURL(objectKey, ctx)
 	if err != nil {
 		svc.logger.PrintError(ctx, fmt.Errorf("Generattion down load url failed: %w", err), map[string]string{"key": key})
 		return "", fmt.Errorf("could not generate url: %w", err)
@@ -29,3 +45,150 @@ func (svc *FetchContentService) GetContentUrl(ctx context.Context, key string) (
 
 	return url, nil
 }
+
+// ListFiles returns the manifest of a bundle.
+func (svc *FetchContentService) ListFiles(ctx context.Context, key string) ([]repository.FileObject, error) {
+	files, err := svc.fileRepo.ListFiles(ctx, key)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Listing bundle files failed: %w", err), map[string]string{"key": key})
+		return nil, fmt.Errorf("could not list files: %w", err)
+	}
+	return files, nil
+}
+
+// GetFile returns a single file of a bundle.
+func (svc *FetchContentService) GetFile(ctx context.Context, key, name string) (repository.FileObject, error) {
+	file, err := svc.fileRepo.GetFile(ctx, key, name)
+	if err != nil && !errors.Is(err, repository.ErrFileNotFound) {
+		svc.logger.PrintError(ctx, fmt.Errorf("Loading bundle file failed: %w", err), map[string]string{"key": key})
+	}
+	return file, err
+}
+
+// objectKey resolves the storage key of a file of a bundle, or of the latest revision when file is empty.
+func (svc *FetchContentService) objectKey(ctx context.Context, key, file string) (string, error) {
+	if file != "" {
+		f, err := svc.GetFile(ctx, key, file)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve file %s: %w", file, err)
+		}
+		return f.ObjectKey, nil
+	}
+
+	objectKey, err := svc.versionRepo.LatestObjectKey(ctx, key)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Resolving latest version failed: %w", err), map[string]string{"key": key})
+		return "", fmt.Errorf("could not resolve latest version: %w", err)
+	}
+	return objectKey, nil
+}
+
+// GetContent reads the latest revision of the paste, or one file of a bundle, from storage.
+func (svc *FetchContentService) GetContent(ctx context.Context, key, file string) ([]byte, error) {
+	objectKey, err := svc.objectKey(ctx, key, file)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := svc.repo.GetContent(objectKey, ctx)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Reading paste content failed: %w", err), map[string]string{"key": key})
+		return nil, fmt.Errorf("could not read content: %w", err)
+	}
+	return content, nil
+}
+
+// StatContent describes the latest revision of the paste, or one file of a bundle, and returns the
+// object it is stored in. Stores that do not know the content type report it as a generic binary
+// type, it is then sniffed from the first bytes of the content.
+func (svc *FetchContentService) StatContent(ctx context.Context, key, file string) (string, *storage.ObjectInfo, error) {
+	objectKey, err := svc.objectKey(ctx, key, file)
+	if err != nil {
+		return "", nil, err
+	}
+
+	info, err := svc.repo.StatContent(objectKey, ctx)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Stat of paste content failed: %w", err), map[string]string{"key": key})
+		return "", nil, fmt.Errorf("could not stat content: %w", err)
+	}
+
+	if info.ContentType == "" || info.ContentType == "application/octet-stream" || info.ContentType == "binary/octet-stream" {
+		body, err := svc.repo.GetContentRange(objectKey, 0, sniffLen, ctx)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not read content: %w", err)
+		}
+		defer body.Close()
+		head, err := io.ReadAll(body)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not read content: %w", err)
+		}
+		info.ContentType = http.DetectContentType(head)
+	}
+	return objectKey, info, nil
+}
+
+// OpenContent opens a reader over length bytes of the object starting at offset, the caller closes it.
+func (svc *FetchContentService) OpenContent(ctx context.Context, objectKey string, offset, length int64) (io.ReadCloser, error) {
+	body, err := svc.repo.GetContentRange(objectKey, offset, length, ctx)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Opening paste content failed: %w", err), map[string]string{"object_key": objectKey})
+		return nil, fmt.Errorf("could not read content: %w", err)
+	}
+	return body, nil
+}
+
+// sniffLen is the number of bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// ErrRangeNotSatisfiable is returned when a requested range lies outside of the content.
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// ResolveRange turns a requested range into the offset and length of the bytes to send.
+// A negative offset selects the last -offset bytes, a length of 0 reads to the end.
+// Without a range the whole content is selected.
+func ResolveRange(hasRange bool, offset, length, size int64) (int64, int64, error) {
+	if !hasRange {
+		return 0, size, nil
+	}
+	if offset < 0 {
+		n := min(-offset, size)
+		if n == 0 {
+			return 0, 0, ErrRangeNotSatisfiable
+		}
+		return size - n, n, nil
+	}
+	if offset >= size || length < 0 {
+		return 0, 0, ErrRangeNotSatisfiable
+	}
+	if length == 0 || length > size-offset {
+		length = size - offset
+	}
+	return offset, length, nil
+}
+
+// NotModified evaluates the conditional request headers against the stored content.
+// If-None-Match takes precedence, If-Modified-Since is only consulted without it.
+func NotModified(info *storage.ObjectInfo, ifNoneMatch []string, ifModifiedSince time.Time) bool {
+	if len(ifNoneMatch) > 0 {
+		for _, etag := range ifNoneMatch {
+			if etag == "*" || etag == info.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	// HTTP dates have a resolution of one second
+	return !ifModifiedSince.IsZero() && !info.LastModified.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// RangeApplies evaluates If-Range against the stored content. The range is only sent when the
+// strong ETag or the modification date of the validator matches, otherwise the whole content is.
+// Without a validator the range always applies.
+func RangeApplies(info *storage.ObjectInfo, ifRangeETag string, ifRangeDate time.Time) bool {
+	if ifRangeETag != "" {
+		return ifRangeETag == info.ETag
+	}
+	// HTTP dates have a resolution of one second
+	return ifRangeDate.IsZero() || info.LastModified.Truncate(time.Second).Equal(ifRangeDate)
+}
@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/cache"
@@ -43,6 +45,11 @@ func (svc *FetchMetadataService) FetchMetadataByKey(ctx context.Context, key str
 	if err := svc.checkAndHandleExpiration(ctx, metadata); err != nil {
 		return nil, err
 	}
+	// Protected pastes are never cached, every read has to go through the password check
+	// and the view counter in the database
+	if metadata.PasswordProtected || metadata.ViewLimited {
+		return metadata, nil
+	}
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -54,17 +61,43 @@ func (svc *FetchMetadataService) FetchMetadataByKey(ctx context.Context, key str
 	return metadata, nil
 }
 
-func (svc *FetchMetadataService) FetchMetadataByUserId(ctx context.Context, userId string, limit, offence int) ([]*pb.Metadata, error) {
+// PasteUpdatedTopic is the topic the upload service announces edited pastes on.
+const PasteUpdatedTopic = "paste-updated"
+
+// HandlePasteUpdated applies a message of PasteUpdatedTopic. The cached metadata of the paste is
+// dropped, the next read loads the edit from the database.
+func (svc *FetchMetadataService) HandlePasteUpdated(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	var update struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(msg.Value, &update); err != nil {
+		return fmt.Errorf("failed to decode paste update: %w", err)
+	}
+	if err := svc.cache.Delete(ctx, update.Key); err != nil {
+		return fmt.Errorf("failed to delete updated cache entry: %w", err)
+	}
+	return nil
+}
+
+func (svc *FetchMetadataService) FetchEncryptionHeader(ctx context.Context, key string) (*pb.EncryptionHeader, error) {
+	header, err := svc.repo.GetEncryptionHeader(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch encryption header: %w", err)
+	}
+	return header, nil
+}
+
+func (svc *FetchMetadataService) FetchMetadataByUserId(ctx context.Context, userId string, filter repository.UserPastesFilter, cursor string, limit, offence int) (*repository.UserPastesPage, error) {
 	if err := validation.IsUserIdValid(userId); err != nil {
 		return nil, err
 	}
 
-	metadataList, err := svc.repo.DownloadMetadataByUserId(ctx, userId, limit, offence)
+	page, err := svc.repo.DownloadMetadataByUserId(ctx, userId, filter, cursor, limit, offence)
 	if err != nil {
 		return nil, err
 	}
 
-	return metadataList, nil
+	return page, nil
 }
 
 func (svc *FetchMetadataService) checkAndHandleExpiration(ctx context.Context, metadata *pb.Metadata) error {
@@ -77,14 +110,14 @@ func (svc *FetchMetadataService) checkAndHandleExpiration(ctx context.Context, m
 	}
 
 	// Async Kafka message with retry
-	go svc.retryKafkaMessage(metadata.Key, 3)
+	go retryKafkaMessage(svc.kafkaProducer, metadata.Key, 3)
 
 	return fmt.Errorf("paste with key '%s' has expired", metadata.Key)
 }
 
-func (svc *FetchMetadataService) retryKafkaMessage(key string, maxRetries int) {
+func retryKafkaMessage(producer *kafka.KafkaProducer, key string, maxRetries int) {
 	for i := 0; i < maxRetries; i++ {
-		err := svc.kafkaProducer.ProduceMessages(key, "delete-expired-paste")
+		err := producer.ProduceMessages(key, "delete-expired-paste")
 		if err == nil {
 			return
 		}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// MaxRenderSize caps the content that is highlighted on the server, larger pastes have to be downloaded.
+const MaxRenderSize = 1 << 20
+
+// renderStyle is the color scheme of both output formats.
+const renderStyle = "monokai"
+
+var (
+	ErrTooLargeToRender = fmt.Errorf("pastes larger than %d bytes cannot be rendered", MaxRenderSize)
+	ErrBinaryContent    = errors.New("binary content cannot be rendered")
+	ErrUnknownFormat    = errors.New("unknown render format")
+)
+
+type RenderService struct{}
+
+func NewRenderService() *RenderService {
+	return &RenderService{}
+}
+
+// Render highlights the content as the given language. When the language is unknown it is
+// guessed from the content, content that matches no lexer is rendered as plain text.
+func (svc *RenderService) Render(content []byte, language string, format pb.RenderFormat) (*pb.RenderPasteResponse, error) {
+	if len(content) > MaxRenderSize {
+		return nil, ErrTooLargeToRender
+	}
+	if !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0 {
+		return nil, ErrBinaryContent
+	}
+
+	var lexer chroma.Lexer
+	if language != "" {
+		lexer = lexers.Get(language)
+	}
+	if lexer == nil {
+		lexer = lexers.Analyse(string(content))
+	}
+	if lexer == nil {
+		lexer = lexers.Get("plaintext")
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	var formatter chroma.Formatter
+	var contentType string
+	switch format {
+	case pb.RenderFormat_RENDER_FORMAT_HTML:
+		formatter = html.New(html.Standalone(true), html.WithLineNumbers(true))
+		contentType = "text/html; charset=utf-8"
+	case pb.RenderFormat_RENDER_FORMAT_ANSI:
+		formatter = formatters.TTY256
+		contentType = "text/plain; charset=utf-8"
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, format)
+	}
+
+	iterator, err := lexer.Tokenise(nil, string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to tokenise content: %w", err)
+	}
+	var out bytes.Buffer
+	if err := formatter.Format(&out, styles.Get(renderStyle), iterator); err != nil {
+		return nil, fmt.Errorf("failed to format content: %w", err)
+	}
+
+	return &pb.RenderPasteResponse{
+		Content:     out.Bytes(),
+		ContentType: contentType,
+		Language:    strings.ToLower(lexer.Config().Name),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/validation"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// ErrInvalidSearch is returned when a search request is missing the user or the query.
+var ErrInvalidSearch = errors.New("invalid search request")
+
+type SearchService struct {
+	repo   *repository.SearchRepo
+	logger *jsonlog.Logger
+}
+
+func NewSearchService(repo *repository.SearchRepo, log *jsonlog.Logger) *SearchService {
+	return &SearchService{
+		repo:   repo,
+		logger: log,
+	}
+}
+
+func (svc *SearchService) SearchPastes(ctx context.Context, userId, query string, limit, offset int) ([]*pb.SearchResult, error) {
+	if err := validation.IsUserIdValid(userId); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidSearch)
+	}
+	if limit <= 0 || limit > maxSearchLimit {
+		limit = defaultSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	results, err := svc.repo.SearchPastes(ctx, userId, query, limit, offset)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Searching pastes failed: %w", err), map[string]string{"user_id": userId})
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+)
+
+type VersionService struct {
+	versionRepo *repository.VersionRepo
+	contentRepo *repository.ContentRepo
+	logger      *jsonlog.Logger
+}
+
+func NewVersionService(versionRepo *repository.VersionRepo, contentRepo *repository.ContentRepo, log *jsonlog.Logger) *VersionService {
+	return &VersionService{
+		versionRepo: versionRepo,
+		contentRepo: contentRepo,
+		logger:      log,
+	}
+}
+
+func (svc *VersionService) ListVersions(ctx context.Context, key string) ([]*pb.PasteVersion, error) {
+	versions, err := svc.versionRepo.ListVersions(ctx, key)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Listing versions failed: %w", err), map[string]string{"key": key})
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetVersion returns the revision with its full content.
+func (svc *VersionService) GetVersion(ctx context.Context, key string, version int32) (*pb.PasteVersion, []byte, error) {
+	v, objectKey, err := svc.versionRepo.GetVersion(ctx, key, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := svc.contentRepo.GetContent(objectKey, ctx)
+	if err != nil {
+		svc.logger.PrintError(ctx, fmt.Errorf("Loading version content failed: %w", err), map[string]string{
+			"key":     key,
+			"version": strconv.Itoa(int(version)),
+		})
+		return nil, nil, fmt.Errorf("could not load content of version %d: %w", version, err)
+	}
+	return v, content, nil
+}
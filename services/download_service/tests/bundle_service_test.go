@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteArchive(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+	contents := map[string]string{
+		"main.go":   "package main\n\nfunc main() {}\n",
+		"README.md": "# Bundle\n",
+	}
+	var files []repository.FileObject
+	for i, name := range []string{"main.go", "README.md"} {
+		objectKey := storage.FileObjectKey("bundle", int32(i))
+		assert.NoError(t, store.UploadPaste(objectKey, []byte(contents[name])))
+		files = append(files, repository.FileObject{
+			File:      &pb.PasteFile{Name: name, Size: int64(len(contents[name]))},
+			ObjectKey: objectKey,
+		})
+	}
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc := services.NewBundleService(repository.NewContentRepository(store))
+
+	t.Run("tar.gz", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, svc.WriteArchive(ctx, &buf, files, modified, pb.ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ))
+
+		gz, err := gzip.NewReader(&buf)
+		assert.NoError(t, err)
+		tr := tar.NewReader(gz)
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			body, err := io.ReadAll(tr)
+			assert.NoError(t, err)
+			assert.Equal(t, contents[header.Name], string(body))
+			assert.True(t, modified.Equal(header.ModTime))
+			names = append(names, header.Name)
+		}
+		assert.Equal(t, []string{"main.go", "README.md"}, names)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, svc.WriteArchive(ctx, &buf, files, modified, pb.ArchiveFormat_ARCHIVE_FORMAT_ZIP))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		assert.Len(t, zr.File, 2)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			body, err := io.ReadAll(rc)
+			rc.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, contents[f.Name], string(body))
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := svc.WriteArchive(ctx, io.Discard, files, modified, pb.ArchiveFormat(42))
+		assert.ErrorIs(t, err, services.ErrUnknownArchiveFormat)
+	})
+}
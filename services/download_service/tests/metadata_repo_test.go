@@ -2,9 +2,11 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
 	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,3 +33,239 @@ func TestDownloadMetadata(t *testing.T) {
 	assert.WithinDuration(t, expirationDate.AsTime(), res.ExpiredDate.AsTime(), time.Second)
 	assert.Equal(t, title, res.Title)
 }
+
+func TestPendingPasteIsHidden(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `
+        INSERT INTO metadata(key, title, user_id, expiration_date, status) 
+        VALUES ($1, NULLIF($2, ''), $3, $4, 'pending')
+    `
+	_, err := db.ExecContext(ctx, query, key, title, userId, expirationDate.AsTime())
+	assert.NoError(t, err)
+
+	// The content of a pending paste may not be in storage yet
+	repo := repository.NewMetadataRepo(db)
+	_, err = repo.DownloadPasteMetadata(ctx, key)
+	assert.Error(t, err)
+	page, err := repo.DownloadMetadataByUserId(ctx, userId, repository.UserPastesFilter{}, "", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Pastes)
+	assert.Zero(t, page.TotalCount)
+
+	_, err = db.ExecContext(ctx, `UPDATE metadata SET status = 'active' WHERE key = $1`, key)
+	assert.NoError(t, err)
+	res, err := repo.DownloadPasteMetadata(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, key, res.Key)
+}
+
+func TestFilterUserPastes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	var collectionId int64
+	err := db.QueryRowContext(ctx, `INSERT INTO collections(user_id, name) VALUES ($1, 'work') RETURNING id`, userId).Scan(&collectionId)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	pastes := []struct {
+		key        string
+		title      string
+		expiration time.Time
+		collection any
+		tag        string
+	}{
+		{"paste-aa", "beta", now.Add(3 * time.Hour), collectionId, "go"},
+		{"paste-bb", "Alpha", now.Add(1 * time.Hour), nil, "go"},
+		{"paste-cc", "gamma", now.Add(2 * time.Hour), collectionId, "sql"},
+	}
+	for i, p := range pastes {
+		query := `
+            INSERT INTO metadata(key, title, user_id, expiration_date, created_at, collection_id)
+            VALUES ($1, $2, $3, $4, $5, $6)
+        `
+		_, err := db.ExecContext(ctx, query, p.key, p.title, userId, p.expiration, now.Add(time.Duration(i)*time.Minute), p.collection)
+		assert.NoError(t, err)
+		_, err = db.ExecContext(ctx, `INSERT INTO paste_tags(key, tag) VALUES ($1, $2)`, p.key, p.tag)
+		assert.NoError(t, err)
+	}
+
+	repo := repository.NewMetadataRepo(db)
+	keys := func(filter repository.UserPastesFilter) []string {
+		res, err := repo.DownloadMetadataByUserId(ctx, userId, filter, "", 10, 0)
+		assert.NoError(t, err)
+		var keys []string
+		for _, m := range res.Pastes {
+			keys = append(keys, m.Key)
+		}
+		return keys
+	}
+
+	assert.Equal(t, []string{"paste-cc", "paste-bb", "paste-aa"}, keys(repository.UserPastesFilter{}))
+	assert.Equal(t, []string{"paste-bb", "paste-cc", "paste-aa"}, keys(repository.UserPastesFilter{Sort: pb.PasteSort_PASTE_SORT_EXPIRATION}))
+	assert.Equal(t, []string{"paste-bb", "paste-aa", "paste-cc"}, keys(repository.UserPastesFilter{Sort: pb.PasteSort_PASTE_SORT_TITLE}))
+	assert.Equal(t, []string{"paste-bb", "paste-aa"}, keys(repository.UserPastesFilter{Tag: "go", Sort: pb.PasteSort_PASTE_SORT_TITLE}))
+	assert.Equal(t, []string{"paste-cc", "paste-aa"}, keys(repository.UserPastesFilter{CollectionID: collectionId}))
+	assert.Equal(t, []string{"paste-aa"}, keys(repository.UserPastesFilter{Tag: "go", CollectionID: collectionId}))
+
+	res, err := repo.DownloadPasteMetadata(ctx, "paste-aa")
+	assert.NoError(t, err)
+	assert.Equal(t, collectionId, res.CollectionId)
+	assert.Equal(t, []string{"go"}, res.Tags)
+}
+
+func TestPaginateUserPastes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	// Two pastes share a creation time, the key has to keep them apart across pages
+	now := time.Now()
+	created := []time.Time{now, now.Add(time.Minute), now.Add(time.Minute), now.Add(2 * time.Minute), now.Add(3 * time.Minute)}
+	for i, createdAt := range created {
+		query := `
+            INSERT INTO metadata(key, title, user_id, expiration_date, created_at)
+            VALUES ($1, $2, $3, $4, $5)
+        `
+		_, err := db.ExecContext(ctx, query, fmt.Sprintf("paste-%d", i), fmt.Sprintf("title %d", i), userId, now.Add(time.Duration(5-i)*time.Hour), createdAt)
+		assert.NoError(t, err)
+	}
+
+	repo := repository.NewMetadataRepo(db)
+	walk := func(sort pb.PasteSort) []string {
+		var keys []string
+		cursor := ""
+		for {
+			page, err := repo.DownloadMetadataByUserId(ctx, userId, repository.UserPastesFilter{Sort: sort}, cursor, 2, 0)
+			if !assert.NoError(t, err) {
+				return keys
+			}
+			assert.Equal(t, int32(len(created)), page.TotalCount)
+			for _, m := range page.Pastes {
+				keys = append(keys, m.Key)
+			}
+			if page.NextCursor == "" {
+				return keys
+			}
+			cursor = page.NextCursor
+		}
+	}
+
+	assert.Equal(t, []string{"paste-4", "paste-3", "paste-2", "paste-1", "paste-0"}, walk(pb.PasteSort_PASTE_SORT_CREATED_AT))
+	assert.Equal(t, []string{"paste-4", "paste-3", "paste-2", "paste-1", "paste-0"}, walk(pb.PasteSort_PASTE_SORT_EXPIRATION))
+	assert.Equal(t, []string{"paste-0", "paste-1", "paste-2", "paste-3", "paste-4"}, walk(pb.PasteSort_PASTE_SORT_TITLE))
+
+	// Cursors are bound to the order they were issued for
+	page, err := repo.DownloadMetadataByUserId(ctx, userId, repository.UserPastesFilter{}, "", 2, 0)
+	assert.NoError(t, err)
+	_, err = repo.DownloadMetadataByUserId(ctx, userId, repository.UserPastesFilter{Sort: pb.PasteSort_PASTE_SORT_TITLE}, page.NextCursor, 2, 0)
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+	_, err = repo.DownloadMetadataByUserId(ctx, userId, repository.UserPastesFilter{}, "not a cursor", 2, 0)
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+}
+
+func TestSharedPasteAccess(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `
+        INSERT INTO metadata(key, title, user_id, expiration_date, visibility) 
+        VALUES ($1, $2, $3, $4, 'shared')
+    `
+	_, err := db.ExecContext(ctx, query, key, title, userId, expirationDate.AsTime())
+	assert.NoError(t, err)
+	_, err = db.ExecContext(ctx, `INSERT INTO paste_acl(key, user_id) VALUES ($1, $2)`, key, "reader")
+	assert.NoError(t, err)
+
+	repo := repository.NewMetadataRepo(db)
+	res, err := repo.DownloadPasteMetadata(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Visibility_VISIBILITY_SHARED, res.Visibility)
+	assert.Equal(t, userId, res.UserId)
+
+	shared, err := repo.IsSharedWith(ctx, key, "reader")
+	assert.NoError(t, err)
+	assert.True(t, shared)
+
+	shared, err = repo.IsSharedWith(ctx, key, "stranger")
+	assert.NoError(t, err)
+	assert.False(t, shared)
+}
+
+func TestDownloadEncryptionHeader(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `
+        INSERT INTO metadata(key, title, user_id, expiration_date, encryption_header) 
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	header := `{"algorithm": "AES-256-GCM", "kdf": "none", "nonce": "AAAAAAAAAAAAAAAA", "keyCheck": "Y2hlY2s="}`
+	_, err := db.ExecContext(ctx, query, key, title, userId, expirationDate.AsTime(), header)
+	assert.NoError(t, err)
+
+	repo := repository.NewMetadataRepo(db)
+	res, err := repo.DownloadPasteMetadata(ctx, key)
+	assert.NoError(t, err)
+	assert.True(t, res.Encrypted)
+
+	encryption, err := repo.GetEncryptionHeader(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "AES-256-GCM", encryption.Algorithm)
+	assert.Len(t, encryption.Nonce, 12)
+	assert.Equal(t, []byte("check"), encryption.KeyCheck)
+}
+
+func TestBurnAfterRead(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `
+        INSERT INTO metadata(key, title, user_id, expiration_date, max_views) 
+        VALUES ($1, NULLIF($2, ''), $3, $4, 2)
+    `
+	_, err := db.ExecContext(ctx, query, key, title, userId, expirationDate.AsTime())
+	assert.NoError(t, err)
+
+	repo := repository.NewMetadataRepo(db)
+	remaining, err := repo.ConsumeView(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), remaining)
+	res, err := repo.DownloadPasteMetadata(ctx, key)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, expirationDate.AsTime(), res.ExpiredDate.AsTime(), time.Second)
+
+	// The last view expires the paste right away
+	remaining, err = repo.ConsumeView(ctx, key)
+	assert.NoError(t, err)
+	assert.Zero(t, remaining)
+	_, err = repo.ConsumeView(ctx, key)
+	assert.ErrorIs(t, err, repository.ErrNoViewsLeft)
+
+	// The cleanup service deletes it with the other expired pastes
+	var deleted string
+	err = db.QueryRowContext(ctx, `DELETE FROM metadata WHERE expiration_date <= $1 RETURNING key`, time.Now()).Scan(&deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, key, deleted)
+	_, err = repo.DownloadPasteMetadata(ctx, key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/NesterovYehor/TextNest/services/download_service/api"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPaste(t *testing.T) {
+	svc := services.NewRenderService()
+	content := []byte("package main\n\nfunc main() {}\n")
+
+	res, err := svc.Render(content, "go", pb.RenderFormat_RENDER_FORMAT_HTML)
+	assert.NoError(t, err)
+	assert.Equal(t, "go", res.Language)
+	assert.Equal(t, "text/html; charset=utf-8", res.ContentType)
+	assert.Contains(t, string(res.Content), "<html>")
+	assert.Contains(t, string(res.Content), "package")
+
+	res, err = svc.Render(content, "go", pb.RenderFormat_RENDER_FORMAT_ANSI)
+	assert.NoError(t, err)
+	assert.Contains(t, string(res.Content), "\x1b[")
+	assert.NotContains(t, string(res.Content), "<span")
+
+	// Content that matches no lexer is rendered as plain text
+	res, err = svc.Render([]byte("just some words"), "", pb.RenderFormat_RENDER_FORMAT_ANSI)
+	assert.NoError(t, err)
+	assert.Equal(t, "plaintext", res.Language)
+
+	_, err = svc.Render([]byte{0xff, 0xfe, 0x00}, "", pb.RenderFormat_RENDER_FORMAT_HTML)
+	assert.ErrorIs(t, err, services.ErrBinaryContent)
+
+	_, err = svc.Render([]byte(strings.Repeat("a", services.MaxRenderSize+1)), "", pb.RenderFormat_RENDER_FORMAT_HTML)
+	assert.ErrorIs(t, err, services.ErrTooLargeToRender)
+}
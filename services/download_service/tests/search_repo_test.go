@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPastes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	pastes := []struct {
+		key, title, content string
+	}{
+		{"search-1", "nginx config", "server { listen 80; proxy_pass http://backend; }"},
+		{"search-2", "shopping list", "milk, eggs and a new nginx book"},
+		{"search-3", "notes", "nothing relevant here"},
+	}
+	for _, p := range pastes {
+		_, err := db.ExecContext(ctx, `INSERT INTO metadata (key, title, user_id, expiration_date) VALUES ($1, $2, $3, $4)`,
+			p.key, p.title, userId, expirationDate.AsTime())
+		assert.NoError(t, err)
+		_, err = db.ExecContext(ctx, `
+            INSERT INTO paste_search (key, content, document)
+            VALUES ($1, $3, setweight(to_tsvector('simple', $2), 'A') || setweight(to_tsvector('simple', $3), 'C'))`,
+			p.key, p.title, p.content)
+		assert.NoError(t, err)
+	}
+
+	repo := repository.NewSearchRepo(db)
+
+	results, err := repo.SearchPastes(ctx, userId, "nginx", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	// A title match outranks a content match.
+	assert.Equal(t, "search-1", results[0].Metadata.Key)
+	assert.Equal(t, "search-2", results[1].Metadata.Key)
+	assert.Contains(t, results[1].Snippet, "<mark>nginx</mark>")
+
+	results, err = repo.SearchPastes(ctx, "another-user", "nginx", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -34,12 +34,65 @@ func SetUpPostgres(ctx context.Context, t *testing.T) (*sql.DB, func()) {
 
 	// Run migrations (or a minimal schema for testing)
 	query := `
+    CREATE TABLE IF NOT EXISTS collections (
+        id BIGSERIAL PRIMARY KEY,
+        user_id TEXT NOT NULL,
+        name TEXT NOT NULL,
+        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+        UNIQUE (user_id, name)
+        );
     CREATE TABLE IF NOT EXISTS metadata (
         key VARCHAR NOT NULL UNIQUE,
         title TEXT,
         user_id TEXT DEFAULT NULL,
         created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
-        expiration_date TIMESTAMP WITH TIME ZONE NOT NULL
+        expiration_date TIMESTAMP WITH TIME ZONE NOT NULL,
+        password_hash BYTEA DEFAULT NULL,
+        max_views INTEGER DEFAULT NULL CHECK (max_views >= 0),
+        visibility TEXT NOT NULL DEFAULT 'public',
+        encryption_header JSONB DEFAULT NULL,
+        language TEXT DEFAULT NULL,
+        status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('pending', 'active')),
+        size BIGINT DEFAULT NULL,
+        checksum TEXT DEFAULT NULL,
+        content_type TEXT DEFAULT NULL,
+        metadata_version BIGINT NOT NULL DEFAULT 1,
+        collection_id BIGINT DEFAULT NULL REFERENCES collections (id) ON DELETE SET NULL
+        );
+    CREATE TABLE IF NOT EXISTS paste_tags (
+        key VARCHAR NOT NULL REFERENCES metadata (key) ON DELETE CASCADE,
+        tag TEXT NOT NULL,
+        PRIMARY KEY (key, tag)
+        );
+    CREATE TABLE IF NOT EXISTS paste_search (
+        key VARCHAR PRIMARY KEY REFERENCES metadata (key) ON DELETE CASCADE,
+        content TEXT NOT NULL DEFAULT '',
+        document TSVECTOR NOT NULL
+        );
+    CREATE TABLE IF NOT EXISTS paste_acl (
+        key VARCHAR NOT NULL REFERENCES metadata (key) ON DELETE CASCADE,
+        user_id TEXT NOT NULL,
+        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+        PRIMARY KEY (key, user_id)
+        );
+    CREATE TABLE IF NOT EXISTS paste_versions (
+        key VARCHAR NOT NULL,
+        version INTEGER NOT NULL,
+        object_key TEXT NOT NULL,
+        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+        status TEXT NOT NULL DEFAULT 'active',
+        PRIMARY KEY (key, version)
+        );
+    CREATE TABLE IF NOT EXISTS paste_files (
+        key VARCHAR NOT NULL,
+        position INTEGER NOT NULL,
+        name TEXT NOT NULL,
+        language TEXT DEFAULT NULL,
+        size BIGINT NOT NULL,
+        object_key TEXT NOT NULL,
+        created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+        PRIMARY KEY (key, position),
+        UNIQUE (key, name)
         );
 
     `
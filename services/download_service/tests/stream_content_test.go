@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRange(t *testing.T) {
+	tests := []struct {
+		name           string
+		hasRange       bool
+		offset, length int64
+		wantOffset     int64
+		wantLength     int64
+		wantErr        error
+	}{
+		{name: "no range", hasRange: false, wantOffset: 0, wantLength: 100},
+		{name: "bounded", hasRange: true, offset: 10, length: 20, wantOffset: 10, wantLength: 20},
+		{name: "open ended", hasRange: true, offset: 90, wantOffset: 90, wantLength: 10},
+		{name: "past the end is clamped", hasRange: true, offset: 50, length: 500, wantOffset: 50, wantLength: 50},
+		{name: "suffix", hasRange: true, offset: -30, wantOffset: 70, wantLength: 30},
+		{name: "suffix longer than content", hasRange: true, offset: -500, wantOffset: 0, wantLength: 100},
+		{name: "starts after the end", hasRange: true, offset: 100, wantErr: services.ErrRangeNotSatisfiable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, length, err := services.ResolveRange(tt.hasRange, tt.offset, tt.length, 100)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOffset, offset)
+			assert.Equal(t, tt.wantLength, length)
+		})
+	}
+
+	_, _, err := services.ResolveRange(true, -10, 0, 0)
+	assert.ErrorIs(t, err, services.ErrRangeNotSatisfiable)
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	info := &storage.ObjectInfo{ETag: "abc", LastModified: modified}
+
+	assert.True(t, services.NotModified(info, []string{"xyz", "abc"}, time.Time{}))
+	assert.True(t, services.NotModified(info, []string{"*"}, time.Time{}))
+	assert.False(t, services.NotModified(info, []string{"xyz"}, time.Time{}))
+	// If-None-Match wins over If-Modified-Since
+	assert.False(t, services.NotModified(info, []string{"xyz"}, modified.Add(time.Hour)))
+
+	assert.True(t, services.NotModified(info, nil, modified.Truncate(time.Second)))
+	assert.False(t, services.NotModified(info, nil, modified.Add(-time.Minute)))
+	assert.False(t, services.NotModified(info, nil, time.Time{}))
+}
+
+func TestRangeApplies(t *testing.T) {
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	info := &storage.ObjectInfo{ETag: "abc", LastModified: modified}
+
+	tests := []struct {
+		name string
+		etag string
+		date time.Time
+		want bool
+	}{
+		{name: "no validator", want: true},
+		{name: "matching etag", etag: "abc", want: true},
+		{name: "stale etag", etag: "xyz", want: false},
+		{name: "matching date", date: modified.Truncate(time.Second), want: true},
+		{name: "older date", date: modified.Add(-time.Minute), want: false},
+		{name: "newer date", date: modified.Add(time.Minute), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, services.RangeApplies(info, tt.etag, tt.date))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+	"github.com/NesterovYehor/TextNest/services/download_service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAndGetVersions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `INSERT INTO paste_versions(key, version, object_key) VALUES ($1, $2, $3)`
+	for _, version := range []int32{1, 2} {
+		_, err := db.ExecContext(ctx, query, key, version, storage.VersionObjectKey(key, version))
+		assert.NoError(t, err)
+	}
+
+	repo := repository.NewVersionRepo(db)
+
+	versions, err := repo.ListVersions(ctx, key)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, int32(1), versions[0].Version)
+	assert.Equal(t, int32(2), versions[1].Version)
+
+	version, objectKey, err := repo.GetVersion(ctx, key, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), version.Version)
+	assert.Equal(t, storage.VersionObjectKey(key, 2), objectKey)
+
+	latest, err := repo.LatestObjectKey(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.VersionObjectKey(key, 2), latest)
+
+	_, _, err = repo.GetVersion(ctx, key, 3)
+	assert.ErrorIs(t, err, repository.ErrVersionNotFound)
+}
+
+func TestPendingVersionIsNotServed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	query := `INSERT INTO paste_versions(key, version, object_key, status) VALUES ($1, $2, $3, $4)`
+	_, err := db.ExecContext(ctx, query, key, 1, storage.VersionObjectKey(key, 1), "active")
+	assert.NoError(t, err)
+	// The content of a pending revision may never be uploaded
+	_, err = db.ExecContext(ctx, query, key, 2, storage.VersionObjectKey(key, 2), "pending")
+	assert.NoError(t, err)
+
+	repo := repository.NewVersionRepo(db)
+
+	latest, err := repo.LatestObjectKey(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.VersionObjectKey(key, 1), latest)
+
+	versions, err := repo.ListVersions(ctx, key)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+
+	_, _, err = repo.GetVersion(ctx, key, 2)
+	assert.ErrorIs(t, err, repository.ErrVersionNotFound)
+}
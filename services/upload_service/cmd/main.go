@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/config"
@@ -42,8 +43,18 @@ func main() {
 	}
 	defer db.Close()
 
+	var producer *kafka.KafkaProducer
+	if cfg.Kafka != nil {
+		producer, err = kafka.NewProducer(*cfg.Kafka, ctx)
+		if err != nil {
+			log.PrintFatal(ctx, fmt.Errorf("failed to initialize Kafka producer: %w", err), nil)
+			return
+		}
+		defer producer.Close()
+	}
+
 	grpcSrv := grpc.NewGrpcServer(cfg.Grpc)
-	coord, err := coordinators.NewUploadCoordinator(cfg, log, db)
+	coord, err := coordinators.NewUploadCoordinator(cfg, log, db, producer)
 	if err != nil {
 		log.PrintFatal(ctx, fmt.Errorf("failed to initialize coordinator: %w", err), nil)
 		return
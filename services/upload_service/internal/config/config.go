@@ -3,20 +3,59 @@ package config
 import (
 	"context"
 	"fmt"
+	"mime"
 	"os"
+	"strings"
 
 	"github.com/NesterovYehor/TextNest/pkg/grpc"
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"gopkg.in/yaml.v3"
 )
 
 const DefaultConfigFile = "config.yaml"
 
 type Config struct {
-	Grpc       *grpc.GrpcConfig `yaml:"grpc"`
-	BucketName string           `yaml:"bucket_name"`
-	S3Region   string           `yaml:"region"`
-	DBURL      string           `yaml:"db"`
+	Grpc       *grpc.GrpcConfig   `yaml:"grpc"`
+	BucketName string             `yaml:"bucket_name"`
+	S3Region   string             `yaml:"region"`
+	DBURL      string             `yaml:"db"`
+	Storage    *storage.Config    `yaml:"storage"`
+	Limits     *UploadLimits      `yaml:"upload_limits"`
+	Kafka      *kafka.KafkaConfig `yaml:"kafka"` // Optional, publishes paste updates to the download service
+}
+
+// DefaultMaxUploadSize caps uploads of tiers without a configured limit, like the gateway caps streamed content.
+const DefaultMaxUploadSize = 10 << 20
+
+// UploadLimits restricts the content uploaded through presigned URLs. Storage does not enforce
+// them, they are checked when the upload is completed.
+type UploadLimits struct {
+	MaxSize      map[string]int64 `yaml:"max_size"`      // Bytes per role of the uploader: user, moderator or admin
+	AllowedTypes []string         `yaml:"allowed_types"` // MIME types the content may be detected as, text/* allows every text type
+}
+
+// MaxSizeFor returns the size limit of a user tier.
+func (l *UploadLimits) MaxSizeFor(tier string) int64 {
+	if size, ok := l.MaxSize[tier]; ok {
+		return size
+	}
+	return DefaultMaxUploadSize
+}
+
+// Allows reports whether content of the MIME type may be uploaded. Parameters like the charset are ignored.
+func (l *UploadLimits) Allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.AllowedTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+	return false
 }
 
 // LoadConfig loads the configuration from a YAML file.
@@ -48,8 +87,19 @@ func LoadConfig(log *jsonlog.Logger, ctx context.Context) (*Config, error) {
 	if cfg.DBURL == "" {
 		log.PrintFatal(ctx, fmt.Errorf("database URL is not set"), nil)
 	}
-	if cfg.BucketName == "" || cfg.S3Region == "" {
-		log.PrintError(ctx, fmt.Errorf("S3 configuration is incomplete, some features may be unavailable"), nil)
+	// Pastes are text, binary content is only accepted where it is explicitly allowed
+	if cfg.Limits == nil {
+		cfg.Limits = &UploadLimits{}
+	}
+	if len(cfg.Limits.AllowedTypes) == 0 {
+		cfg.Limits.AllowedTypes = []string{"text/*"}
+	}
+	// Configs without a storage section keep using S3 with the top-level bucket settings
+	if cfg.Storage == nil {
+		cfg.Storage = &storage.Config{Driver: storage.DriverS3, Bucket: cfg.BucketName, Region: cfg.S3Region}
+	}
+	if err := cfg.Storage.Validate(); err != nil {
+		log.PrintError(ctx, fmt.Errorf("storage configuration is invalid, some features may be unavailable: %w", err), nil)
 	}
 
 	return &cfg, nil
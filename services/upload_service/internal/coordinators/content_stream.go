@@ -0,0 +1,92 @@
+package coordinators
+
+import (
+	"errors"
+	"io"
+
+	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+)
+
+// contentStreamReader exposes the chunks of an UploadContent stream as an io.Reader,
+// so they can be handed to storage without buffering the whole paste.
+// The first headLimit bytes are kept in head for indexing.
+// For bundles every file header ends the current file, NextFile moves on to the next one.
+// Reading fails with errContentTooLarge once all files together exceed maxSize.
+type contentStreamReader struct {
+	stream    pb.PasteUpload_UploadContentServer
+	buf       []byte
+	size      int64
+	total     int64 // Size of all files read so far
+	maxSize   int64
+	tooLarge  bool
+	head      []byte
+	headLimit int
+	next      *pb.PasteFile // Header of the file following the current one
+	done      bool          // The client closed the stream
+}
+
+// errContentTooLarge is returned by contentStreamReader when the content exceeds its size limit.
+var errContentTooLarge = errors.New("content is too large")
+
+func (r *contentStreamReader) Read(p []byte) (int, error) {
+	ok, err := r.fill()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.buf)
+	if r.maxSize > 0 && r.total+int64(n) > r.maxSize {
+		r.tooLarge = true
+		return 0, errContentTooLarge
+	}
+	if missing := r.headLimit - len(r.head); missing > 0 {
+		r.head = append(r.head, p[:min(n, missing)]...)
+	}
+	r.buf = r.buf[n:]
+	r.size += int64(n)
+	r.total += int64(n)
+	return n, nil
+}
+
+// NextFile moves on to the next file of a bundle and returns its header. It returns nil when
+// the stream is exhausted or continues with content that does not belong to a named file.
+func (r *contentStreamReader) NextFile() (*pb.PasteFile, error) {
+	if _, err := r.fill(); err != nil {
+		return nil, err
+	}
+	file := r.next
+	if file != nil {
+		r.next, r.size, r.head = nil, 0, nil
+	}
+	return file, nil
+}
+
+// fill receives messages until there is content to read. It reports false at the end of
+// the current file, which is either the end of the stream or the header of the next file.
+func (r *contentStreamReader) fill() (bool, error) {
+	for len(r.buf) == 0 {
+		if r.done || r.next != nil {
+			return false, nil
+		}
+		req, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			r.done = true
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch data := req.Data.(type) {
+		case *pb.UploadContentRequest_Chunk:
+			r.buf = data.Chunk
+		case *pb.UploadContentRequest_File:
+			r.next = data.File
+		default:
+			return false, errors.New("metadata can only be sent in the first message")
+		}
+	}
+	return true, nil
+}
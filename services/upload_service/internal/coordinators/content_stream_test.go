@@ -0,0 +1,144 @@
+package coordinators
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeContentStream replays messages and then fails with err, or io.EOF when err is nil.
+type fakeContentStream struct {
+	grpc.ServerStream
+	msgs []*pb.UploadContentRequest
+	err  error
+}
+
+func (s *fakeContentStream) Recv() (*pb.UploadContentRequest, error) {
+	if len(s.msgs) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+func (s *fakeContentStream) SendAndClose(*pb.UploadContentResponse) error {
+	return nil
+}
+
+func chunk(data string) *pb.UploadContentRequest {
+	return &pb.UploadContentRequest{Data: &pb.UploadContentRequest_Chunk{Chunk: []byte(data)}}
+}
+
+func fileHeader(name string) *pb.UploadContentRequest {
+	return &pb.UploadContentRequest{Data: &pb.UploadContentRequest_File{File: &pb.PasteFile{Name: name}}}
+}
+
+func TestContentStreamReader(t *testing.T) {
+	errAborted := errors.New("stream aborted")
+
+	tests := []struct {
+		name         string
+		msgs         []*pb.UploadContentRequest
+		streamErr    error
+		maxSize      int64
+		wantContent  string
+		wantHead     string
+		wantErr      error
+		wantTooLarge bool
+	}{
+		{
+			name:        "chunks are read in order",
+			msgs:        []*pb.UploadContentRequest{chunk("hello "), chunk(""), chunk("world")},
+			wantContent: "hello world",
+			wantHead:    "hello wor",
+		},
+		{
+			name:        "empty stream",
+			wantContent: "",
+		},
+		{
+			name:        "content at the limit",
+			msgs:        []*pb.UploadContentRequest{chunk("12345"), chunk("678")},
+			maxSize:     8,
+			wantContent: "12345678",
+			wantHead:    "12345678",
+		},
+		{
+			name:         "content over the limit",
+			msgs:         []*pb.UploadContentRequest{chunk("12345"), chunk("6789")},
+			maxSize:      8,
+			wantErr:      errContentTooLarge,
+			wantTooLarge: true,
+		},
+		{
+			name:      "aborted stream",
+			msgs:      []*pb.UploadContentRequest{chunk("hello")},
+			streamErr: errAborted,
+			wantErr:   errAborted,
+		},
+		{
+			name:    "metadata after the first message",
+			msgs:    []*pb.UploadContentRequest{{Data: &pb.UploadContentRequest_Metadata{Metadata: &pb.UploadPasteRequest{}}}},
+			wantErr: errors.New("metadata can only be sent in the first message"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &contentStreamReader{
+				stream:    &fakeContentStream{msgs: tt.msgs, err: tt.streamErr},
+				headLimit: 9,
+				maxSize:   tt.maxSize,
+			}
+
+			data, err := io.ReadAll(r)
+			assert.Equal(t, tt.wantTooLarge, r.tooLarge)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContent, string(data))
+			assert.Equal(t, tt.wantHead, string(r.head))
+			assert.Equal(t, int64(len(tt.wantContent)), r.size)
+		})
+	}
+}
+
+func TestContentStreamReaderBundle(t *testing.T) {
+	r := &contentStreamReader{
+		stream: &fakeContentStream{msgs: []*pb.UploadContentRequest{
+			fileHeader("a.go"), chunk("package a"),
+			fileHeader("b.go"), chunk("package "), chunk("b"),
+		}},
+		headLimit: 64,
+		maxSize:   18,
+	}
+
+	var names, contents []string
+	for {
+		file, err := r.NextFile()
+		assert.NoError(t, err)
+		if file == nil {
+			break
+		}
+		data, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		names = append(names, file.Name)
+		contents = append(contents, string(data))
+		// Every file has its own head and size
+		assert.Equal(t, string(data), string(r.head))
+		assert.Equal(t, int64(len(data)), r.size)
+	}
+	assert.Equal(t, []string{"a.go", "b.go"}, names)
+	assert.Equal(t, []string{"package a", "package b"}, contents)
+	// The size limit covers all files together
+	assert.Equal(t, int64(18), r.total)
+}
@@ -5,37 +5,63 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/config"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/services"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	maxReportReasonLength   = 1000
+	maxAbuseReportsPageSize = 100
+)
+
+// Reasons of the ErrorInfo attached to a rejected upload, the gateway maps them to HTTP statuses
+const (
+	uploadErrorDomain           = "upload.textnest"
+	ReasonContentMissing        = "CONTENT_MISSING"
+	ReasonContentTooLarge       = "CONTENT_TOO_LARGE"
+	ReasonContentTypeNotAllowed = "CONTENT_TYPE_NOT_ALLOWED"
+	ReasonChecksumMismatch      = "CHECKSUM_MISMATCH"
+)
+
 type UploadCoordinator struct {
 	metadataService *services.MetadataManagementService
 	storageService  *services.ContentManagementService
+	eventService    *services.PasteEventService
+	collections     *services.CollectionService
 	mu              sync.Mutex
 	cfg             *config.Config
 	log             *jsonlog.Logger
 	pb.UnimplementedPasteUploadServer
 }
 
-func NewUploadCoordinator(cfg *config.Config, log *jsonlog.Logger, db *sql.DB) (*UploadCoordinator, error) {
+// NewUploadCoordinator wires the services of the upload service. producer is nil when Kafka is not configured.
+func NewUploadCoordinator(cfg *config.Config, log *jsonlog.Logger, db *sql.DB, producer *kafka.KafkaProducer) (*UploadCoordinator, error) {
 	metadataRepo := repository.NewMetadataRepository(db)
-	storageRepo, err := repository.NewContentRepository(cfg.BucketName, cfg.S3Region)
+	store, err := storage.New(cfg.Storage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize S3 repository: %w", err)
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
+	storageRepo := repository.NewContentRepository(store)
 	return &UploadCoordinator{
 		metadataService: services.NewMetadataManagementService(metadataRepo, log),
 		storageService:  services.NewStorageService(storageRepo, log),
+		eventService:    services.NewPasteEventService(producer),
+		collections:     services.NewCollectionService(repository.NewCollectionRepository(db), log),
 		mu:              sync.Mutex{},
 		cfg:             cfg,
 		log:             log,
@@ -56,7 +82,7 @@ func (uc *UploadCoordinator) UploadPaste(ctx context.Context, req *pb.UploadPast
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if err := uc.metadataService.ValidateAndSave(ctx, req); err != nil {
+		if err := uc.metadataService.ValidateAndSavePending(ctx, req); err != nil {
 			errChan <- fmt.Errorf("metadata save: %w", err)
 			cancel()
 		} else {
@@ -67,7 +93,7 @@ func (uc *UploadCoordinator) UploadPaste(ctx context.Context, req *pb.UploadPast
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		url, err := uc.storageService.GenerateUploadURL(ctx, req.Key)
+		url, err := uc.storageService.GenerateUploadURL(ctx, storage.UploadObjectKey(req.Key, 1))
 		if err != nil {
 			errChan <- fmt.Errorf("generate URL: %w", err)
 			cancel()
@@ -89,6 +115,289 @@ func (uc *UploadCoordinator) UploadPaste(ctx context.Context, req *pb.UploadPast
 	return &resp, nil
 }
 
+// CompleteUpload activates a paste uploaded through the URL of UploadPaste, or a revision
+// uploaded through the URL of UploadUpdates, once its content is in storage. Storage accepts
+// whatever is sent to a presigned URL, so the upload limits are enforced here. The content is
+// copied away from the presigned URL before it is checked, the URL stays usable until it
+// expires but can no longer change what is served.
+func (uc *UploadCoordinator) CompleteUpload(ctx context.Context, req *pb.CompleteUploadRequest) (*pb.CompleteUploadResponse, error) {
+	if req.Checksum == "" {
+		return nil, status.Error(codes.InvalidArgument, "checksum of the content must be provided")
+	}
+	userId, err := uc.metadataService.GetPasteOwner(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if userId != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "only the owner can complete the upload of a paste")
+	}
+
+	version := max(req.Version, 1)
+	if version == 1 {
+		pasteStatus, err := uc.metadataService.GetPasteStatus(ctx, req.Key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+		}
+		if pasteStatus != models.StatusPending {
+			return nil, status.Error(codes.FailedPrecondition, "the upload of the paste is already completed")
+		}
+	} else {
+		versionStatus, err := uc.metadataService.GetVersionStatus(ctx, req.Key, version)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.NotFound, "paste has no version %d", version)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to load version: %v", err)
+		}
+		if versionStatus != models.StatusPending {
+			return nil, status.Errorf(codes.FailedPrecondition, "the upload of version %d is already completed", version)
+		}
+	}
+
+	uploadKey := storage.UploadObjectKey(req.Key, version)
+	objectKey := storage.VersionObjectKey(req.Key, version)
+	if err := uc.storageService.CopyContent(ctx, uploadKey, objectKey); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, rejectUpload(ReasonContentMissing, "content of the paste was not uploaded yet", nil)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to check uploaded content: %v", err)
+	}
+	object, err := uc.storageService.StatContent(ctx, objectKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check uploaded content: %v", err)
+	}
+
+	// Rejected content is deleted right away. The paste or revision stays pending, it can be
+	// uploaded again to the same URL or the cleanup service reaps it
+	reject := func(reason, msg string, metadata map[string]string) error {
+		_ = uc.storageService.DeleteContent(context.Background(), objectKey)
+		_ = uc.storageService.DeleteContent(context.Background(), uploadKey)
+		return rejectUpload(reason, msg, metadata)
+	}
+	limits := uc.cfg.Limits
+	if maxSize := limits.MaxSizeFor(req.Tier); object.Size > maxSize {
+		return nil, reject(ReasonContentTooLarge, fmt.Sprintf("content must not be more than %d bytes long", maxSize), map[string]string{
+			"max_size": strconv.FormatInt(maxSize, 10),
+			"size":     strconv.FormatInt(object.Size, 10),
+		})
+	}
+	if !strings.EqualFold(req.Checksum, object.ETag) {
+		return nil, reject(ReasonChecksumMismatch, "checksum does not match the uploaded content", nil)
+	}
+	// Ciphertext always looks like binary content, the type of an encrypted paste cannot be checked
+	encrypted, err := uc.metadataService.IsPasteEncrypted(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	// The head is sniffed for the content type and indexed for search once the upload is activated
+	var head []byte
+	if !encrypted {
+		head, err = uc.storageService.ReadContentHead(ctx, objectKey, services.MaxIndexedContent)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check uploaded content: %v", err)
+		}
+		contentType := services.SniffContentType(head)
+		if !limits.Allows(contentType) {
+			return nil, reject(ReasonContentTypeNotAllowed, fmt.Sprintf("content of type %s is not allowed", contentType), map[string]string{
+				"content_type": contentType,
+			})
+		}
+		object.ContentType = contentType
+	}
+
+	if version == 1 {
+		if err := uc.metadataService.ActivatePaste(ctx, req.Key, object); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Error(codes.NotFound, "paste not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to activate paste: %v", err)
+		}
+	} else {
+		if err := uc.metadataService.ActivateVersion(ctx, req.Key, version, object); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.FailedPrecondition, "the upload of version %d is already completed", version)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to activate version: %v", err)
+		}
+		// Cached metadata and content of the paste belong to the previous revision
+		if err := uc.eventService.PasteUpdated(req.Key); err != nil {
+			uc.log.PrintError(ctx, err, map[string]string{"key": req.Key})
+		}
+	}
+	// Ciphertext is not worth indexing. A completed revision replaces the indexed content of the
+	// previous one, a failed index leaves the paste searchable by title and tags only
+	if !encrypted {
+		_ = uc.metadataService.IndexContent(ctx, req.Key, head)
+	}
+	// A leftover upload is only a copy of the served content, the cleanup service reaps it with the paste
+	_ = uc.storageService.DeleteContent(context.Background(), uploadKey)
+	return &pb.CompleteUploadResponse{
+		Key:         req.Key,
+		Size:        object.Size,
+		Checksum:    object.ETag,
+		ContentType: object.ContentType,
+		Version:     version,
+	}, nil
+}
+
+func (uc *UploadCoordinator) UploadContent(stream pb.PasteUpload_UploadContentServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive paste metadata: %v", err)
+	}
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain paste metadata")
+	}
+	if err := uc.metadataService.Validate(ctx, metadata); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// The metadata row is written only after the content is durably stored,
+	// so a paste never becomes visible without its content.
+	maxSize := uc.cfg.Limits.MaxSizeFor(metadata.Tier)
+	content := &contentStreamReader{stream: stream, headLimit: services.MaxIndexedContent, maxSize: maxSize}
+	file, err := content.NextFile()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive paste content: %v", err)
+	}
+	if file != nil {
+		return uc.uploadBundle(ctx, stream, metadata, content, file)
+	}
+
+	objectKey := storage.VersionObjectKey(metadata.Key, 1)
+	if err := uc.storageService.UploadContent(ctx, objectKey, content); err != nil {
+		if content.tooLarge {
+			return contentTooLarge(maxSize)
+		}
+		return status.Errorf(codes.Internal, "upload failed: %v", err)
+	}
+	if content.next != nil {
+		_ = uc.storageService.DeleteContent(context.Background(), objectKey)
+		return status.Error(codes.InvalidArgument, "file headers must precede all content of a bundle")
+	}
+	// Ciphertext always looks like binary content, the type of an encrypted paste cannot be checked
+	if metadata.Encryption == nil {
+		if contentType := services.SniffContentType(content.head); !uc.cfg.Limits.Allows(contentType) {
+			_ = uc.storageService.DeleteContent(context.Background(), objectKey)
+			return contentTypeNotAllowed(contentType)
+		}
+	}
+
+	// Ciphertext carries no syntax, encrypted pastes rely on the language and filename hints
+	if metadata.Encryption == nil {
+		metadata.Language = services.ResolveLanguage(metadata.Language, metadata.Filename, content.head)
+	}
+
+	if err := uc.metadataService.Save(ctx, metadata); err != nil {
+		_ = uc.storageService.DeleteContent(context.Background(), objectKey)
+		return status.Errorf(codes.Internal, "upload failed: %v", err)
+	}
+
+	// Ciphertext is not worth indexing. A failed index leaves the paste searchable by title and
+	// tags only, which does not justify failing the upload
+	if metadata.Encryption == nil {
+		_ = uc.metadataService.IndexContent(ctx, metadata.Key, content.head)
+	}
+
+	return stream.SendAndClose(&pb.UploadContentResponse{
+		Key:            metadata.Key,
+		Size:           content.size,
+		ExpirationDate: timestamppb.New(metadata.ExpirationDate.AsTime()),
+	})
+}
+
+// uploadBundle stores every file of a bundle under its own object and saves the manifest
+// once all of them are stored. A failure removes the files stored so far.
+func (uc *UploadCoordinator) uploadBundle(ctx context.Context, stream pb.PasteUpload_UploadContentServer, metadata *pb.UploadPasteRequest, content *contentStreamReader, file *pb.PasteFile) error {
+	// The encryption header holds a single nonce, it cannot protect several files
+	if metadata.Encryption != nil {
+		return status.Error(codes.InvalidArgument, "encrypted pastes cannot be bundles")
+	}
+
+	var (
+		files   []*models.PasteFile
+		names   = make(map[string]bool)
+		indexed []byte
+		size    int64
+	)
+	deleteFiles := func() {
+		for _, f := range files {
+			_ = uc.storageService.DeleteContent(context.Background(), f.ObjectKey)
+		}
+	}
+
+	for position := int32(0); file != nil; position++ {
+		if v := validation.ValidatePasteFile(file); !v.Valid() {
+			deleteFiles()
+			return status.Errorf(codes.InvalidArgument, "file validation errors: %v", v.Errors)
+		}
+		if names[file.Name] {
+			deleteFiles()
+			return status.Errorf(codes.InvalidArgument, "file %s is part of the bundle twice", file.Name)
+		}
+		if len(files) == validation.MaxBundleFiles {
+			deleteFiles()
+			return status.Errorf(codes.InvalidArgument, "a bundle can hold at most %d files", validation.MaxBundleFiles)
+		}
+		names[file.Name] = true
+
+		objectKey := storage.FileObjectKey(metadata.Key, position)
+		if err := uc.storageService.UploadContent(ctx, objectKey, content); err != nil {
+			deleteFiles()
+			if content.tooLarge {
+				return contentTooLarge(content.maxSize)
+			}
+			return status.Errorf(codes.Internal, "upload of file %s failed: %v", file.Name, err)
+		}
+		if contentType := services.SniffContentType(content.head); !uc.cfg.Limits.Allows(contentType) {
+			deleteFiles()
+			_ = uc.storageService.DeleteContent(context.Background(), objectKey)
+			return contentTypeNotAllowed(contentType)
+		}
+		files = append(files, &models.PasteFile{
+			Position:  position,
+			Name:      file.Name,
+			Language:  services.ResolveLanguage(file.Language, file.Name, content.head),
+			Size:      content.size,
+			ObjectKey: objectKey,
+		})
+		size += content.size
+		// File names are searchable together with the content of the files
+		if len(indexed) < services.MaxIndexedContent {
+			indexed = append(append(append(indexed, file.Name...), '\n'), content.head...)
+		}
+
+		var err error
+		if file, err = content.NextFile(); err != nil {
+			deleteFiles()
+			return status.Errorf(codes.InvalidArgument, "failed to receive paste content: %v", err)
+		}
+	}
+
+	if err := uc.metadataService.SaveBundle(ctx, metadata, files); err != nil {
+		deleteFiles()
+		return status.Errorf(codes.Internal, "upload failed: %v", err)
+	}
+	_ = uc.metadataService.IndexContent(ctx, metadata.Key, indexed)
+
+	manifest := make([]*pb.PasteFile, 0, len(files))
+	for _, f := range files {
+		manifest = append(manifest, &pb.PasteFile{Name: f.Name, Language: f.Language, Size: f.Size})
+	}
+	return stream.SendAndClose(&pb.UploadContentResponse{
+		Key:            metadata.Key,
+		Size:           size,
+		ExpirationDate: timestamppb.New(metadata.ExpirationDate.AsTime()),
+		Files:          manifest,
+	})
+}
+
 func (uc *UploadCoordinator) UploadUpdates(ctx context.Context, req *pb.UploadUpdatesRequest) (*pb.UploadUpdatesResponse, error) {
 	userId, err := uc.metadataService.GetPasteOwner(ctx, req.Key)
 	if err != nil {
@@ -97,20 +406,117 @@ func (uc *UploadCoordinator) UploadUpdates(ctx context.Context, req *pb.UploadUp
 	if userId != req.UserId {
 		return nil, status.Errorf(codes.PermissionDenied, "Upload content failed %v", err)
 	}
-	url, err := uc.storageService.GenerateUploadURL(ctx, req.Key)
+	pasteStatus, err := uc.metadataService.GetPasteStatus(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if pasteStatus == models.StatusPending {
+		return nil, status.Error(codes.FailedPrecondition, "the upload of the paste must be completed before it can be updated")
+	}
+	// A new revision would be encrypted under the nonce of the original header,
+	// reusing a nonce with the same key breaks AES-GCM and ChaCha20
+	encrypted, err := uc.metadataService.IsPasteEncrypted(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if encrypted {
+		return nil, status.Error(codes.FailedPrecondition, "encrypted pastes cannot be updated, upload a new paste instead")
+	}
+	bundle, err := uc.metadataService.IsPasteBundle(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if bundle {
+		return nil, status.Error(codes.FailedPrecondition, "bundles have no revisions, upload a new paste instead")
+	}
+	// Every update is uploaded as a new immutable revision instead of overwriting the previous
+	// content. The revision stays pending, readers keep getting the previous one until CompleteUpload
+	version, err := uc.metadataService.CreateVersion(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create new version: %v", err)
+	}
+	url, err := uc.storageService.GenerateUploadURL(ctx, storage.UploadObjectKey(req.Key, version))
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.UploadUpdatesResponse{UploadUrl: url}, nil
+	return &pb.UploadUpdatesResponse{UploadUrl: url, Version: version}, nil
 }
 
+// UpdatePasteMetadata edits the title, expiration and tags of a paste. The edit only applies to
+// the metadata version it was made against, a concurrent edit makes it fail with Aborted.
+func (uc *UploadCoordinator) UpdatePasteMetadata(ctx context.Context, req *pb.UpdatePasteMetadataRequest) (*pb.UpdatePasteMetadataResponse, error) {
+	if v := validation.ValidateMetadataUpdate(req); !v.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "metadata validation errors: %v", v.Errors)
+	}
+	userId, err := uc.metadataService.GetPasteOwner(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if userId == "" || userId != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "only the owner can edit a paste")
+	}
+	pasteStatus, err := uc.metadataService.GetPasteStatus(ctx, req.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if pasteStatus == models.StatusPending {
+		return nil, status.Error(codes.FailedPrecondition, "the upload of the paste must be completed before it can be edited")
+	}
+
+	change := &models.MetadataChange{
+		Version:    req.Version,
+		Title:      req.Title,
+		AddTags:    req.AddTags,
+		RemoveTags: req.RemoveTags,
+	}
+	if req.ExpirationDate != nil {
+		expirationDate := req.ExpirationDate.AsTime()
+		change.ExpirationDate = &expirationDate
+	}
+	metadata, err := uc.metadataService.UpdateMetadata(ctx, req.Key, change)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Error(codes.NotFound, "paste not found")
+	case errors.Is(err, repository.ErrEditConflict):
+		return nil, status.Error(codes.Aborted, "the paste was edited since the given version, reload it and try again")
+	case errors.Is(err, models.ErrTooManyTags):
+		return nil, status.Errorf(codes.InvalidArgument, "a paste can have at most %d tags", models.MaxPasteTags)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to update metadata: %v", err)
+	}
+
+	// The download service caches metadata for a day, without the event readers would see the old
+	// title and expiration until then. The edit is stored either way, so it is not failed
+	if err := uc.eventService.PasteUpdated(req.Key); err != nil {
+		uc.log.PrintError(ctx, err, map[string]string{"key": req.Key})
+	}
+
+	return &pb.UpdatePasteMetadataResponse{
+		Key:            metadata.Key,
+		Title:          metadata.Title,
+		ExpirationDate: timestamppb.New(metadata.ExpirationDate),
+		Tags:           metadata.Tags,
+		Version:        metadata.Version,
+	}, nil
+}
+
+// ExpirePaste expires a paste of the requesting user. Force expires a paste of any owner and is
+// only set by the gateway, after the auth service has checked the moderator role of the user
+// against its database and recorded the action. The RPC must not be reachable by anything else.
 func (uc *UploadCoordinator) ExpirePaste(ctx context.Context, req *pb.ExpirePasteRequest) (*pb.ExpirePasteResponse, error) {
 	userId, err := uc.metadataService.GetPasteOwner(ctx, req.Key)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
 		return nil, err
 	}
-	if userId != req.UserId {
+	// Moderators expire pastes of any owner, the gateway authorizes them before forcing it
+	if !req.Force && userId != req.UserId {
 		uc.log.PrintInfo(ctx, fmt.Sprintf("Provided userID: %v || Needed userID:%v", req.UserId, userId), nil)
 		return nil, status.Error(codes.PermissionDenied, "User is not denaied to expire this paste:%v")
 	}
@@ -118,6 +524,12 @@ func (uc *UploadCoordinator) ExpirePaste(ctx context.Context, req *pb.ExpirePast
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Expire paste failed:%v")
 	}
+	if req.Force {
+		// The paste is gone either way, open reports are only left for the moderators to close
+		if err := uc.metadataService.ResolveAbuseReports(ctx, req.Key, req.UserId); err != nil {
+			uc.log.PrintError(ctx, err, map[string]string{"key": req.Key})
+		}
+	}
 	return &pb.ExpirePasteResponse{Message: fmt.Sprintf("Paste %v is expired successfully", req.Key)}, nil
 }
 
@@ -128,6 +540,187 @@ func (uc *UploadCoordinator) ExpireAllPastesByUserID(ctx context.Context, req *p
 	return &pb.ExpireAllPastesByUserIDResponse{Message: fmt.Sprintf("All Pastes of user %v expired successfully", req.UserId)}, nil
 }
 
+// ReportPaste records a report of a paste that breaks the rules. Anonymous reports are accepted.
+func (uc *UploadCoordinator) ReportPaste(ctx context.Context, req *pb.ReportPasteRequest) (*pb.ReportPasteResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason must be provided")
+	}
+	if len(reason) > maxReportReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must not be more than %d bytes long", maxReportReasonLength)
+	}
+	id, err := uc.metadataService.ReportPaste(ctx, req.Key, req.ReporterId, reason)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to report paste: %v", err)
+	}
+	return &pb.ReportPasteResponse{Id: id}, nil
+}
+
+// ListAbuseReports returns the reports of pastes. The gateway only lets moderators call it.
+func (uc *UploadCoordinator) ListAbuseReports(ctx context.Context, req *pb.ListAbuseReportsRequest) (*pb.ListAbuseReportsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > maxAbuseReportsPageSize {
+		limit = maxAbuseReportsPageSize
+	}
+	reports, err := uc.metadataService.ListAbuseReports(ctx, req.IncludeResolved, limit, max(req.Offset, 0))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list abuse reports: %v", err)
+	}
+	res := &pb.ListAbuseReportsResponse{Reports: make([]*pb.AbuseReport, 0, len(reports))}
+	for _, report := range reports {
+		item := &pb.AbuseReport{
+			Id:         report.ID,
+			Key:        report.Key,
+			ReporterId: report.ReporterId,
+			Reason:     report.Reason,
+			CreatedAt:  timestamppb.New(report.CreatedAt),
+			ResolvedBy: report.ResolvedBy,
+		}
+		if report.ResolvedAt != nil {
+			item.ResolvedAt = timestamppb.New(*report.ResolvedAt)
+		}
+		res.Reports = append(res.Reports, item)
+	}
+	return res, nil
+}
+
+// rejectUpload builds the error of content that breaks the upload limits. The reason is
+// attached as an ErrorInfo so callers do not have to parse the message.
+func rejectUpload(reason, msg string, metadata map[string]string) error {
+	st, err := status.New(codes.FailedPrecondition, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   uploadErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, msg)
+	}
+	return st.Err()
+}
+
+// contentTooLarge rejects streamed content over the size limit of the uploader.
+func contentTooLarge(maxSize int64) error {
+	return rejectUpload(ReasonContentTooLarge, fmt.Sprintf("content must not be more than %d bytes long", maxSize), map[string]string{
+		"max_size": strconv.FormatInt(maxSize, 10),
+	})
+}
+
+// contentTypeNotAllowed rejects content of a type the upload limits do not allow.
+func contentTypeNotAllowed(contentType string) error {
+	return rejectUpload(ReasonContentTypeNotAllowed, fmt.Sprintf("content of type %s is not allowed", contentType), map[string]string{
+		"content_type": contentType,
+	})
+}
+
+func (uc *UploadCoordinator) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.PermissionDenied, "collections require an account")
+	}
+	name := strings.TrimSpace(req.Name)
+	if v := validation.ValidateCollectionName(name); !v.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "collection validation errors: %v", v.Errors)
+	}
+	collection, err := uc.collections.Create(ctx, req.UserId, name)
+	if err != nil {
+		return nil, collectionError(err)
+	}
+	return &pb.CreateCollectionResponse{Collection: collectionMessage(collection)}, nil
+}
+
+func (uc *UploadCoordinator) RenameCollection(ctx context.Context, req *pb.RenameCollectionRequest) (*pb.RenameCollectionResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if v := validation.ValidateCollectionName(name); !v.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "collection validation errors: %v", v.Errors)
+	}
+	collection, err := uc.collections.Rename(ctx, req.Id, req.UserId, name)
+	if err != nil {
+		return nil, collectionError(err)
+	}
+	return &pb.RenameCollectionResponse{Collection: collectionMessage(collection)}, nil
+}
+
+// DeleteCollection removes a collection of the user. Its pastes are kept outside of any collection.
+func (uc *UploadCoordinator) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	if err := uc.collections.Delete(ctx, req.Id, req.UserId); err != nil {
+		return nil, collectionError(err)
+	}
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+func (uc *UploadCoordinator) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	collections, err := uc.collections.List(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list collections: %v", err)
+	}
+	res := &pb.ListCollectionsResponse{Collections: make([]*pb.Collection, 0, len(collections))}
+	for _, collection := range collections {
+		res.Collections = append(res.Collections, collectionMessage(collection))
+	}
+	return res, nil
+}
+
+// MovePaste puts a paste into a collection of its owner, a collection ID of 0 takes it out of its collection.
+func (uc *UploadCoordinator) MovePaste(ctx context.Context, req *pb.MovePasteRequest) (*pb.MovePasteResponse, error) {
+	userId, err := uc.metadataService.GetPasteOwner(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to load paste: %v", err)
+	}
+	if userId == "" || userId != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "only the owner can move a paste")
+	}
+	if err := uc.collections.MovePaste(ctx, req.Key, req.UserId, req.CollectionId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "paste not found")
+		}
+		return nil, collectionError(err)
+	}
+	// The collection is part of the metadata cached by the download service
+	if err := uc.eventService.PasteUpdated(req.Key); err != nil {
+		uc.log.PrintError(ctx, err, map[string]string{"key": req.Key})
+	}
+	return &pb.MovePasteResponse{}, nil
+}
+
+// ListTags returns the tags of the pastes of a user together with how many pastes carry them.
+func (uc *UploadCoordinator) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tags, err := uc.metadataService.ListTags(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+	res := &pb.ListTagsResponse{Tags: make([]*pb.TagCount, 0, len(tags))}
+	for _, tag := range tags {
+		res.Tags = append(res.Tags, &pb.TagCount{Tag: tag.Tag, PasteCount: tag.PasteCount})
+	}
+	return res, nil
+}
+
+func collectionMessage(collection *models.Collection) *pb.Collection {
+	return &pb.Collection{
+		Id:         collection.ID,
+		Name:       collection.Name,
+		CreatedAt:  timestamppb.New(collection.CreatedAt),
+		PasteCount: collection.PasteCount,
+	}
+}
+
+// collectionError maps the errors of the collection repository to gRPC statuses.
+func collectionError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, "collection not found")
+	case errors.Is(err, repository.ErrDuplicateCollection):
+		return status.Error(codes.AlreadyExists, "a collection with this name already exists")
+	default:
+		return status.Errorf(codes.Internal, "collection operation failed: %v", err)
+	}
+}
+
 func collectErrors(errorCh <-chan error) error {
 	var errorMessages []string
 	for err := range errorCh {
@@ -1,6 +1,22 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// Status of a paste. Pastes uploaded through a presigned URL stay pending until the upload is
+// completed, pending pastes cannot be read.
+const (
+	StatusPending = "pending"
+	StatusActive  = "active"
+)
+
+// MaxPasteTags is the largest number of tags a paste can have.
+const MaxPasteTags = 20
+
+// ErrTooManyTags is returned when an edit would leave a paste with more than MaxPasteTags tags.
+var ErrTooManyTags = errors.New("too many tags")
 
 type MetaData struct {
 	Key            string
@@ -9,3 +25,56 @@ type MetaData struct {
 	CreatedAt      time.Time
 	ExpirationDate time.Time
 }
+
+// PasteFile is a named file of a bundle paste.
+type PasteFile struct {
+	Position  int32
+	Name      string
+	Language  string
+	Size      int64
+	ObjectKey string
+}
+
+// AbuseReport is a report of a paste that breaks the rules, reviewed by moderators.
+type AbuseReport struct {
+	ID         int64
+	Key        string
+	ReporterId string // Empty for anonymous reports
+	Reason     string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+	ResolvedBy string
+}
+
+// MetadataChange is an edit of a paste by its owner. Nil fields are left unchanged.
+type MetadataChange struct {
+	Version        int64 // Metadata version the edit was made against
+	Title          *string
+	ExpirationDate *time.Time
+	AddTags        []string
+	RemoveTags     []string
+}
+
+// EditableMetadata is the part of the metadata a MetadataChange can edit.
+type EditableMetadata struct {
+	Key            string
+	Title          string
+	ExpirationDate time.Time
+	Tags           []string
+	Version        int64
+}
+
+// Collection is a named folder a user organizes their pastes in.
+type Collection struct {
+	ID         int64
+	UserId     string
+	Name       string
+	CreatedAt  time.Time
+	PasteCount int32
+}
+
+// TagCount is a tag of a user with the number of their pastes carrying it.
+type TagCount struct {
+	Tag        string
+	PasteCount int32
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrCollectionNotFound is returned for collections that do not exist or belong to another user.
+	ErrCollectionNotFound = errors.New("collection not found")
+	// ErrDuplicateCollection is returned when the user already has a collection of the same name.
+	ErrDuplicateCollection = errors.New("duplicate collection name")
+)
+
+// CollectionRepository stores the collections users organize their pastes in. Every query is
+// scoped to the owner, collections of other users behave as if they did not exist.
+type CollectionRepository struct {
+	DB      *sql.DB
+	breaker *middleware.CircuitBreakerMiddleware
+}
+
+func NewCollectionRepository(db *sql.DB) *CollectionRepository {
+	cbConfig := middleware.CircuitBreakerConfig{
+		MaxRequests: 3,
+		Interval:    10 * time.Second,
+		Timeout:     30 * time.Second,
+	}
+	return &CollectionRepository{
+		DB:      db,
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "CollectionRepo"),
+	}
+}
+
+func (repo *CollectionRepository) Insert(ctx context.Context, userId, name string) (*models.Collection, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `INSERT INTO collections (user_id, name) VALUES ($1, $2) RETURNING id, created_at`
+
+		collection := models.Collection{UserId: userId, Name: name}
+		err := repo.DB.QueryRowContext(ctx, query, userId, name).Scan(&collection.ID, &collection.CreatedAt)
+		if err != nil {
+			return nil, duplicateCollection(err)
+		}
+		return &collection, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.Collection), nil
+}
+
+func (repo *CollectionRepository) Rename(ctx context.Context, id int64, userId, name string) (*models.Collection, error) {
+	operation := func(ctx context.Context) (any, error) {
+		query := `
+        UPDATE collections SET name = $3 WHERE id = $1 AND user_id = $2
+        RETURNING created_at, (SELECT COUNT(*) FROM metadata WHERE collection_id = $1 AND status = 'active')
+        `
+
+		collection := models.Collection{ID: id, UserId: userId, Name: name}
+		err := repo.DB.QueryRowContext(ctx, query, id, userId, name).Scan(&collection.CreatedAt, &collection.PasteCount)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCollectionNotFound
+		} else if err != nil {
+			return nil, duplicateCollection(err)
+		}
+		return &collection, nil
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.Collection), nil
+}
+
+// Delete removes a collection, its pastes are kept outside of any collection.
+func (repo *CollectionRepository) Delete(ctx context.Context, id int64, userId string) error {
+	operation := func(ctx context.Context) (any, error) {
+		query := `DELETE FROM collections WHERE id = $1 AND user_id = $2`
+
+		res, err := repo.DB.ExecContext(ctx, query, id, userId)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected == 0 {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, nil
+	}
+
+	_, err := repo.breaker.Execute(ctx, operation)
+	return err
+}
+
+// List returns the collections of a user in alphabetical order.
+func (repo *CollectionRepository) List(ctx context.Context, userId string) ([]*models.Collection, error) {
+	query := `
+        SELECT c.id, c.name, c.created_at, COUNT(m.key)
+        FROM collections c
+        LEFT JOIN metadata m ON m.collection_id = c.id AND m.status = 'active'
+        WHERE c.user_id = $1
+        GROUP BY c.id
+        ORDER BY c.name
+    `
+	rows, err := repo.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collections []*models.Collection
+	for rows.Next() {
+		collection := models.Collection{UserId: userId}
+		if err := rows.Scan(&collection.ID, &collection.Name, &collection.CreatedAt, &collection.PasteCount); err != nil {
+			return nil, err
+		}
+		collections = append(collections, &collection)
+	}
+	return collections, rows.Err()
+}
+
+// MovePaste puts a paste of the user into one of their collections, a collection ID of 0 takes
+// it out of its collection. It returns sql.ErrNoRows when the user has no such paste.
+func (repo *CollectionRepository) MovePaste(ctx context.Context, key, userId string, collectionId int64) error {
+	operation := func(ctx context.Context) (any, error) {
+		if collectionId != 0 {
+			var exists bool
+			query := `SELECT EXISTS(SELECT 1 FROM collections WHERE id = $1 AND user_id = $2)`
+			if err := repo.DB.QueryRowContext(ctx, query, collectionId, userId).Scan(&exists); err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, ErrCollectionNotFound
+			}
+		}
+
+		query := `UPDATE metadata SET collection_id = NULLIF($3, 0) WHERE key = $1 AND user_id = $2`
+		res, err := repo.DB.ExecContext(ctx, query, key, userId, collectionId)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected == 0 {
+			return nil, sql.ErrNoRows
+		}
+		return nil, nil
+	}
+
+	_, err := repo.breaker.Execute(ctx, operation)
+	return err
+}
+
+func duplicateCollection(err error) error {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicateCollection
+	}
+	return err
+}
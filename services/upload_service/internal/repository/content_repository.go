@@ -2,53 +2,106 @@ package repository
 
 import (
 	"context"
-	"log"
+	"io"
 	"time"
 
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/sony/gobreaker"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 )
 
+// uploadURLExpiry is how long a presigned upload URL stays valid.
+const uploadURLExpiry = 15 * time.Minute
+
 type ContentRepository struct {
-	client  *s3.PresignClient
-	bucket  string
+	storage storage.Storage
 	breaker *middleware.CircuitBreakerMiddleware
 }
 
-func NewContentRepository(bucket, region string) (*ContentRepository, error) {
-	cbSettings := gobreaker.Settings{
-		Name:        "ContentRepo",
+func NewContentRepository(store storage.Storage) *ContentRepository {
+	cbConfig := middleware.CircuitBreakerConfig{
 		MaxRequests: 3,                // Max requests allowed in half-open state
 		Interval:    30 * time.Second, // Time window for tracking errors
 		Timeout:     60 * time.Minute, // Time to reset the circuit after tripping
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			return counts.ConsecutiveFailures > 5
-		},
-	}
-	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
-	if err != nil {
-		log.Fatalf("Failed to load AWS config: %v", err)
 	}
 
-	client := s3.NewPresignClient(s3.NewFromConfig(cfg))
-
 	return &ContentRepository{
-		client:  client,
-		bucket:  bucket,
-		breaker: middleware.NewCircuitBreakerMiddleware(cbSettings),
-	}, nil
+		storage: store,
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "ContentRepo"),
+	}
 }
 
 func (repo *ContentRepository) GenerateUploadURL(ctx context.Context, key string) (string, error) {
-	req, err := repo.client.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket: &repo.bucket,
-		Key:    &key,
-	})
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.PresignPutURL(ctx, key, uploadURLExpiry)
+	}
+
+	url, err := repo.breaker.Execute(ctx, operation)
 	if err != nil {
-		log.Fatalf("Failed to upload object: %v", err)
 		return "", err
 	}
-	return req.URL, nil
+	return url.(string), nil
+}
+
+// UploadContent streams the paste content into storage. It returns only after
+// the object has been fully written.
+//
+// The body is read from the client, so the upload is not run through the breaker: an aborted
+// or oversized stream says nothing about the health of the storage and must not open the circuit.
+func (repo *ContentRepository) UploadContent(ctx context.Context, key string, body io.Reader) error {
+	return repo.storage.UploadPasteStream(ctx, key, body)
+}
+
+// DeleteContent removes the paste content from storage.
+func (repo *ContentRepository) DeleteContent(ctx context.Context, key string) error {
+	operation := func(ctx context.Context) (any, error) {
+		return nil, repo.storage.DeletePaste(key)
+	}
+
+	if _, err := repo.breaker.Execute(ctx, operation); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CopyContent copies stored content to another key.
+func (repo *ContentRepository) CopyContent(ctx context.Context, srcKey, dstKey string) error {
+	operation := func(ctx context.Context) (any, error) {
+		return nil, repo.storage.CopyPaste(ctx, srcKey, dstKey)
+	}
+
+	if _, err := repo.breaker.Execute(ctx, operation); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StatContent returns the size, checksum and content type of the stored paste content.
+func (repo *ContentRepository) StatContent(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	operation := func(ctx context.Context) (any, error) {
+		return repo.storage.StatPaste(ctx, key)
+	}
+
+	info, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return info.(*storage.ObjectInfo), nil
+}
+
+// ReadContentHead returns up to length bytes from the start of the stored paste content.
+func (repo *ContentRepository) ReadContentHead(ctx context.Context, key string, length int64) ([]byte, error) {
+	operation := func(ctx context.Context) (any, error) {
+		body, err := repo.storage.GetPasteRange(ctx, key, 0, length)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	head, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return head.([]byte), nil
 }
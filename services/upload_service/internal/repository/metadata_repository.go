@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	middleware "github.com/NesterovYehor/TextNest/pkg/middlewares"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
-	"github.com/sony/gobreaker"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 type MetadataRepository struct {
@@ -19,38 +22,109 @@ type MetadataRepository struct {
 
 // NewMetadataRepository creates a new metadata repository with circuit breaker middleware.
 func NewMetadataRepository(db *sql.DB) *MetadataRepository {
-	cbSettings := gobreaker.Settings{
-		Name:        "MetadataRepo",
+	cbConfig := middleware.CircuitBreakerConfig{
 		MaxRequests: 3,
 		Interval:    10 * time.Second,
 		Timeout:     30 * time.Second,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			return counts.ConsecutiveFailures > 5
-		},
 	}
 	return &MetadataRepository{
 		DB:      db,
-		breaker: middleware.NewCircuitBreakerMiddleware(cbSettings),
+		breaker: middleware.NewCircuitBreakerMiddleware(cbConfig, "MetadataRepo"),
 	}
 }
 
-// UploadPasteMetadata inserts metadata into the database with circuit breaker protection.
-func (repo *MetadataRepository) InsertPasteMetadata(ctx context.Context, data *pb.UploadPasteRequest) error {
+// UploadPasteMetadata inserts metadata and its first version into the database with circuit breaker protection.
+// passwordHash is nil for pastes without an access password, a maxViews of 0 means unlimited views.
+func (repo *MetadataRepository) InsertPasteMetadata(ctx context.Context, data *pb.UploadPasteRequest, passwordHash []byte, maxViews int32) error {
+	return repo.insertPaste(ctx, data, passwordHash, maxViews, nil, models.StatusActive)
+}
+
+// InsertPendingPasteMetadata stores the metadata of a paste whose content is not uploaded yet.
+// The paste stays pending until ActivatePaste.
+func (repo *MetadataRepository) InsertPendingPasteMetadata(ctx context.Context, data *pb.UploadPasteRequest, passwordHash []byte, maxViews int32) error {
+	return repo.insertPaste(ctx, data, passwordHash, maxViews, nil, models.StatusPending)
+}
+
+// InsertBundleMetadata stores the metadata of a paste made of several files together with its file manifest.
+// Bundles have no revisions, their files are stored under the keys recorded in the manifest.
+func (repo *MetadataRepository) InsertBundleMetadata(ctx context.Context, data *pb.UploadPasteRequest, passwordHash []byte, maxViews int32, files []*models.PasteFile) error {
+	return repo.insertPaste(ctx, data, passwordHash, maxViews, files, models.StatusActive)
+}
+
+func (repo *MetadataRepository) insertPaste(ctx context.Context, data *pb.UploadPasteRequest, passwordHash []byte, maxViews int32, files []*models.PasteFile, status string) error {
 	operation := func(ctx context.Context) (any, error) {
+		tx, err := repo.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+
 		query := `
-        INSERT INTO metadata(key, title, user_id, expiration_date) 
-        VALUES ($1, NULLIF($2, ''), $3, $4)
+        INSERT INTO metadata(key, title, user_id, expiration_date, password_hash, max_views, visibility, encryption_header, language, status) 
+        VALUES ($1, NULLIF($2, ''), $3, $4, $5, NULLIF($6, 0), $7, $8, NULLIF($9, ''), $10)
         `
 
+		var encryptionHeader sql.NullString
+		if data.Encryption != nil {
+			header, err := protojson.Marshal(data.Encryption)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode encryption header: %w", err)
+			}
+			encryptionHeader = sql.NullString{String: string(header), Valid: true}
+		}
+
 		args := []any{
 			data.Key,
 			data.Title,
 			data.UserId,
 			data.ExpirationDate.AsTime(),
+			passwordHash,
+			maxViews,
+			visibilityName(data.Visibility),
+			encryptionHeader,
+			data.Language,
+			status,
 		}
 		// Execute the query
-		_, err := repo.DB.ExecContext(ctx, query, args...)
-		return nil, err
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+
+		for _, userId := range data.SharedWith {
+			query := `INSERT INTO paste_acl(key, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+			if _, err := tx.ExecContext(ctx, query, data.Key, userId); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, tag := range data.Tags {
+			query := `INSERT INTO paste_tags(key, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+			if _, err := tx.ExecContext(ctx, query, data.Key, tag); err != nil {
+				return nil, err
+			}
+		}
+
+		// Title and tags are searchable right away, the content is added once it is uploaded
+		if err := upsertSearchDocument(ctx, tx, data.Key, ""); err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			query := `
+            INSERT INTO paste_files(key, position, name, language, size, object_key)
+            VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+            `
+			if _, err := tx.ExecContext(ctx, query, data.Key, file.Position, file.Name, file.Language, file.Size, file.ObjectKey); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(files) == 0 {
+			if err := insertVersion(ctx, tx, data.Key, 1, models.StatusActive); err != nil {
+				return nil, err
+			}
+		}
+		return nil, tx.Commit()
 	}
 
 	// Execute the operation with the circuit breaker
@@ -63,6 +137,82 @@ func (repo *MetadataRepository) InsertPasteMetadata(ctx context.Context, data *p
 	return nil
 }
 
+// InsertNextVersion records a new pending revision of the paste and returns its number. The
+// revision is not served before ActivateVersion. Concurrent edits of the same paste fail on the
+// (key, version) primary key instead of sharing a version.
+func (repo *MetadataRepository) InsertNextVersion(ctx context.Context, key string) (int32, error) {
+	operation := func(ctx context.Context) (any, error) {
+		tx, err := repo.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+
+		var latest int32
+		query := `SELECT COALESCE(MAX(version), 0) FROM paste_versions WHERE key = $1`
+		if err := tx.QueryRowContext(ctx, query, key).Scan(&latest); err != nil {
+			return nil, err
+		}
+
+		version := latest + 1
+		if err := insertVersion(ctx, tx, key, version, models.StatusPending); err != nil {
+			return nil, err
+		}
+		return version, tx.Commit()
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create new version of paste %s: %w", key, err)
+	}
+	return result.(int32), nil
+}
+
+// IndexContent adds the text of the paste content to its search document.
+func (repo *MetadataRepository) IndexContent(ctx context.Context, key, content string) error {
+	operation := func(ctx context.Context) (any, error) {
+		return nil, upsertSearchDocument(ctx, repo.DB, key, content)
+	}
+
+	_, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return fmt.Errorf("failed to index content of paste %s: %w", key, err)
+	}
+	return nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// upsertSearchDocument rebuilds the full-text document of a paste. Matches in the title rank
+// above matches in the tags, which rank above matches in the content. The 'simple' configuration
+// is used because pastes are mostly code, where stemming and stop words do more harm than good.
+func upsertSearchDocument(ctx context.Context, db execer, key, content string) error {
+	query := `
+    INSERT INTO paste_search(key, content, document)
+    SELECT m.key, $2,
+        setweight(to_tsvector('simple', COALESCE(m.title, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT string_agg(tag, ' ') FROM paste_tags WHERE key = m.key), '')), 'B') ||
+        setweight(to_tsvector('simple', $2), 'C')
+    FROM metadata m WHERE m.key = $1
+    ON CONFLICT (key) DO UPDATE SET content = EXCLUDED.content, document = EXCLUDED.document
+    `
+	_, err := db.ExecContext(ctx, query, key, content)
+	return err
+}
+
+// visibilityName maps the visibility enum to the value stored in the metadata table, e.g. VISIBILITY_PRIVATE to "private".
+func visibilityName(visibility pb.Visibility) string {
+	return strings.ToLower(strings.TrimPrefix(visibility.String(), "VISIBILITY_"))
+}
+
+func insertVersion(ctx context.Context, tx *sql.Tx, key string, version int32, status string) error {
+	query := `INSERT INTO paste_versions(key, version, object_key, status) VALUES ($1, $2, $3, $4)`
+	_, err := tx.ExecContext(ctx, query, key, version, storage.VersionObjectKey(key, version), status)
+	return err
+}
+
 func (repo *MetadataRepository) UpdatePasteMetadata(ctx context.Context, expirationDate time.Time, key string) error {
 	operation := func(ctx context.Context) (any, error) {
 		query := `
@@ -95,6 +245,100 @@ func (repo *MetadataRepository) UpdatePasteMetadata(ctx context.Context, expirat
 	return nil
 }
 
+// ErrEditConflict is returned when the metadata of a paste changed since the version an edit was made against.
+var ErrEditConflict = errors.New("edit conflict")
+
+// UpdateMetadata applies an edit of the title, expiration and tags and bumps the metadata version.
+// It returns sql.ErrNoRows when the paste does not exist and ErrEditConflict when the version
+// of the edit is outdated.
+func (repo *MetadataRepository) UpdateMetadata(ctx context.Context, key string, change *models.MetadataChange) (*models.EditableMetadata, error) {
+	operation := func(ctx context.Context) (any, error) {
+		tx, err := repo.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+
+		query := `
+        UPDATE metadata SET
+            title = CASE WHEN $3 THEN NULLIF($4, '') ELSE title END,
+            expiration_date = COALESCE($5, expiration_date),
+            metadata_version = metadata_version + 1
+        WHERE key = $1 AND metadata_version = $2
+        RETURNING COALESCE(title, ''), expiration_date, metadata_version
+        `
+		var title string
+		if change.Title != nil {
+			title = *change.Title
+		}
+		metadata := models.EditableMetadata{Key: key}
+		err = tx.QueryRowContext(ctx, query, key, change.Version, change.Title != nil, title, change.ExpirationDate).
+			Scan(&metadata.Title, &metadata.ExpirationDate, &metadata.Version)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Tell a missing paste from an outdated version
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM metadata WHERE key = $1)`, key).Scan(&exists); err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, sql.ErrNoRows
+			}
+			return nil, ErrEditConflict
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range change.RemoveTags {
+			query := `DELETE FROM paste_tags WHERE key = $1 AND tag = $2`
+			if _, err := tx.ExecContext(ctx, query, key, tag); err != nil {
+				return nil, err
+			}
+		}
+		for _, tag := range change.AddTags {
+			query := `INSERT INTO paste_tags(key, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+			if _, err := tx.ExecContext(ctx, query, key, tag); err != nil {
+				return nil, err
+			}
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT tag FROM paste_tags WHERE key = $1 ORDER BY tag`, key)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var tag string
+			if err := rows.Scan(&tag); err != nil {
+				return nil, err
+			}
+			metadata.Tags = append(metadata.Tags, tag)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(metadata.Tags) > models.MaxPasteTags {
+			return nil, models.ErrTooManyTags
+		}
+
+		// Title and tags are part of the search document, the indexed content is kept
+		var content string
+		query = `SELECT COALESCE((SELECT content FROM paste_search WHERE key = $1), '')`
+		if err := tx.QueryRowContext(ctx, query, key).Scan(&content); err != nil {
+			return nil, err
+		}
+		if err := upsertSearchDocument(ctx, tx, key, content); err != nil {
+			return nil, err
+		}
+		return &metadata, tx.Commit()
+	}
+
+	result, err := repo.breaker.Execute(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.EditableMetadata), nil
+}
+
 func (repo *MetadataRepository) ExpireAllPastesByUserId(ctx context.Context, userId string) error {
 	operation := func(ctx context.Context) (any, error) {
 		query := `UPDATE metadata SET expiration_date = NOW() WHERE user_id = $1`
@@ -121,3 +365,189 @@ func (repo *MetadataRepository) GetPasteOwner(ctx context.Context, key string) (
 	}
 	return userId, nil
 }
+
+// GetPasteStatus returns whether the paste is pending or active, sql.ErrNoRows when it does not exist.
+func (repo *MetadataRepository) GetPasteStatus(ctx context.Context, key string) (string, error) {
+	query := `SELECT status FROM metadata WHERE key = $1`
+
+	var status string
+	err := repo.DB.QueryRowContext(ctx, query, key).Scan(&status)
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// ActivatePaste makes a paste readable and records the attributes of its stored content.
+// It returns sql.ErrNoRows when the paste does not exist.
+func (repo *MetadataRepository) ActivatePaste(ctx context.Context, key string, object *storage.ObjectInfo) error {
+	operation := func(ctx context.Context) (any, error) {
+		query := `
+        UPDATE metadata SET status = 'active', size = $2, checksum = NULLIF($3, ''), content_type = NULLIF($4, '')
+        WHERE key = $1
+        `
+		res, err := repo.DB.ExecContext(ctx, query, key, object.Size, object.ETag, object.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return nil, sql.ErrNoRows
+		}
+		return nil, nil
+	}
+
+	_, err := repo.breaker.Execute(ctx, operation)
+	return err
+}
+
+// GetVersionStatus returns whether a revision of the paste is pending or active, sql.ErrNoRows
+// when it does not exist.
+func (repo *MetadataRepository) GetVersionStatus(ctx context.Context, key string, version int32) (string, error) {
+	query := `SELECT status FROM paste_versions WHERE key = $1 AND version = $2`
+
+	var status string
+	err := repo.DB.QueryRowContext(ctx, query, key, version).Scan(&status)
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// ActivateVersion makes a pending revision the served content of the paste and records the
+// attributes of its stored content. It returns sql.ErrNoRows when there is no such pending revision.
+func (repo *MetadataRepository) ActivateVersion(ctx context.Context, key string, version int32, object *storage.ObjectInfo) error {
+	operation := func(ctx context.Context) (any, error) {
+		tx, err := repo.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+
+		query := `UPDATE paste_versions SET status = 'active' WHERE key = $1 AND version = $2 AND status = 'pending'`
+		res, err := tx.ExecContext(ctx, query, key, version)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return nil, sql.ErrNoRows
+		}
+
+		query = `UPDATE metadata SET size = $2, checksum = NULLIF($3, ''), content_type = NULLIF($4, '') WHERE key = $1`
+		if _, err := tx.ExecContext(ctx, query, key, object.Size, object.ETag, object.ContentType); err != nil {
+			return nil, err
+		}
+		return nil, tx.Commit()
+	}
+
+	_, err := repo.breaker.Execute(ctx, operation)
+	return err
+}
+
+func (repo *MetadataRepository) IsPasteEncrypted(ctx context.Context, key string) (bool, error) {
+	query := `SELECT encryption_header IS NOT NULL FROM metadata WHERE key = $1`
+
+	var encrypted bool
+	err := repo.DB.QueryRowContext(ctx, query, key).Scan(&encrypted)
+	if err != nil {
+		return false, err
+	}
+	return encrypted, nil
+}
+
+func (repo *MetadataRepository) IsPasteBundle(ctx context.Context, key string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM paste_files WHERE key = $1)`
+
+	var bundle bool
+	err := repo.DB.QueryRowContext(ctx, query, key).Scan(&bundle)
+	if err != nil {
+		return false, err
+	}
+	return bundle, nil
+}
+
+// InsertAbuseReport stores a report of a paste. It returns sql.ErrNoRows when the paste does not exist.
+func (repo *MetadataRepository) InsertAbuseReport(ctx context.Context, key, reporterId, reason string) (int64, error) {
+	query := `
+        INSERT INTO abuse_reports (key, reporter_id, reason)
+        SELECT key, NULLIF($2, ''), $3 FROM metadata WHERE key = $1
+        RETURNING id
+    `
+
+	var id int64
+	err := repo.DB.QueryRowContext(ctx, query, key, reporterId, reason).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ListAbuseReports returns the reports, oldest first, so moderators work through them in order.
+func (repo *MetadataRepository) ListAbuseReports(ctx context.Context, includeResolved bool, limit, offset int32) ([]*models.AbuseReport, error) {
+	query := `
+        SELECT id, key, COALESCE(reporter_id, ''), reason, created_at, resolved_at, COALESCE(resolved_by, '')
+        FROM abuse_reports
+        WHERE $1 OR resolved_at IS NULL
+        ORDER BY created_at, id
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := repo.DB.QueryContext(ctx, query, includeResolved, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*models.AbuseReport
+	for rows.Next() {
+		var report models.AbuseReport
+		if err := rows.Scan(&report.ID, &report.Key, &report.ReporterId, &report.Reason, &report.CreatedAt, &report.ResolvedAt, &report.ResolvedBy); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	return reports, rows.Err()
+}
+
+// ResolveAbuseReports closes the open reports of a paste.
+func (repo *MetadataRepository) ResolveAbuseReports(ctx context.Context, key, resolvedBy string) error {
+	query := `UPDATE abuse_reports SET resolved_at = NOW(), resolved_by = $2 WHERE key = $1 AND resolved_at IS NULL`
+
+	if _, err := repo.DB.ExecContext(ctx, query, key, resolvedBy); err != nil {
+		return fmt.Errorf("failed to resolve abuse reports: %w", err)
+	}
+	return nil
+}
+
+// ListTags returns the tags of the active pastes of a user, most used first.
+func (repo *MetadataRepository) ListTags(ctx context.Context, userId string) ([]*models.TagCount, error) {
+	query := `
+        SELECT t.tag, COUNT(*)
+        FROM paste_tags t
+        JOIN metadata m ON m.key = t.key
+        WHERE m.user_id = $1 AND m.status = 'active'
+        GROUP BY t.tag
+        ORDER BY COUNT(*) DESC, t.tag
+    `
+	rows, err := repo.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*models.TagCount
+	for rows.Next() {
+		var tag models.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.PasteCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+	return tags, rows.Err()
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
+)
+
+// CollectionService manages the collections users organize their pastes in.
+type CollectionService struct {
+	repo *repository.CollectionRepository
+	log  *jsonlog.Logger
+}
+
+func NewCollectionService(repo *repository.CollectionRepository, log *jsonlog.Logger) *CollectionService {
+	return &CollectionService{repo: repo, log: log}
+}
+
+func (svc *CollectionService) Create(ctx context.Context, userId, name string) (*models.Collection, error) {
+	return svc.repo.Insert(ctx, userId, name)
+}
+
+func (svc *CollectionService) Rename(ctx context.Context, id int64, userId, name string) (*models.Collection, error) {
+	return svc.repo.Rename(ctx, id, userId, name)
+}
+
+func (svc *CollectionService) Delete(ctx context.Context, id int64, userId string) error {
+	if err := svc.repo.Delete(ctx, id, userId); err != nil {
+		return err
+	}
+	svc.log.PrintInfo(ctx, "Collection deleted", map[string]string{"collection_id": fmt.Sprint(id)})
+	return nil
+}
+
+func (svc *CollectionService) List(ctx context.Context, userId string) ([]*models.Collection, error) {
+	return svc.repo.List(ctx, userId)
+}
+
+// MovePaste puts a paste into a collection, a collection ID of 0 takes it out of its collection.
+func (svc *CollectionService) MovePaste(ctx context.Context, key, userId string, collectionId int64) error {
+	return svc.repo.MovePaste(ctx, key, userId, collectionId)
+}
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
 )
 
@@ -36,3 +39,62 @@ func (svc *ContentManagementService) GenerateUploadURL(ctx context.Context, key
 
 	return uploadURL, nil
 }
+
+func (svc *ContentManagementService) UploadContent(ctx context.Context, key string, body io.Reader) error {
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
+	}
+
+	if err := svc.repo.UploadContent(ctx, key, body); err != nil {
+		err = fmt.Errorf("failed to upload content: %w", err)
+		svc.log.PrintError(ctx, err, map[string]string{"key": key})
+		return err
+	}
+
+	svc.log.PrintInfo(ctx, "Content uploaded successfully", map[string]string{"key": key})
+	return nil
+}
+
+func (svc *ContentManagementService) DeleteContent(ctx context.Context, key string) error {
+	if err := svc.repo.DeleteContent(ctx, key); err != nil {
+		err = fmt.Errorf("failed to delete content: %w", err)
+		svc.log.PrintError(ctx, err, map[string]string{"key": key})
+		return err
+	}
+	return nil
+}
+
+// CopyContent copies stored content to another key.
+func (svc *ContentManagementService) CopyContent(ctx context.Context, srcKey, dstKey string) error {
+	if err := svc.repo.CopyContent(ctx, srcKey, dstKey); err != nil {
+		return fmt.Errorf("failed to copy content: %w", err)
+	}
+	return nil
+}
+
+// StatContent describes stored content without downloading it.
+func (svc *ContentManagementService) StatContent(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	info, err := svc.repo.StatContent(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat content: %w", err)
+	}
+	return info, nil
+}
+
+// sniffLength is how much content http.DetectContentType looks at.
+const sniffLength = 512
+
+// ReadContentHead returns up to length bytes from the start of stored content.
+func (svc *ContentManagementService) ReadContentHead(ctx context.Context, key string, length int64) ([]byte, error) {
+	head, err := svc.repo.ReadContentHead(ctx, key, length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	return head, nil
+}
+
+// SniffContentType detects the MIME type of content from its first bytes. The type declared by
+// the uploader is not trusted, presigned uploads can claim any type.
+func SniffContentType(head []byte) string {
+	return http.DetectContentType(head[:min(len(head), sniffLength)])
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// ResolveLanguage returns the canonical name of the language of a paste, e.g. "go" or "python".
+// An explicit language wins over the file name, which wins over guessing from the content.
+// An empty result means the language is unknown.
+func ResolveLanguage(language, filename string, content []byte) string {
+	var lexer chroma.Lexer
+	switch {
+	case language != "":
+		lexer = lexers.Get(language)
+	case filename != "":
+		lexer = lexers.Match(filename)
+	}
+	if lexer == nil && language == "" && len(content) > 0 {
+		lexer = lexers.Analyse(string(content))
+	}
+	if lexer == nil {
+		return ""
+	}
+	return strings.ToLower(lexer.Config().Name)
+}
@@ -1,14 +1,19 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/validation"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type MetadataManagementService struct {
@@ -20,14 +25,64 @@ func NewMetadataManagementService(repo *repository.MetadataRepository, log *json
 	return &MetadataManagementService{repo: repo, log: log}
 }
 
-func (ms *MetadataManagementService) ValidateAndSave(ctx context.Context, metadata *pb.UploadPasteRequest) error {
+// ValidateAndSavePending persists the metadata of a paste whose content is uploaded through a
+// presigned URL. The paste stays pending until ActivatePaste.
+func (ms *MetadataManagementService) ValidateAndSavePending(ctx context.Context, metadata *pb.UploadPasteRequest) error {
+	if err := ms.Validate(ctx, metadata); err != nil {
+		return err
+	}
+	return ms.save(ctx, metadata, nil, models.StatusPending)
+}
+
+// Validate checks the metadata without persisting it.
+func (ms *MetadataManagementService) Validate(ctx context.Context, metadata *pb.UploadPasteRequest) error {
 	if v := validation.ValidateMetaData(metadata); !v.Valid() {
 		err := fmt.Errorf("metadata validation errors: %v", v.Errors)
 		ms.log.PrintError(ctx, err, map[string]string{"key": metadata.Key})
 		return err
 	}
+	return nil
+}
+
+// Save persists already validated metadata.
+func (ms *MetadataManagementService) Save(ctx context.Context, metadata *pb.UploadPasteRequest) error {
+	return ms.save(ctx, metadata, nil, models.StatusActive)
+}
+
+// SaveBundle persists already validated metadata of a bundle together with its file manifest.
+func (ms *MetadataManagementService) SaveBundle(ctx context.Context, metadata *pb.UploadPasteRequest, files []*models.PasteFile) error {
+	return ms.save(ctx, metadata, files, models.StatusActive)
+}
+
+func (ms *MetadataManagementService) save(ctx context.Context, metadata *pb.UploadPasteRequest, files []*models.PasteFile, status string) error {
+	var passwordHash []byte
+	if metadata.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(metadata.Password), 10)
+		if err != nil {
+			err = fmt.Errorf("failed to hash paste password: %w", err)
+			ms.log.PrintError(ctx, err, map[string]string{"key": metadata.Key})
+			return err
+		}
+		passwordHash = hash
+	}
+
+	maxViews := metadata.MaxViews
+	if metadata.BurnAfterRead {
+		maxViews = 1
+	}
+
+	metadata.Language = ResolveLanguage(metadata.Language, metadata.Filename, nil)
 
-	if err := ms.repo.InsertPasteMetadata(ctx, metadata); err != nil {
+	var err error
+	switch {
+	case len(files) > 0:
+		err = ms.repo.InsertBundleMetadata(ctx, metadata, passwordHash, maxViews, files)
+	case status == models.StatusPending:
+		err = ms.repo.InsertPendingPasteMetadata(ctx, metadata, passwordHash, maxViews)
+	default:
+		err = ms.repo.InsertPasteMetadata(ctx, metadata, passwordHash, maxViews)
+	}
+	if err != nil {
 		err = fmt.Errorf("failed to save metadata: %w", err)
 		ms.log.PrintError(ctx, err, map[string]string{"key": metadata.Key})
 		return err
@@ -37,12 +92,96 @@ func (ms *MetadataManagementService) ValidateAndSave(ctx context.Context, metada
 	return nil
 }
 
+// CreateVersion registers the next revision of the paste and returns its number.
+func (ms *MetadataManagementService) CreateVersion(ctx context.Context, key string) (int32, error) {
+	version, err := ms.repo.InsertNextVersion(ctx, key)
+	if err != nil {
+		ms.log.PrintError(ctx, err, map[string]string{"key": key})
+		return 0, err
+	}
+	return version, nil
+}
+
+// IndexContent makes the content of a paste searchable. Only the first MaxIndexedContent bytes
+// are indexed, binary content is skipped.
+func (ms *MetadataManagementService) IndexContent(ctx context.Context, key string, content []byte) error {
+	text, ok := searchableText(content)
+	if !ok {
+		return nil
+	}
+	if err := ms.repo.IndexContent(ctx, key, text); err != nil {
+		ms.log.PrintError(ctx, err, map[string]string{"key": key})
+		return err
+	}
+	return nil
+}
+
+// MaxIndexedContent caps the content stored for full-text search, PostgreSQL rejects tsvectors over 1MB.
+const MaxIndexedContent = 64 << 10
+
+func searchableText(content []byte) (string, bool) {
+	// Heads read up to the limit may end in a cut rune as well
+	if len(content) >= MaxIndexedContent {
+		content = content[:MaxIndexedContent]
+		// The cut may have split the last rune, drop its leftover bytes
+		for i := 0; i < utf8.UTFMax-1 && !utf8.Valid(content); i++ {
+			content = content[:len(content)-1]
+		}
+	}
+	// PostgreSQL text cannot hold NUL bytes, like invalid UTF-8 they mark binary content
+	if !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0 {
+		return "", false
+	}
+	return string(content), true
+}
+
+func (ms *MetadataManagementService) IsPasteEncrypted(ctx context.Context, key string) (bool, error) {
+	return ms.repo.IsPasteEncrypted(ctx, key)
+}
+
+func (ms *MetadataManagementService) IsPasteBundle(ctx context.Context, key string) (bool, error) {
+	return ms.repo.IsPasteBundle(ctx, key)
+}
+
+func (ms *MetadataManagementService) GetPasteStatus(ctx context.Context, key string) (string, error) {
+	return ms.repo.GetPasteStatus(ctx, key)
+}
+
+// ActivatePaste makes a pending paste readable once its content is in storage.
+func (ms *MetadataManagementService) ActivatePaste(ctx context.Context, key string, object *storage.ObjectInfo) error {
+	if err := ms.repo.ActivatePaste(ctx, key, object); err != nil {
+		ms.log.PrintError(ctx, fmt.Errorf("failed to activate paste: %w", err), map[string]string{"key": key})
+		return err
+	}
+	return nil
+}
+
+// GetVersionStatus returns whether a revision of the paste is pending or active.
+func (ms *MetadataManagementService) GetVersionStatus(ctx context.Context, key string, version int32) (string, error) {
+	return ms.repo.GetVersionStatus(ctx, key, version)
+}
+
+// ActivateVersion serves a pending revision once its content is in storage.
+func (ms *MetadataManagementService) ActivateVersion(ctx context.Context, key string, version int32, object *storage.ObjectInfo) error {
+	if err := ms.repo.ActivateVersion(ctx, key, version, object); err != nil {
+		ms.log.PrintError(ctx, fmt.Errorf("failed to activate version: %w", err), map[string]string{"key": key})
+		return err
+	}
+	return nil
+}
+
 func (ms *MetadataManagementService) GetPasteOwner(ctx context.Context, key string) (string, error) {
 	return ms.repo.GetPasteOwner(ctx, key)
 }
 
-func (ms *MetadataManagementService) UpdateMetadata(ctx context.Context, key string, expirationDate time.Time) error {
-	return ms.repo.UpdatePasteMetadata(ctx, expirationDate, key)
+// UpdateMetadata applies an edit of the title, expiration and tags made by the owner of the paste.
+func (ms *MetadataManagementService) UpdateMetadata(ctx context.Context, key string, change *models.MetadataChange) (*models.EditableMetadata, error) {
+	metadata, err := ms.repo.UpdateMetadata(ctx, key, change)
+	if err != nil {
+		ms.log.PrintError(ctx, fmt.Errorf("failed to update metadata: %w", err), map[string]string{"key": key})
+		return nil, err
+	}
+	return metadata, nil
 }
 
 func (ms *MetadataManagementService) ExpireMetadata(ctx context.Context, key string) error {
@@ -52,3 +191,19 @@ func (ms *MetadataManagementService) ExpireMetadata(ctx context.Context, key str
 func (ms *MetadataManagementService) ExpireAllPastes(ctx context.Context, userID string) error {
 	return ms.repo.ExpireAllPastesByUserId(ctx, userID)
 }
+
+func (ms *MetadataManagementService) ReportPaste(ctx context.Context, key, reporterId, reason string) (int64, error) {
+	return ms.repo.InsertAbuseReport(ctx, key, reporterId, reason)
+}
+
+func (ms *MetadataManagementService) ListAbuseReports(ctx context.Context, includeResolved bool, limit, offset int32) ([]*models.AbuseReport, error) {
+	return ms.repo.ListAbuseReports(ctx, includeResolved, limit, offset)
+}
+
+func (ms *MetadataManagementService) ResolveAbuseReports(ctx context.Context, key, resolvedBy string) error {
+	return ms.repo.ResolveAbuseReports(ctx, key, resolvedBy)
+}
+
+func (ms *MetadataManagementService) ListTags(ctx context.Context, userId string) ([]*models.TagCount, error) {
+	return ms.repo.ListTags(ctx, userId)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NesterovYehor/TextNest/pkg/kafka"
+)
+
+// PasteUpdatedTopic carries the pastes whose metadata was edited, the download service drops its cached copy.
+const PasteUpdatedTopic = "paste-updated"
+
+// PasteUpdated is the message published to PasteUpdatedTopic.
+type PasteUpdated struct {
+	Key string `json:"key"`
+}
+
+// PasteEventService announces changes of pastes to the other services.
+type PasteEventService struct {
+	producer *kafka.KafkaProducer // nil when Kafka is not configured
+}
+
+func NewPasteEventService(producer *kafka.KafkaProducer) *PasteEventService {
+	return &PasteEventService{producer: producer}
+}
+
+func (srv *PasteEventService) PasteUpdated(key string) error {
+	if srv.producer == nil {
+		return nil
+	}
+	message, err := json.Marshal(PasteUpdated{Key: key})
+	if err != nil {
+		return fmt.Errorf("failed to encode paste update: %w", err)
+	}
+	if err := srv.producer.ProduceMessages(string(message), PasteUpdatedTopic); err != nil {
+		return fmt.Errorf("failed to publish paste update: %w", err)
+	}
+	return nil
+}
@@ -2,10 +2,14 @@
 package validation
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/NesterovYehor/TextNest/pkg/validator"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
+	"github.com/alecthomas/chroma/v2/lexers"
 )
 
 // ValidateMetaData performs validation checks on metadata
@@ -13,5 +17,89 @@ func ValidateMetaData(metadata *pb.UploadPasteRequest) *validator.Validator {
 	v := validator.New()
 	v.Check(len([]rune(metadata.Key)) == 8, "key", "Key should be 8 characters long")
 	v.Check(metadata.ExpirationDate.AsTime().After(time.Now()), "expiration_date", "Expiration date must be in the future")
+	v.Check(len(metadata.Password) <= 72, "password", "Password must not be longer than 72 bytes")
+	v.Check(metadata.MaxViews >= 0, "max_views", "Max views must not be negative")
+	v.Check(!metadata.BurnAfterRead || metadata.MaxViews <= 1, "max_views", "Burn after read pastes can be viewed only once")
+	v.Check(pb.Visibility_name[int32(metadata.Visibility)] != "", "visibility", "Unknown visibility")
+	ownerOnly := metadata.Visibility == pb.Visibility_VISIBILITY_PRIVATE || metadata.Visibility == pb.Visibility_VISIBILITY_SHARED
+	v.Check(!ownerOnly || metadata.UserId != "", "visibility", "Private and shared pastes require an owner")
+	if metadata.Visibility == pb.Visibility_VISIBILITY_SHARED {
+		v.Check(len(metadata.SharedWith) > 0, "shared_with", "Shared pastes must be shared with at least one user")
+	} else {
+		v.Check(len(metadata.SharedWith) == 0, "shared_with", "Only shared pastes can have a list of users")
+	}
+	if metadata.Encryption != nil {
+		validateEncryptionHeader(v, metadata.Encryption)
+	}
+	v.Check(len(metadata.Tags) <= models.MaxPasteTags, "tags", fmt.Sprintf("A paste can have at most %d tags", models.MaxPasteTags))
+	checkTags(v, "tags", metadata.Tags)
+	v.Check(metadata.Language == "" || lexers.Get(metadata.Language) != nil, "language", "Unknown language")
+	v.Check(len(metadata.Filename) <= 255, "filename", "File name must not be longer than 255 bytes")
 	return v
 }
+
+// ValidateMetadataUpdate checks an edit of the title, expiration and tags of a paste.
+func ValidateMetadataUpdate(req *pb.UpdatePasteMetadataRequest) *validator.Validator {
+	v := validator.New()
+	v.Check(req.Version > 0, "version", "Version of the metadata the edit was made against must be provided")
+	v.Check(req.Title != nil || req.ExpirationDate != nil || len(req.AddTags) > 0 || len(req.RemoveTags) > 0, "metadata", "At least one change must be provided")
+	if req.ExpirationDate != nil {
+		v.Check(req.ExpirationDate.AsTime().After(time.Now()), "expiration_date", "Expiration date must be in the future")
+	}
+	v.Check(len(req.AddTags) <= models.MaxPasteTags, "add_tags", fmt.Sprintf("A paste can have at most %d tags", models.MaxPasteTags))
+	checkTags(v, "add_tags", req.AddTags)
+	checkTags(v, "remove_tags", req.RemoveTags)
+	return v
+}
+
+// ValidateCollectionName checks the name of a collection, surrounding whitespace must be trimmed first.
+func ValidateCollectionName(name string) *validator.Validator {
+	v := validator.New()
+	v.Check(name != "" && len([]rune(name)) <= 100, "name", "Name must be between 1 and 100 characters long")
+	return v
+}
+
+func checkTags(v *validator.Validator, field string, tags []string) {
+	for _, tag := range tags {
+		v.Check(tag != "" && len([]rune(tag)) <= 50, field, "Tags must be between 1 and 50 characters long")
+	}
+}
+
+// MaxBundleFiles is the largest number of files a bundle paste can hold.
+const MaxBundleFiles = 50
+
+// ValidatePasteFile checks the header of a file of a bundle. Names are plain file names,
+// they end up as entries of tar and zip archives and must not escape the archive root.
+func ValidatePasteFile(file *pb.PasteFile) *validator.Validator {
+	v := validator.New()
+	v.Check(file.Name != "" && len(file.Name) <= 255, "file.name", "File name must be between 1 and 255 bytes long")
+	v.Check(!strings.ContainsAny(file.Name, "/\\\x00"), "file.name", "File name must not contain slashes or NUL bytes")
+	v.Check(file.Name != "." && file.Name != "..", "file.name", "File name must not be . or ..")
+	v.Check(file.Language == "" || lexers.Get(file.Language) != nil, "file.language", "Unknown language")
+	return v
+}
+
+// nonceSizes maps the supported content ciphers to the nonce length they require.
+var nonceSizes = map[string]int{
+	"AES-256-GCM":        12,
+	"XChaCha20-Poly1305": 24,
+}
+
+// validateEncryptionHeader checks the shape of a client side encryption header. Error messages
+// must never include the header values.
+func validateEncryptionHeader(v *validator.Validator, header *pb.EncryptionHeader) {
+	nonceSize, ok := nonceSizes[header.Algorithm]
+	v.Check(ok, "encryption.algorithm", "Algorithm must be AES-256-GCM or XChaCha20-Poly1305")
+	v.Check(!ok || len(header.Nonce) == nonceSize, "encryption.nonce", "Nonce length does not match the algorithm")
+	v.Check(len(header.KeyCheck) > 0, "encryption.key_check", "Key check value must be provided")
+
+	switch header.Kdf {
+	case "none":
+	case "PBKDF2-SHA256", "argon2id":
+		v.Check(len(header.KdfSalt) >= 16, "encryption.kdf_salt", "KDF salt must be at least 16 bytes long")
+		v.Check(header.KdfIterations > 0, "encryption.kdf_iterations", "KDF iterations must be positive")
+		v.Check(header.Kdf != "argon2id" || header.KdfMemoryKib > 0, "encryption.kdf_memory_kib", "argon2id requires a memory cost")
+	default:
+		v.Check(false, "encryption.kdf", "KDF must be none, PBKDF2-SHA256 or argon2id")
+	}
+}
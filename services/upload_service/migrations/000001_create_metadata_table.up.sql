@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS metadata (
+    key VARCHAR NOT NULL UNIQUE,
+    title TEXT,
+    user_id TEXT DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    expiration_date TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS paste_versions (
+    key VARCHAR NOT NULL,
+    version INTEGER NOT NULL,
+    object_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (key, version)
+);
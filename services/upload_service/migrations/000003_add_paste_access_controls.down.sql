@@ -0,0 +1,3 @@
+ALTER TABLE metadata
+    DROP COLUMN IF EXISTS max_views,
+    DROP COLUMN IF EXISTS password_hash;
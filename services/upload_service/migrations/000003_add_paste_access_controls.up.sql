@@ -0,0 +1,3 @@
+ALTER TABLE metadata
+    ADD COLUMN IF NOT EXISTS password_hash BYTEA DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS max_views INTEGER DEFAULT NULL CHECK (max_views >= 0);
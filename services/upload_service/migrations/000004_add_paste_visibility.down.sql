@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS paste_acl;
+
+ALTER TABLE metadata
+    DROP COLUMN IF EXISTS visibility;
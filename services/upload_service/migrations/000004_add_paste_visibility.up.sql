@@ -0,0 +1,10 @@
+ALTER TABLE metadata
+    ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'unlisted', 'private', 'shared'));
+
+CREATE TABLE IF NOT EXISTS paste_acl (
+    key VARCHAR NOT NULL REFERENCES metadata (key) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (key, user_id)
+);
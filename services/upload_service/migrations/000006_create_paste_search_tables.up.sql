@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS paste_tags (
+    key VARCHAR NOT NULL REFERENCES metadata (key) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (key, tag)
+);
+
+CREATE TABLE IF NOT EXISTS paste_search (
+    key VARCHAR PRIMARY KEY REFERENCES metadata (key) ON DELETE CASCADE,
+    content TEXT NOT NULL DEFAULT '',
+    document TSVECTOR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS paste_search_document_idx ON paste_search USING GIN (document);
@@ -0,0 +1,2 @@
+ALTER TABLE metadata
+    ADD COLUMN IF NOT EXISTS language TEXT DEFAULT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS paste_files (
+    key VARCHAR NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    language TEXT DEFAULT NULL,
+    size BIGINT NOT NULL,
+    object_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (key, position),
+    UNIQUE (key, name)
+);
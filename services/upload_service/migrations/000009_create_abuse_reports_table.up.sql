@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS abuse_reports (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR NOT NULL REFERENCES metadata (key) ON DELETE CASCADE,
+    reporter_id TEXT DEFAULT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    resolved_by TEXT DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS abuse_reports_open_idx ON abuse_reports (created_at) WHERE resolved_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS metadata_pending_created_at_idx;
+ALTER TABLE metadata DROP COLUMN IF EXISTS content_type;
+ALTER TABLE metadata DROP COLUMN IF EXISTS checksum;
+ALTER TABLE metadata DROP COLUMN IF EXISTS size;
+ALTER TABLE metadata DROP COLUMN IF EXISTS status;
@@ -0,0 +1,8 @@
+-- Pastes uploaded through a presigned URL are pending until CompleteUpload found their content
+-- in storage. The cleanup service reaps pending pastes that are never completed.
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('pending', 'active'));
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS size BIGINT DEFAULT NULL;
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS checksum TEXT DEFAULT NULL;
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS content_type TEXT DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS metadata_pending_created_at_idx ON metadata (created_at) WHERE status = 'pending';
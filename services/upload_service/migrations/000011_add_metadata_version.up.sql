@@ -0,0 +1,3 @@
+-- Counts the edits of the title, expiration and tags. An edit only applies to the version it
+-- was made against, so concurrent edits cannot silently overwrite each other.
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS metadata_version BIGINT NOT NULL DEFAULT 1;
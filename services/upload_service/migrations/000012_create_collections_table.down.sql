@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS metadata_collection_id_idx;
+ALTER TABLE metadata DROP COLUMN IF EXISTS collection_id;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,13 @@
+-- Named folders users organize their pastes in. A paste is in at most one collection, deleting
+-- a collection keeps its pastes.
+CREATE TABLE IF NOT EXISTS collections (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS collection_id BIGINT DEFAULT NULL REFERENCES collections (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS metadata_collection_id_idx ON metadata (collection_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS metadata_user_created_at_key_idx;
@@ -0,0 +1,3 @@
+-- Serves the keyset pagination of DownloadByUserId, which lists the active pastes of a user
+-- newest first and continues after the (created_at, key) of the last paste of a page.
+CREATE INDEX IF NOT EXISTS metadata_user_created_at_key_idx ON metadata (user_id, created_at DESC, key DESC) WHERE status = 'active';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS paste_versions_pending_created_at_idx;
+ALTER TABLE paste_versions DROP COLUMN IF EXISTS status;
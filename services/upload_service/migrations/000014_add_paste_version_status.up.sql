@@ -0,0 +1,5 @@
+-- Revisions requested through UploadUpdates are pending until CompleteUpload found their content
+-- in storage. Only active revisions are served, abandoned pending ones are reaped by the cleanup service.
+ALTER TABLE paste_versions ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('pending', 'active'));
+
+CREATE INDEX IF NOT EXISTS paste_versions_pending_created_at_idx ON paste_versions (created_at) WHERE status = 'pending';
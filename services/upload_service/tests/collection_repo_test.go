@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollections(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	metadataRepo := repository.NewMetadataRepository(db)
+	repo := repository.NewCollectionRepository(db)
+
+	paste := &pb.UploadPasteRequest{
+		Key:            testData.Key,
+		UserId:         testData.UserId,
+		ExpirationDate: testData.ExpirationDate,
+		Title:          testData.Title,
+		Tags:           []string{"go", "snippet"},
+	}
+	assert.NoError(t, metadataRepo.InsertPasteMetadata(ctx, paste, nil, 0))
+
+	work, err := repo.Insert(ctx, paste.UserId, "work")
+	assert.NoError(t, err)
+	assert.Equal(t, "work", work.Name)
+
+	// Names are unique per user only
+	_, err = repo.Insert(ctx, paste.UserId, "work")
+	assert.ErrorIs(t, err, repository.ErrDuplicateCollection)
+	_, err = repo.Insert(ctx, "other-user", "work")
+	assert.NoError(t, err)
+
+	// Pastes can only be moved into collections of their owner
+	assert.NoError(t, repo.MovePaste(ctx, paste.Key, paste.UserId, work.ID))
+	assert.ErrorIs(t, repo.MovePaste(ctx, paste.Key, "other-user", work.ID), repository.ErrCollectionNotFound)
+	assert.ErrorIs(t, repo.MovePaste(ctx, "missing-key", paste.UserId, 0), sql.ErrNoRows)
+
+	renamed, err := repo.Rename(ctx, work.ID, paste.UserId, "archive")
+	assert.NoError(t, err)
+	assert.Equal(t, "archive", renamed.Name)
+	assert.Equal(t, int32(1), renamed.PasteCount)
+
+	collections, err := repo.List(ctx, paste.UserId)
+	assert.NoError(t, err)
+	if assert.Len(t, collections, 1) {
+		assert.Equal(t, "archive", collections[0].Name)
+		assert.Equal(t, int32(1), collections[0].PasteCount)
+	}
+
+	tags, err := metadataRepo.ListTags(ctx, paste.UserId)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	// Deleting a collection keeps its pastes
+	assert.NoError(t, repo.Delete(ctx, work.ID, paste.UserId))
+	assert.ErrorIs(t, repo.Delete(ctx, work.ID, paste.UserId), repository.ErrCollectionNotFound)
+	var collectionID sql.NullInt64
+	err = db.QueryRowContext(ctx, "SELECT collection_id FROM metadata WHERE key = $1", paste.Key).Scan(&collectionID)
+	assert.NoError(t, err)
+	assert.False(t, collectionID.Valid)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadContent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	repo := repository.NewContentRepository(store)
+
+	content := strings.Repeat("paste content ", 1024)
+	err = repo.UploadContent(ctx, testData.Key, strings.NewReader(content))
+	assert.NoError(t, err)
+
+	info, err := store.StatPaste(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+
+	data, err := store.GetPaste(testData.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	err = repo.DeleteContent(ctx, testData.Key)
+	assert.NoError(t, err)
+
+	_, err = store.StatPaste(ctx, testData.Key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestGenerateUploadURL(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	repo := repository.NewContentRepository(storage.NewMemoryStorage())
+
+	url, err := repo.GenerateUploadURL(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.Contains(t, url, testData.Key)
+}
+
+func TestReadContentHead(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	repo := repository.NewContentRepository(store)
+
+	content := strings.Repeat("paste content ", 1024)
+	assert.NoError(t, repo.UploadContent(ctx, testData.Key, strings.NewReader(content)))
+
+	head, err := repo.ReadContentHead(ctx, testData.Key, 512)
+	assert.NoError(t, err)
+	assert.Equal(t, content[:512], string(head))
+}
+
+func TestCopyContent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	repo := repository.NewContentRepository(store)
+
+	uploadKey := storage.UploadObjectKey(testData.Key, 1)
+	objectKey := storage.VersionObjectKey(testData.Key, 1)
+	assert.NoError(t, repo.UploadContent(ctx, uploadKey, strings.NewReader("first")))
+	assert.NoError(t, repo.CopyContent(ctx, uploadKey, objectKey))
+
+	// Writing to the upload key again does not change the copied content
+	assert.NoError(t, repo.UploadContent(ctx, uploadKey, strings.NewReader("second")))
+	data, err := store.GetPaste(objectKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+
+	err = repo.CopyContent(ctx, storage.UploadObjectKey(testData.Key, 2), objectKey)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,18 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLanguage(t *testing.T) {
+	// An explicit language is normalized to its canonical name
+	assert.Equal(t, "python", services.ResolveLanguage("py", "", nil))
+	// The file name is used when no language is given
+	assert.Equal(t, "go", services.ResolveLanguage("", "main.go", nil))
+	// The content is the last resort
+	assert.Equal(t, "bash", services.ResolveLanguage("", "", []byte("#!/bin/bash\necho hello\n")))
+	assert.Equal(t, "", services.ResolveLanguage("", "", []byte("just some words")))
+}
@@ -2,10 +2,14 @@ package tests
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/NesterovYehor/TextNest/pkg/storage"
 	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/models"
 	"github.com/NesterovYehor/TextNest/services/upload_service/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -30,7 +34,7 @@ func TestUploadPaste(t *testing.T) {
 	repo := repository.NewMetadataRepository(db)
 
 	// Insert metadata
-	err := repo.InsertPasteMetadata(ctx, testData)
+	err := repo.InsertPasteMetadata(ctx, testData, nil, 0)
 	assert.NoError(t, err, "Failed to insert paste metadata")
 
 	// Query DB directly to verify insertion
@@ -53,7 +57,7 @@ func TestUpdatePaste(t *testing.T) {
 	repo := repository.NewMetadataRepository(db)
 
 	// Insert test data
-	assert.NoError(t, repo.InsertPasteMetadata(ctx, testData))
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, testData, nil, 0))
 
 	// Perform the update
 	newExpiration := time.Now().Add(time.Hour) // Set expiration an hour ahead
@@ -68,3 +72,284 @@ func TestUpdatePaste(t *testing.T) {
 	// Check if the expiration date matches (allowing a small margin)
 	assert.WithinDuration(t, newExpiration, expirationDate, time.Second, "Expiration date mismatch")
 }
+
+func TestInsertNextVersion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	// The first version is created together with the metadata
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, testData, nil, 0))
+
+	version, err := repo.InsertNextVersion(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), version)
+
+	var objectKey string
+	err = db.QueryRowContext(ctx, "SELECT object_key FROM paste_versions WHERE key = $1 AND version = $2", testData.Key, version).
+		Scan(&objectKey)
+	assert.NoError(t, err, "Failed to retrieve inserted version")
+	assert.Equal(t, storage.VersionObjectKey(testData.Key, 2), objectKey)
+
+	// New revisions are pending until their upload is completed
+	versionStatus, err := repo.GetVersionStatus(ctx, testData.Key, version)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPending, versionStatus)
+
+	object := &storage.ObjectInfo{Size: 42, ETag: "etag", ContentType: "text/plain"}
+	assert.NoError(t, repo.ActivateVersion(ctx, testData.Key, version, object))
+	versionStatus, err = repo.GetVersionStatus(ctx, testData.Key, version)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusActive, versionStatus)
+	assert.ErrorIs(t, repo.ActivateVersion(ctx, testData.Key, version, object), sql.ErrNoRows)
+
+	var size int64
+	err = db.QueryRowContext(ctx, "SELECT size FROM metadata WHERE key = $1", testData.Key).Scan(&size)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), size)
+}
+
+func TestUploadPasteWithAccessControls(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	passwordHash := []byte("$2a$10$test-hash")
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, testData, passwordHash, 1))
+
+	var storedHash []byte
+	var maxViews int32
+	err := db.QueryRowContext(ctx, "SELECT password_hash, max_views FROM metadata WHERE key = $1", testData.Key).
+		Scan(&storedHash, &maxViews)
+	assert.NoError(t, err, "Failed to retrieve inserted metadata")
+	assert.Equal(t, passwordHash, storedHash)
+	assert.Equal(t, int32(1), maxViews)
+}
+
+func TestUploadSharedPaste(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	shared := &pb.UploadPasteRequest{
+		Key:            "shared01",
+		UserId:         "test-userid",
+		ExpirationDate: timestamppb.New(time.Now().Add(time.Hour)),
+		Visibility:     pb.Visibility_VISIBILITY_SHARED,
+		SharedWith:     []string{"reader-1", "reader-2"},
+	}
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, shared, nil, 0))
+
+	var visibility string
+	err := db.QueryRowContext(ctx, "SELECT visibility FROM metadata WHERE key = $1", shared.Key).Scan(&visibility)
+	assert.NoError(t, err, "Failed to retrieve inserted metadata")
+	assert.Equal(t, "shared", visibility)
+
+	var readers int
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM paste_acl WHERE key = $1", shared.Key).Scan(&readers)
+	assert.NoError(t, err, "Failed to count paste readers")
+	assert.Equal(t, 2, readers)
+}
+
+func TestUploadEncryptedPaste(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	encrypted := &pb.UploadPasteRequest{
+		Key:            "secret01",
+		UserId:         "test-userid",
+		ExpirationDate: timestamppb.New(time.Now().Add(time.Hour)),
+		Encryption: &pb.EncryptionHeader{
+			Algorithm: "AES-256-GCM",
+			Kdf:       "none",
+			Nonce:     make([]byte, 12),
+			KeyCheck:  []byte("check"),
+		},
+	}
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, encrypted, nil, 0))
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, testData, nil, 0))
+
+	isEncrypted, err := repo.IsPasteEncrypted(ctx, encrypted.Key)
+	assert.NoError(t, err)
+	assert.True(t, isEncrypted)
+
+	isEncrypted, err = repo.IsPasteEncrypted(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.False(t, isEncrypted)
+
+	var algorithm string
+	err = db.QueryRowContext(ctx, "SELECT encryption_header->>'algorithm' FROM metadata WHERE key = $1", encrypted.Key).Scan(&algorithm)
+	assert.NoError(t, err)
+	assert.Equal(t, "AES-256-GCM", algorithm)
+}
+
+func TestIndexContent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	tagged := &pb.UploadPasteRequest{
+		Key:            "tagged01",
+		UserId:         "test-userid",
+		Title:          "nginx config",
+		ExpirationDate: timestamppb.New(time.Now().Add(time.Hour)),
+		Tags:           []string{"infra"},
+	}
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, tagged, nil, 0))
+
+	search := func(query string) []string {
+		rows, err := db.QueryContext(ctx, "SELECT key FROM paste_search WHERE document @@ websearch_to_tsquery('simple', $1)", query)
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		var keys []string
+		for rows.Next() {
+			var key string
+			assert.NoError(t, rows.Scan(&key))
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	// Title and tags are indexed together with the metadata
+	assert.Equal(t, []string{tagged.Key}, search("nginx"))
+	assert.Equal(t, []string{tagged.Key}, search("infra"))
+	assert.Empty(t, search("upstream"))
+
+	assert.NoError(t, repo.IndexContent(ctx, tagged.Key, "upstream backend { server 127.0.0.1; }"))
+	assert.Equal(t, []string{tagged.Key}, search("upstream"))
+	assert.Equal(t, []string{tagged.Key}, search("nginx"))
+}
+
+func TestUploadBundle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+
+	files := []*models.PasteFile{
+		{Position: 0, Name: "Dockerfile", Language: "docker", Size: 120, ObjectKey: storage.FileObjectKey(testData.Key, 0)},
+		{Position: 1, Name: "compose.yaml", Language: "yaml", Size: 300, ObjectKey: storage.FileObjectKey(testData.Key, 1)},
+		{Position: 2, Name: ".env.example", Size: 40, ObjectKey: storage.FileObjectKey(testData.Key, 2)},
+	}
+	assert.NoError(t, repo.InsertBundleMetadata(ctx, testData, nil, 0, files))
+
+	var count int
+	assert.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM paste_files WHERE key = $1", testData.Key).Scan(&count))
+	assert.Equal(t, len(files), count)
+
+	// Bundles have no revisions
+	assert.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM paste_versions WHERE key = $1", testData.Key).Scan(&count))
+	assert.Zero(t, count)
+
+	bundle, err := repo.IsPasteBundle(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.True(t, bundle)
+}
+
+func TestActivatePendingPaste(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+	assert.NoError(t, repo.InsertPendingPasteMetadata(ctx, testData, nil, 0))
+
+	status, err := repo.GetPasteStatus(ctx, testData.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPending, status)
+
+	object := &storage.ObjectInfo{Key: storage.VersionObjectKey(testData.Key, 1), Size: 42, ETag: "9e107d9d372bb6826bd81d3542a419d6", ContentType: "text/plain; charset=utf-8"}
+	assert.NoError(t, repo.ActivatePaste(ctx, testData.Key, object))
+
+	var size int64
+	var checksum, contentType string
+	err = db.QueryRowContext(ctx, "SELECT status, size, checksum, content_type FROM metadata WHERE key = $1", testData.Key).
+		Scan(&status, &size, &checksum, &contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusActive, status)
+	assert.Equal(t, object.Size, size)
+	assert.Equal(t, object.ETag, checksum)
+	assert.Equal(t, object.ContentType, contentType)
+
+	assert.ErrorIs(t, repo.ActivatePaste(ctx, "missing-key", object), sql.ErrNoRows)
+}
+
+func TestUpdateMetadata(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	repo := repository.NewMetadataRepository(db)
+	paste := &pb.UploadPasteRequest{
+		Key:            testData.Key,
+		UserId:         testData.UserId,
+		ExpirationDate: testData.ExpirationDate,
+		Title:          testData.Title,
+		Tags:           []string{"go", "snippet"},
+	}
+	assert.NoError(t, repo.InsertPasteMetadata(ctx, paste, nil, 0))
+
+	title := "new title"
+	expirationDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	metadata, err := repo.UpdateMetadata(ctx, paste.Key, &models.MetadataChange{
+		Version:        1,
+		Title:          &title,
+		ExpirationDate: &expirationDate,
+		AddTags:        []string{"cli"},
+		RemoveTags:     []string{"snippet"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, title, metadata.Title)
+	assert.True(t, expirationDate.Equal(metadata.ExpirationDate))
+	assert.Equal(t, []string{"cli", "go"}, metadata.Tags)
+	assert.Equal(t, int64(2), metadata.Version)
+
+	// The new title is searchable
+	var matches bool
+	err = db.QueryRowContext(ctx, "SELECT document @@ to_tsquery('simple', 'new') FROM paste_search WHERE key = $1", paste.Key).Scan(&matches)
+	assert.NoError(t, err)
+	assert.True(t, matches)
+
+	// An edit made against the old version must not overwrite the first one
+	_, err = repo.UpdateMetadata(ctx, paste.Key, &models.MetadataChange{Version: 1, AddTags: []string{"stale"}})
+	assert.ErrorIs(t, err, repository.ErrEditConflict)
+
+	tooMany := make([]string, models.MaxPasteTags)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+	_, err = repo.UpdateMetadata(ctx, paste.Key, &models.MetadataChange{Version: 2, AddTags: tooMany})
+	assert.ErrorIs(t, err, models.ErrTooManyTags)
+
+	_, err = repo.UpdateMetadata(ctx, "missing-key", &models.MetadataChange{Version: 1, Title: &title})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
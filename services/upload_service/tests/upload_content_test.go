@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	jsonlog "github.com/NesterovYehor/TextNest/pkg/logger"
+	"github.com/NesterovYehor/TextNest/pkg/storage"
+	pb "github.com/NesterovYehor/TextNest/services/upload_service/api"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/config"
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/coordinators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startUploadServer serves the upload coordinator over an in-memory connection. Every finished
+// call reports its error on the returned channel, so a test can wait for the server to clean up.
+func startUploadServer(t *testing.T, db *sql.DB, cfg *config.Config) (pb.PasteUploadClient, <-chan error) {
+	t.Helper()
+
+	coord, err := coordinators.NewUploadCoordinator(cfg, jsonlog.New(io.Discard, slog.LevelError), db, nil)
+	require.NoError(t, err)
+
+	finished := make(chan error, 8)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		finished <- err
+		return err
+	}))
+	pb.RegisterPasteUploadServer(srv, coord)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewPasteUploadClient(conn), finished
+}
+
+func TestUploadContentStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	root := t.TempDir()
+	store, err := storage.NewLocalStorage(root)
+	require.NoError(t, err)
+	client, finished := startUploadServer(t, db, &config.Config{
+		Storage: &storage.Config{Driver: storage.DriverLocal, Path: root},
+		Limits:  &config.UploadLimits{MaxSize: map[string]int64{"user": 16}, AllowedTypes: []string{"text/*"}},
+	})
+
+	metadata := func(key string) *pb.UploadContentRequest {
+		return &pb.UploadContentRequest{Data: &pb.UploadContentRequest_Metadata{Metadata: &pb.UploadPasteRequest{
+			Key:            key,
+			UserId:         testData.UserId,
+			ExpirationDate: timestamppb.New(time.Now().Add(time.Hour)),
+			Tier:           "user",
+		}}}
+	}
+	chunk := func(data string) *pb.UploadContentRequest {
+		return &pb.UploadContentRequest{Data: &pb.UploadContentRequest_Chunk{Chunk: []byte(data)}}
+	}
+	assertNothingStored := func(t *testing.T, key string) {
+		var state string
+		err := db.QueryRowContext(ctx, "SELECT status FROM metadata WHERE key = $1", key).Scan(&state)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = store.StatPaste(ctx, storage.VersionObjectKey(key, 1))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	}
+
+	t.Run("stores the content and activates the paste", func(t *testing.T) {
+		stream, err := client.UploadContent(ctx)
+		require.NoError(t, err)
+		for _, req := range []*pb.UploadContentRequest{metadata("stream01"), chunk("hello "), chunk("world")} {
+			require.NoError(t, stream.Send(req))
+		}
+		res, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		assert.Equal(t, "stream01", res.Key)
+		assert.Equal(t, int64(11), res.Size)
+		<-finished
+
+		var state string
+		err = db.QueryRowContext(ctx, "SELECT status FROM metadata WHERE key = $1", "stream01").Scan(&state)
+		assert.NoError(t, err)
+		assert.Equal(t, "active", state)
+		data, err := store.GetPaste(storage.VersionObjectKey("stream01", 1))
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(data))
+	})
+
+	t.Run("aborted stream leaves nothing behind", func(t *testing.T) {
+		streamCtx, abort := context.WithCancel(ctx)
+		stream, err := client.UploadContent(streamCtx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(metadata("stream02")))
+		require.NoError(t, stream.Send(chunk("hello")))
+		abort()
+
+		assert.Error(t, <-finished)
+		assertNothingStored(t, "stream02")
+	})
+
+	t.Run("oversize content is rejected", func(t *testing.T) {
+		stream, err := client.UploadContent(ctx)
+		require.NoError(t, err)
+		for _, req := range []*pb.UploadContentRequest{metadata("stream03"), chunk("0123456789"), chunk("0123456789")} {
+			require.NoError(t, stream.Send(req))
+		}
+		_, err = stream.CloseAndRecv()
+		<-finished
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		if assert.Len(t, st.Details(), 1) {
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			if assert.True(t, ok) {
+				assert.Equal(t, coordinators.ReasonContentTooLarge, info.Reason)
+				assert.Equal(t, "16", info.Metadata["max_size"])
+			}
+		}
+		assertNothingStored(t, "stream03")
+	})
+}
+
+func TestCompleteUploadIndexesContent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	db, cleanup := SetUpPostgres(ctx, t)
+	defer cleanup()
+
+	root := t.TempDir()
+	store, err := storage.NewLocalStorage(root)
+	require.NoError(t, err)
+	client, _ := startUploadServer(t, db, &config.Config{
+		Storage: &storage.Config{Driver: storage.DriverLocal, Path: root},
+		Limits:  &config.UploadLimits{AllowedTypes: []string{"text/*"}},
+	})
+
+	_, err = client.UploadPaste(ctx, &pb.UploadPasteRequest{
+		Key:            "presign1",
+		UserId:         testData.UserId,
+		ExpirationDate: timestamppb.New(time.Now().Add(time.Hour)),
+		Tier:           "user",
+	})
+	require.NoError(t, err)
+
+	// The client writes to the presigned URL, which is the upload object of the first version
+	require.NoError(t, store.UploadPaste(storage.UploadObjectKey("presign1", 1), []byte("server {\n    listen 8080;\n}\n")))
+	object, err := store.StatPaste(ctx, storage.UploadObjectKey("presign1", 1))
+	require.NoError(t, err)
+	_, err = client.CompleteUpload(ctx, &pb.CompleteUploadRequest{Key: "presign1", UserId: testData.UserId, Checksum: object.ETag})
+	require.NoError(t, err)
+
+	var key string
+	err = db.QueryRowContext(ctx, "SELECT key FROM paste_search WHERE document @@ websearch_to_tsquery('simple', $1)", "listen").Scan(&key)
+	assert.NoError(t, err)
+	assert.Equal(t, "presign1", key)
+}
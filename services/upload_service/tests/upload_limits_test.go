@@ -0,0 +1,27 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/NesterovYehor/TextNest/services/upload_service/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadLimits(t *testing.T) {
+	limits := &config.UploadLimits{
+		MaxSize:      map[string]int64{"admin": 50 << 20},
+		AllowedTypes: []string{"text/*", "application/json"},
+	}
+
+	// Roles without a configured limit fall back to the default
+	assert.Equal(t, int64(50<<20), limits.MaxSizeFor("admin"))
+	assert.Equal(t, int64(config.DefaultMaxUploadSize), limits.MaxSizeFor("user"))
+
+	// Parameters of the detected type are ignored, wildcards match every subtype
+	assert.True(t, limits.Allows("text/plain; charset=utf-8"))
+	assert.True(t, limits.Allows("text/html"))
+	assert.True(t, limits.Allows("application/json"))
+	assert.False(t, limits.Allows("application/octet-stream"))
+	assert.False(t, limits.Allows("image/png"))
+	assert.False(t, limits.Allows(""))
+}